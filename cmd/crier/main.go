@@ -80,6 +80,8 @@ type options struct {
 	reportAgent string
 
 	resultstoreArtifactsDirOnly bool
+
+	artifactIndexDir string
 }
 
 func (o *options) validate() error {
@@ -134,6 +136,7 @@ func (o *options) parseArgs(fs *flag.FlagSet, args []string) error {
 	fs.StringVar(&o.slackTokenFile, "slack-token-file", "", "Path to a Slack token file")
 	fs.StringVar(&o.reportAgent, "report-agent", "", "Only report specified agent - empty means report to all agents (effective for github and Slack only)")
 	fs.IntVar(&o.resultStoreWorkers, "resultstore-workers", 0, "Number of ResultStore report workers (0 means disabled)")
+	fs.StringVar(&o.artifactIndexDir, "artifact-index-dir", "", "Storage path (e.g. gs://bucket/index) of an index of the artifacts of finished jobs, for gangway's --artifact-index-dir. If set, the blob storage reporter records the storage path of the artifacts of every finished job in it.")
 	fs.BoolVar(&o.resultstoreArtifactsDirOnly, "resultstore-artifacts-dir-only", false, "Report the artifacts/ dir instead of subtree files (testing)")
 
 	// TODO(krzyzacy): implement dryrun for gerrit/pubsub
@@ -283,7 +286,7 @@ func main() {
 	if o.blobStorageWorkers > 0 || o.k8sBlobStorageWorkers > 0 {
 		hasReporter = true
 		if o.blobStorageWorkers > 0 {
-			if err := crier.New(mgr, gcsreporter.New(cfg, opener, o.dryrun), o.blobStorageWorkers, o.githubEnablement.EnablementChecker()); err != nil {
+			if err := crier.New(mgr, gcsreporter.New(cfg, opener, o.artifactIndexDir, o.dryrun), o.blobStorageWorkers, o.githubEnablement.EnablementChecker()); err != nil {
 				logrus.WithError(err).Fatal("failed to construct gcsreporter controller")
 			}
 		}
@@ -294,7 +297,15 @@ func main() {
 				logrus.WithError(err).Fatal("Error building pod client sets for Kubernetes GCS workers")
 			}
 
-			k8sGcsReporter := k8sgcsreporter.New(cfg, opener, k8sgcsreporter.NewK8sResourceGetter(coreClients), float32(o.k8sReportFraction), o.dryrun)
+			resourceGetter := k8sgcsreporter.NewK8sResourceGetter(coreClients)
+			if cfg().Plank.WaitForPodInfoUpload {
+				// The reporter checks on the pods of all pending jobs until
+				// they finished, so it reads them from informers.
+				if err := resourceGetter.WatchPods(interrupts.Context(), cfg().PodNamespace); err != nil {
+					logrus.WithError(err).Fatal("Error watching the pods of the build clusters")
+				}
+			}
+			k8sGcsReporter := k8sgcsreporter.New(cfg, opener, resourceGetter, float32(o.k8sReportFraction), o.dryrun)
 			if err := crier.New(mgr, k8sGcsReporter, o.k8sBlobStorageWorkers, o.githubEnablement.EnablementChecker()); err != nil {
 				logrus.WithError(err).Fatal("failed to construct k8sgcsreporter controller")
 			}
@@ -142,6 +142,9 @@ type options struct {
 	controllerManager     prowflagutil.ControllerManagerOptions
 	dryRun                bool
 	tenantIDs             prowflagutil.Strings
+	podLogCacheSize       int
+	podLogCacheLogSize    int
+	podLogCacheTTL        time.Duration
 }
 
 func (o *options) Validate() error {
@@ -163,6 +166,16 @@ func (o *options) Validate() error {
 	if (o.hiddenOnly && o.showHidden) || (o.tenantIDs.Strings() != nil && (o.hiddenOnly || o.showHidden)) {
 		return errors.New("'--hidden-only', '--tenant-id', and '--show-hidden' are mutually exclusive, 'hidden-only' shows only hidden job, '--tenant-id' shows all jobs with matching ID and 'show-hidden' shows both hidden and non-hidden jobs")
 	}
+
+	if o.podLogCacheSize < 0 {
+		return errors.New("--pod-log-cache-size must not be negative")
+	}
+	if o.podLogCacheLogSize < 0 {
+		return errors.New("--pod-log-cache-log-size must not be negative")
+	}
+	if o.podLogCacheTTL < 0 {
+		return errors.New("--pod-log-cache-ttl must not be negative")
+	}
 	return nil
 }
 
@@ -188,6 +201,9 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	fs.BoolVar(&o.allowInsecure, "allow-insecure", false, "Allows insecure requests for CSRF and GitHub oauth.")
 	fs.BoolVar(&o.dryRun, "dry-run", false, "Whether or not to make mutating API calls to GitHub.")
 	fs.Var(&o.tenantIDs, "tenant-id", "The tenantID(s) used by the ProwJobs that should be displayed by this instance of Deck. This flag can be repeated.")
+	fs.IntVar(&o.podLogCacheSize, "pod-log-cache-size", 64<<20, "Maximum total size in bytes of the container logs of completed jobs to cache in memory. Set to 0 to disable the cache.")
+	fs.IntVar(&o.podLogCacheLogSize, "pod-log-cache-log-size", 4<<20, "Maximum size in bytes of a container log to cache in memory. Larger logs are always streamed from the build cluster.")
+	fs.DurationVar(&o.podLogCacheTTL, "pod-log-cache-ttl", 15*time.Minute, "How long to cache the container logs of completed jobs. Set to 0 to cache them until they are evicted.")
 	o.config.AddFlags(fs)
 	o.instrumentation.AddFlags(fs)
 	o.controllerManager.TimeoutListingProwJobsDefault = 30 * time.Second
@@ -447,6 +463,9 @@ func main() {
 	})
 
 	ja := jobs.NewJobAgent(context.Background(), pjListingClient, o.hiddenOnly, o.showHidden, o.tenantIDs.Strings(), podLogClients, cfg)
+	if o.podLogCacheSize > 0 {
+		ja.SetPodLogCache(jobs.NewPodLogCache(o.podLogCacheSize, o.podLogCacheLogSize, o.podLogCacheTTL))
+	}
 	ja.Start()
 
 	// setup prod only handlers. These handlers can work with runlocal as long
@@ -536,6 +555,10 @@ func (c *podLogClient) GetLogs(name, container string) ([]byte, error) {
 	return stdio.ReadAll(reader)
 }
 
+func (c *podLogClient) StreamLogs(name, container string) (stdio.ReadCloser, error) {
+	return c.client.GetLogs(name, &coreapi.PodLogOptions{Container: container}).Stream(context.TODO())
+}
+
 type pjListingClientWrapper struct {
 	reader ctrlruntimeclient.Reader
 }
@@ -1441,7 +1464,7 @@ func handlePluginHelp(ha *helpAgent, log *logrus.Entry) http.HandlerFunc {
 }
 
 type logClient interface {
-	GetJobLog(job, id, container string) ([]byte, error)
+	StreamJobLog(job, id, container string) (stdio.ReadCloser, error)
 }
 
 // TODO(spxtr): Cache, rate limit.
@@ -1460,7 +1483,7 @@ func handleLog(lc logClient, log *logrus.Entry) http.HandlerFunc {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		jobLog, err := lc.GetJobLog(job, id, container)
+		jobLog, err := lc.StreamJobLog(job, id, container)
 		if err != nil {
 			http.Error(w, fmt.Sprintf("Log not found: %v", err), http.StatusNotFound)
 			logger := logger.WithError(err)
@@ -1479,7 +1502,8 @@ func handleLog(lc logClient, log *logrus.Entry) http.HandlerFunc {
 			}
 			return
 		}
-		if _, err = w.Write(jobLog); err != nil {
+		defer jobLog.Close()
+		if _, err = stdio.Copy(w, jobLog); err != nil {
 			logger.WithError(err).Warning("Error writing log.")
 		}
 	}
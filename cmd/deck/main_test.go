@@ -33,6 +33,7 @@ import (
 	"reflect"
 	"slices"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -191,9 +192,9 @@ func TestOptions_Validate(t *testing.T) {
 
 type flc int
 
-func (f flc) GetJobLog(job, id, container string) ([]byte, error) {
+func (f flc) StreamJobLog(job, id, container string) (io.ReadCloser, error) {
 	if job == "job" && id == "123" {
-		return []byte("hello"), nil
+		return io.NopCloser(strings.NewReader("hello")), nil
 	}
 	return nil, errors.New("muahaha")
 }
@@ -941,6 +942,9 @@ func Test_gatherOptions(t *testing.T) {
 				spyglassFilesLocation: "/lenses",
 				github:                ghoptions,
 				instrumentation:       flagutil.DefaultInstrumentationOptions(),
+				podLogCacheSize:       64 << 20,
+				podLogCacheLogSize:    4 << 20,
+				podLogCacheTTL:        15 * time.Minute,
 			}
 			if tc.expected != nil {
 				tc.expected(expected)
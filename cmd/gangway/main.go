@@ -53,12 +53,15 @@ const serviceNameForHealthCheckGrpc = ""
 type options struct {
 	client         prowflagutil.KubernetesOptions
 	github         prowflagutil.GitHubOptions
+	storage        prowflagutil.StorageClientOptions
 	port           int
 	cookiefilePath string
 
 	config configflagutil.ConfigOptions
 
 	dryRun                 bool
+	containerStatuses      bool
+	artifactIndexDir       string
 	gracePeriod            time.Duration
 	instrumentationOptions prowflagutil.InstrumentationOptions
 }
@@ -67,9 +70,11 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	var o options
 	fs.IntVar(&o.port, "port", 32000, "TCP port for gRPC.")
 	fs.BoolVar(&o.dryRun, "dry-run", true, "Dry run for testing. Uses API tokens but does not mutate.")
+	fs.BoolVar(&o.containerStatuses, "container-statuses", false, "Look up the pods of job executions on the build clusters to return the statuses of their containers. Requires read access to the pods in the pod namespace of every build cluster.")
+	fs.StringVar(&o.artifactIndexDir, "artifact-index-dir", "", "Storage path (e.g. gs://bucket/index) of an index with an object per job execution, named after its id and holding the storage path of its artifacts. If set, job executions whose ProwJob is gone are looked up from their artifacts. Crier writes the index if started with the same --artifact-index-dir.")
 	fs.DurationVar(&o.gracePeriod, "grace-period", 180*time.Second, "On shutdown, try to handle remaining events for the specified duration. ")
 	fs.StringVar(&o.cookiefilePath, "cookiefile", "", "Path to git http.cookiefile, leave empty for github or anonymous")
-	for _, group := range []flagutil.OptionGroup{&o.client, &o.github, &o.instrumentationOptions, &o.config} {
+	for _, group := range []flagutil.OptionGroup{&o.client, &o.github, &o.storage, &o.instrumentationOptions, &o.config} {
 		group.AddFlags(fs)
 	}
 
@@ -80,7 +85,7 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 
 func (o *options) validate() error {
 	var errs []error
-	for _, group := range []flagutil.OptionGroup{&o.client, &o.github, &o.instrumentationOptions, &o.config} {
+	for _, group := range []flagutil.OptionGroup{&o.client, &o.github, &o.storage, &o.instrumentationOptions, &o.config} {
 		if err := group.Validate(o.dryRun); err != nil {
 			errs = append(errs, err)
 		}
@@ -162,6 +167,25 @@ func main() {
 		ProwJobClient: prowjobClient,
 	}
 
+	if o.containerStatuses {
+		podClients, err := o.client.BuildClusterClients(configAgent.Config().PodNamespace, o.dryRun)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error creating build cluster clients.")
+		}
+		gw.PodClients = map[string]gangway.PodClient{}
+		for cluster, podClient := range podClients {
+			gw.PodClients[cluster] = podClient
+		}
+	}
+
+	if o.artifactIndexDir != "" {
+		opener, err := o.storage.StorageClient(context.Background())
+		if err != nil {
+			logrus.WithError(err).Fatal("Error creating opener.")
+		}
+		gw.ArtifactLookup = &gangway.GCSArtifactLookup{Opener: opener, IndexDir: o.artifactIndexDir}
+	}
+
 	// InRepoConfig getter.
 	if o.config.MoonrakerAddress != "" {
 		moonrakerClient, err := moonraker.NewClient(o.config.MoonrakerAddress, configAgent)
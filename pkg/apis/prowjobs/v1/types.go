@@ -234,6 +234,12 @@ type ProwJobSpec struct {
 	// This behaviour may be superseded by MaxConcurrency field, if it
 	// is set to a constraining value.
 	JobQueueName string `json:"job_queue_name,omitempty"`
+
+	// DependsOn is an optional list of names of ProwJobs in the same
+	// namespace that have to succeed before this job is started. The job
+	// stays triggered while any of them is still running and is errored
+	// if any of them does not succeed.
+	DependsOn []string `json:"depends_on,omitempty"`
 }
 
 func (pjs ProwJobSpec) HasPipelineRunSpec() bool {
@@ -550,6 +556,10 @@ type DecorationConfig struct {
 	// ability to run workloads on designated node.
 	// If these fields are already present in the pod definition, they will be ignored.
 	SchedulingOptions *SchedulingOptions `json:"scheduling_options,omitempty"`
+	// DefaultNodeSelector are node labels the pod of the job has to match, on
+	// top of the NodeSelector in the job's PodSpec. Keys that the PodSpec
+	// already selects on keep the value of the PodSpec.
+	DefaultNodeSelector map[string]string `json:"default_node_selector,omitempty"`
 
 	// PodPendingTimeout defines how long the controller will wait to perform garbage
 	// collection on pending pods. Specific for OrgRepo or Cluster. If not set, it has a fallback inside plank field.
@@ -560,6 +570,10 @@ type DecorationConfig struct {
 	// PodUnscheduledTimeout defines how long the controller will wait to abort a prowjob
 	// stuck in an unscheduled state. Specific for OrgRepo or Cluster. If not set, it has a fallback inside plank field.
 	PodUnscheduledTimeout *metav1.Duration `json:"pod_unscheduled_timeout,omitempty"`
+	// MaxRevivals defines how many times the controller will recreate the pod of a prowjob
+	// that stopped unexpectedly, e.g. because its node got evicted. 0 means the pod is never
+	// recreated. Specific for OrgRepo or Cluster. If not set, it has a fallback inside plank field.
+	MaxRevivals *int `json:"max_revivals,omitempty"`
 
 	// RunAsUser defines UID for process in all containers running in a Pod.
 	// This field will not override the existing ProwJob's PodSecurityContext.
@@ -845,6 +859,10 @@ func (d *DecorationConfig) ApplyDefault(def *DecorationConfig) *DecorationConfig
 		merged.PodUnscheduledTimeout = def.PodUnscheduledTimeout
 	}
 
+	if merged.MaxRevivals == nil {
+		merged.MaxRevivals = def.MaxRevivals
+	}
+
 	if merged.RunAsUser == nil {
 		merged.RunAsUser = def.RunAsUser
 	}
@@ -866,6 +884,9 @@ func (d *DecorationConfig) ApplyDefault(def *DecorationConfig) *DecorationConfig
 	if merged.SchedulingOptions == nil {
 		merged.SchedulingOptions = def.SchedulingOptions
 	}
+	if len(merged.DefaultNodeSelector) == 0 {
+		merged.DefaultNodeSelector = def.DefaultNodeSelector
+	}
 	return &merged
 }
 
@@ -904,6 +925,9 @@ func (d *DecorationConfig) Validate() error {
 	if d.OauthTokenSecret != nil && len(d.SSHKeySecrets) > 0 {
 		return errors.New("both OAuth token and SSH key secrets are specified")
 	}
+	if d.MaxRevivals != nil && *d.MaxRevivals < 0 {
+		return fmt.Errorf("max_revivals: %d must be a non-negative number", *d.MaxRevivals)
+	}
 	return nil
 }
 
@@ -1113,8 +1137,14 @@ type ProwJobStatus struct {
 	StartTime metav1.Time `json:"startTime,omitempty"`
 	// PendingTime is the timestamp for when the job moved from triggered to pending
 	PendingTime *metav1.Time `json:"pendingTime,omitempty"`
+	// PodScheduledTime is the timestamp for when the pod of the job got
+	// scheduled to a node. It is unset for jobs whose pod never got scheduled.
+	PodScheduledTime *metav1.Time `json:"podScheduledTime,omitempty"`
 	// CompletionTime is the timestamp for when the job goes to a final state
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// LastReconcileTime is the timestamp for when plank last changed the
+	// status of the job.
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
 	// +kubebuilder:validation:Enum=scheduling;triggered;pending;success;failure;aborted;error
 	// +kubebuilder:validation:Required
 	State       ProwJobState `json:"state,omitempty"`
@@ -1129,6 +1159,18 @@ type ProwJobStatus struct {
 	// plank. This field should always be the same as
 	// the ProwJob.ObjectMeta.Name field.
 	PodName string `json:"pod_name,omitempty"`
+	// PodQOSClass applies only to ProwJobs fulfilled by
+	// plank. This field is the quality of service class of
+	// the Pod, if plank is configured to record it.
+	PodQOSClass corev1.PodQOSClass `json:"pod_qos_class,omitempty"`
+	// NodeName applies only to ProwJobs fulfilled by
+	// plank. This field is the name of the node the Pod
+	// ran on, if plank is configured to record it.
+	NodeName string `json:"node_name,omitempty"`
+	// NodeZone applies only to ProwJobs fulfilled by
+	// plank. This field is the zone of the node the Pod
+	// ran on, if plank is configured to record it.
+	NodeZone string `json:"node_zone,omitempty"`
 
 	// BuildID is the build identifier vended either by tot
 	// or the snowflake library for this job and used as an
@@ -1187,6 +1229,10 @@ type Pull struct {
 	// a PR #, and some tools want this metadata to help associate the work with a pull request (e.g. some code
 	// scanning services, or chromatic.com).
 	HeadRef string `json:"head_ref,omitempty"`
+	// HeadRepo is the full name (org/repo) of the repository the proposed
+	// change comes from. It differs from the repository of the refs for pull
+	// requests opened from forks.
+	HeadRepo string `json:"head_repo,omitempty"`
 	// Link links to the pull request itself.
 	Link string `json:"link,omitempty"`
 	// CommitLink links to the commit identified by the SHA.
@@ -189,6 +189,13 @@ func (in *DecorationConfig) DeepCopyInto(out *DecorationConfig) {
 		*out = new(SchedulingOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DefaultNodeSelector != nil {
+		in, out := &in.DefaultNodeSelector, &out.DefaultNodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.PodPendingTimeout != nil {
 		in, out := &in.PodPendingTimeout, &out.PodPendingTimeout
 		*out = new(metav1.Duration)
@@ -204,6 +211,11 @@ func (in *DecorationConfig) DeepCopyInto(out *DecorationConfig) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.MaxRevivals != nil {
+		in, out := &in.MaxRevivals, &out.MaxRevivals
+		*out = new(int)
+		**out = **in
+	}
 	if in.RunAsUser != nil {
 		in, out := &in.RunAsUser, &out.RunAsUser
 		*out = new(int64)
@@ -477,6 +489,11 @@ func (in *ProwJobSpec) DeepCopyInto(out *ProwJobSpec) {
 		*out = new(ProwJobDefault)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -498,10 +515,18 @@ func (in *ProwJobStatus) DeepCopyInto(out *ProwJobStatus) {
 		in, out := &in.PendingTime, &out.PendingTime
 		*out = (*in).DeepCopy()
 	}
+	if in.PodScheduledTime != nil {
+		in, out := &in.PodScheduledTime, &out.PodScheduledTime
+		*out = (*in).DeepCopy()
+	}
 	if in.CompletionTime != nil {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
 	if in.PrevReportStates != nil {
 		in, out := &in.PrevReportStates, &out.PrevReportStates
 		*out = make(map[string]ProwJobState, len(*in))
@@ -658,6 +658,42 @@ type Pipeline struct {
 	AllowConcurrentPostsubmitJobs bool `json:"allow_concurrent_postsubmit_jobs,omitempty"`
 }
 
+// DupePolicy describes which of several running presubmits of the same job
+// for the same refs plank keeps, aborting the others.
+type DupePolicy string
+
+const (
+	// DupePolicyPreferNewest keeps the newest job, so that a job for freshly
+	// pushed commits replaces the ones still running for older commits.
+	DupePolicyPreferNewest DupePolicy = "prefer_newest"
+	// DupePolicyPreferOldest keeps the oldest job, so that a job that is
+	// already running is not discarded for a duplicate.
+	DupePolicyPreferOldest DupePolicy = "prefer_oldest"
+)
+
+// PodCreationWindow is a recurring window of time during which plank may
+// create pods on a build cluster.
+type PodCreationWindow struct {
+	// Cron is the cron expression of when the window opens, e.g. "0 22 * * *"
+	// for 10 PM UTC. A time zone can be set with a CRON_TZ= prefix.
+	Cron string `json:"cron"`
+	// Duration is how long the window stays open after it opened.
+	Duration *metav1.Duration `json:"duration"`
+}
+
+// Delay returns how long it takes until the window opens the next time after
+// now, or 0 if the window is open at now.
+func (w PodCreationWindow) Delay(now time.Time) (time.Duration, error) {
+	schedule, err := cronParser.Parse(w.Cron)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cron expression %q: %w", w.Cron, err)
+	}
+	if w.Duration != nil && !schedule.Next(now.Add(-w.Duration.Duration)).After(now) {
+		return 0, nil
+	}
+	return schedule.Next(now).Sub(now), nil
+}
+
 // Plank is config for the plank controller.
 type Plank struct {
 	Controller `json:",inline"`
@@ -670,6 +706,22 @@ type Plank struct {
 	// PodUnscheduledTimeout defines how long the controller will wait to abort a prowjob
 	// stuck in an unscheduled state. Defaults to 5 minutes.
 	PodUnscheduledTimeout *metav1.Duration `json:"pod_unscheduled_timeout,omitempty"`
+	// ImagePullTimeout defines how long the controller will wait to abort a prowjob
+	// whose pod fails to pull an image, e.g. because of a typo in its tag, instead
+	// of waiting for the pod pending timeout. Defaults to 5 minutes, 0 disables it.
+	ImagePullTimeout *metav1.Duration `json:"image_pull_timeout,omitempty"`
+	// AbortGracePeriodSeconds is the grace period in seconds the pods of aborted
+	// prowjobs get when they are deleted, so that their sidecar can finish
+	// uploading artifacts. Defaults to the termination grace period of the pod.
+	AbortGracePeriodSeconds *int64 `json:"abort_grace_period_seconds,omitempty"`
+	// HonorAbortAnnotation makes plank abort jobs that are annotated with
+	// prow.k8s.io/abort=true and delete their pod, whatever state they are
+	// in, unless they are complete already. This allows to abort jobs with
+	// kubectl alone. Defaults to false.
+	HonorAbortAnnotation bool `json:"honor_abort_annotation,omitempty"`
+	// PausedRequeueInterval is how often plank checks whether a job annotated
+	// with prow.k8s.io/paused=true got unpaused. Defaults to 1m.
+	PausedRequeueInterval *metav1.Duration `json:"paused_requeue_interval,omitempty"`
 
 	// MaxRevivals is the maximum number of times a prowjob will be retried in case of an
 	// unexpected stop of the job before being marked as failed. Generally a job is stopped
@@ -730,6 +782,265 @@ type Plank struct {
 	// limit. An example use case would be easier scheduling of jobs using boskos resources.
 	// This mechanism is separate from ProwJob's MaxConcurrency setting.
 	JobQueueCapacities map[string]int `json:"job_queue_capacities,omitempty"`
+
+	// ValidatePodReferences makes plank check that the secrets and configmaps
+	// required by a job's pod exist in the pod namespace before creating the pod.
+	// Jobs referencing missing resources are errored right away instead of
+	// waiting for the pod to time out. Requires permission to get secrets and
+	// configmaps in the pod namespace of every build cluster. Defaults to false.
+	ValidatePodReferences bool `json:"validate_pod_references,omitempty"`
+
+	// VerifyPodNamespace makes plank check that the pod it created for a job
+	// ended up in the pod namespace of the build cluster of the job. Jobs
+	// whose pod ended up elsewhere, e.g. because of misconfigured namespace
+	// routing, are errored instead of plank failing to find their pod and
+	// creating it over and over again. Defaults to false.
+	VerifyPodNamespace bool `json:"verify_pod_namespace,omitempty"`
+
+	// PeriodicMinIntervals maps names of periodic jobs to the minimum time
+	// between the starts of two of their runs. Plank aborts triggered runs of
+	// these jobs that were created less than the given interval after a
+	// previous run got started, e.g. because of a misconfigured interval or
+	// external triggers.
+	PeriodicMinIntervals map[string]*metav1.Duration `json:"periodic_min_intervals,omitempty"`
+
+	// RecordAdmissionSnapshot makes plank record the numbers of pending jobs
+	// overall, of the same job, in the same job queue and in the same build
+	// cluster on each job it starts, in the prow.k8s.io/admission-snapshot
+	// annotation. Defaults to false.
+	RecordAdmissionSnapshot bool `json:"record_admission_snapshot,omitempty"`
+
+	// CompleteOnTerminatedContainers makes plank consider jobs as succeeded
+	// once all containers of their pod except for the pod utilities sidecar
+	// terminated successfully, even if the pod phase is still Running, e.g.
+	// because the sidecar hangs. Defaults to false.
+	CompleteOnTerminatedContainers bool `json:"complete_on_terminated_containers,omitempty"`
+
+	// DeleteLingeringPods makes plank delete the pods of jobs it completed
+	// because of CompleteOnTerminatedContainers, terminating the lingering
+	// sidecar. Defaults to false.
+	DeleteLingeringPods bool `json:"delete_lingering_pods,omitempty"`
+
+	// RecordRevivalAttempts makes plank record the status of pods that were
+	// stopped unexpectedly, e.g. evicted, before deleting them to revive the
+	// job, in the prow.k8s.io/revival-attempts annotation of the job. As there
+	// is one entry per revival, the annotation grows up to MaxRevivals
+	// entries. Defaults to false.
+	RecordRevivalAttempts bool `json:"record_revival_attempts,omitempty"`
+
+	// WaitForPodInfoUpload makes plank wait for the gcsk8sreporter of crier
+	// to upload the pod info of a finished pod, i.e. to remove its finalizer
+	// from the pod, before completing the job. This makes sure all artifacts
+	// of the job are uploaded once it is reported as complete. The reporter
+	// has to run with the same configuration, as it then checks on the pods
+	// of pending jobs to upload their pod info as soon as they finished. It
+	// watches the pods of the build clusters to do so if this is set when
+	// crier starts, which requires it to be allowed to list and watch pods.
+	// Defaults to false.
+	WaitForPodInfoUpload bool `json:"wait_for_pod_info_upload,omitempty"`
+
+	// DrainingClusters lists build clusters that should not get any new pods,
+	// e.g. because they are being drained for maintenance. Jobs of these
+	// clusters that didn't start yet are started on one of their failover
+	// clusters from ClusterFailover instead.
+	DrainingClusters []string `json:"draining_clusters,omitempty"`
+
+	// ClusterFailover maps build clusters to the clusters plank starts their
+	// jobs on if they are draining or plank has no client for them, in order
+	// of preference. Jobs are only moved before their pod got created. If
+	// none of the failover clusters can be used, the job is started on its
+	// own cluster.
+	ClusterFailover map[string][]string `json:"cluster_failover,omitempty"`
+
+	// ClusterHealthCheckInterval enables probing the health of build clusters
+	// before starting pods on them and defines for how long the result of a
+	// probe is reused. Jobs of a cluster that failed its probe stay triggered
+	// until the cluster is reachable again, instead of failing to create
+	// their pod on every sync. Unset disables the probes.
+	ClusterHealthCheckInterval *metav1.Duration `json:"cluster_health_check_interval,omitempty"`
+
+	// DupePolicy defines which of several presubmits of the same job for the
+	// same refs plank keeps running. One of prefer_newest and prefer_oldest.
+	// Defaults to prefer_newest.
+	DupePolicy DupePolicy `json:"dupe_policy,omitempty"`
+
+	// GlobalMaxRunningPods is the maximum number of pods created by Prow that
+	// may be running at the same time across all build clusters. Unlike
+	// MaxConcurrency, which counts the pending jobs of this plank, it counts
+	// the actual pods, including the ones of other plank instances and the
+	// ones that outlive their job. No new jobs are started while it is
+	// reached. 0 implies no limit.
+	GlobalMaxRunningPods int `json:"global_max_running_pods,omitempty"`
+
+	// ClusterMaxConcurrency is the maximum number of pending and triggered
+	// jobs per build cluster alias that may be started, in addition to
+	// MaxConcurrency. Build clusters without an entry, or with a limit of 0,
+	// are only limited by MaxConcurrency.
+	ClusterMaxConcurrency map[string]int `json:"cluster_max_concurrency,omitempty"`
+
+	// RecordConcurrencyWait makes plank record the time a job waited from its
+	// creation until it got started, e.g. because of concurrency limits, in
+	// the prow.k8s.io/concurrency-wait annotation of the job. The wait is
+	// exported in the prow_plank_concurrency_wait_seconds metric regardless.
+	// Defaults to false.
+	RecordConcurrencyWait bool `json:"record_concurrency_wait,omitempty"`
+
+	// RecordPodQOSClass makes plank record the quality of service class of
+	// the pods of jobs, i.e. Guaranteed, Burstable or BestEffort, in the
+	// pod_qos_class field of the job status. Defaults to false.
+	RecordPodQOSClass bool `json:"record_pod_qos_class,omitempty"`
+
+	// RecordPodNode makes plank record the node the pods of jobs ran on and
+	// the zone of that node in the node_name and node_zone fields of the job
+	// status, once the pods left the Pending phase. Looking up the zone
+	// requires permission to get nodes in every build cluster.
+	// Defaults to false.
+	RecordPodNode bool `json:"record_pod_node,omitempty"`
+
+	// RepairPodLabels makes plank compare the job, type, ID and build ID
+	// labels of the pods of pending jobs with the ones of their ProwJob and
+	// patch the pods back if they drifted, e.g. because of manual edits, so
+	// that label selectors keep matching them. Defaults to false.
+	RepairPodLabels bool `json:"repair_pod_labels,omitempty"`
+
+	// PodUpdateDebounce is how long plank waits after an update of the pod of
+	// a job before it reconciles the job, so that pods flapping through
+	// several states in a short time, e.g. while their containers start, only
+	// cause a single reconcile of their settled state. Creations and deletions
+	// of pods are reconciled right away. Unset reconciles every update right
+	// away.
+	PodUpdateDebounce *metav1.Duration `json:"pod_update_debounce,omitempty"`
+
+	// MaxJobLogBytes is the maximum number of bytes of output the entrypoint
+	// of decorated jobs writes to the build log. Output beyond it is dropped
+	// so that runaway jobs don't exhaust the disk of the nodes. 0 implies no
+	// limit.
+	MaxJobLogBytes int64 `json:"max_job_log_bytes,omitempty"`
+
+	// RetryPodCreateConflicts makes plank retry creating the pod of a job once
+	// with a new build ID when the creation conflicts, and adopt the pod of the
+	// job when it already exists, instead of erroring the job. A pod that
+	// already exists is adopted only if it was created for the same job.
+	// Defaults to false.
+	RetryPodCreateConflicts bool `json:"retry_pod_create_conflicts,omitempty"`
+
+	// StuckTerminatingTimeout defines how long the pod of a pending job may
+	// be terminating before plank considers it stuck, e.g. because a
+	// finalizer is never removed. Stuck pods are logged and counted in the
+	// prow_plank_pods_stuck_terminating_total metric. Unset disables the
+	// detection.
+	StuckTerminatingTimeout *metav1.Duration `json:"stuck_terminating_timeout,omitempty"`
+
+	// RemoveStuckFinalizers makes plank remove the finalizers owned by Prow
+	// from pods that are stuck terminating, so that they can go away. Only
+	// has an effect together with stuck_terminating_timeout. Defaults to
+	// false.
+	RemoveStuckFinalizers bool `json:"remove_stuck_finalizers,omitempty"`
+
+	// ExtraPodFinalizers are finalizers besides the ones owned by Prow that
+	// plank removes from pods before deleting them, e.g. the ones of custom
+	// reporters. Without this, pods that carry them hang around after plank
+	// deleted them.
+	ExtraPodFinalizers []string `json:"extra_pod_finalizers,omitempty"`
+
+	// StuckTriggeredThreshold defines how long a job may stay triggered
+	// without plank creating its pod before it considers the job stuck, e.g.
+	// because of a concurrency limit that never frees up. Stuck jobs are
+	// logged and counted once in the prow_plank_stuck_triggered_total metric.
+	// Unset disables the detection.
+	StuckTriggeredThreshold *metav1.Duration `json:"stuck_triggered_threshold,omitempty"`
+
+	// ReconcileErrorThreshold is the number of reconcile errors of a job
+	// within the ReconcileErrorWindow at which plank annotates its ProwJobs
+	// with the prow.k8s.io/reconcile-errors annotation, so that jobs that
+	// keep failing to reconcile, e.g. because of an invalid pod spec, stand
+	// out. Reconcile errors are counted per job in the
+	// prow_plank_reconcile_errors_total metric either way. 0 disables the
+	// annotation.
+	ReconcileErrorThreshold int `json:"reconcile_error_threshold,omitempty"`
+
+	// ReconcileErrorWindow is the time window within which reconcile errors
+	// of a job count towards the ReconcileErrorThreshold. Defaults to 10m.
+	ReconcileErrorWindow *metav1.Duration `json:"reconcile_error_window,omitempty"`
+
+	// PeriodicStartJitter is the maximum time plank delays starting a
+	// periodic job after it got triggered, so that periodics which share a
+	// schedule don't all get started at once. Each job is delayed by a
+	// different amount below it. Unset disables the delay.
+	PeriodicStartJitter *metav1.Duration `json:"periodic_start_jitter,omitempty"`
+
+	// RequeueJitterFactor spreads out the requeues of pending jobs, so that
+	// jobs that got created at the same time don't all get reconciled again
+	// at once. The time after which a pending job gets requeued is multiplied
+	// by a random factor between 1 and 1+RequeueJitterFactor. Defaults to 0,
+	// which disables the jitter.
+	RequeueJitterFactor float64 `json:"requeue_jitter_factor,omitempty"`
+
+	// RecordReconcileAttribution makes plank record which of its instances
+	// changed the status of a job, and in which of its reconciles, in the
+	// prow.k8s.io/reconciler-instance and prow.k8s.io/reconcile-sequence
+	// annotations of the job. The instance is named after the POD_NAME
+	// environment variable, or the hostname if it is not set. Defaults to
+	// false.
+	RecordReconcileAttribution bool `json:"record_reconcile_attribution,omitempty"`
+
+	// RevivalBackoffBase is how long plank waits before recreating the pod of
+	// a job that stopped unexpectedly, e.g. because it got evicted, for the
+	// first time. The wait doubles with every further revival of the job, so
+	// that nodes under pressure don't evict and recreate pods in a tight loop.
+	// Unset means pods are recreated right away.
+	RevivalBackoffBase *metav1.Duration `json:"revival_backoff_base,omitempty"`
+
+	// RevivalBackoffMax caps the wait before recreating a pod that stopped
+	// unexpectedly. Unset means the wait is not capped.
+	RevivalBackoffMax *metav1.Duration `json:"revival_backoff_max,omitempty"`
+
+	// CacheWarmupPeriod is how long after its start plank does not trust the
+	// pod cache to be complete. Pending jobs whose pod is not found within
+	// that period are checked again once it passed, instead of getting a new
+	// pod while their pod may still be running. Unset disables the period.
+	CacheWarmupPeriod *metav1.Duration `json:"cache_warmup_period,omitempty"`
+
+	// ReconcileTimeout bounds how long plank may take to sync a single job,
+	// including the calls to the API servers it makes on the way, so that a
+	// slow API server can't block a worker indefinitely. Syncs that take
+	// longer fail and get retried. Unset means syncs are not bounded.
+	ReconcileTimeout *metav1.Duration `json:"reconcile_timeout,omitempty"`
+
+	// OrphanedPodTTL is how old a pod created by prow has to get before plank
+	// deletes it if its ProwJob no longer exists, e.g. because the ProwJob got
+	// deleted manually. Unset disables the cleanup of orphaned pods.
+	OrphanedPodTTL *metav1.Duration `json:"orphaned_pod_ttl,omitempty"`
+
+	// PodCreationWindows maps build cluster aliases to the windows of time
+	// during which plank may create pods on them, e.g. to only run jobs in
+	// off-peak hours. Triggered jobs of these clusters wait for the next
+	// window to open, while pending jobs are not affected. Clusters that are
+	// not listed have no restrictions.
+	PodCreationWindows map[string][]PodCreationWindow `json:"pod_creation_windows,omitempty"`
+
+	// TreatOOMAsError makes plank error jobs whose pod failed because a
+	// container got OOMKilled, instead of failing them, as running out of
+	// memory is rather a resource than a test problem. Such jobs are also
+	// annotated with prow.k8s.io/oomkilled. Defaults to true.
+	TreatOOMAsError *bool `json:"treat_oom_as_error,omitempty"`
+
+	// ImagePullPolicyRules makes plank rewrite the image pull policy of the
+	// containers of the pods it creates depending on their image, so that
+	// jobs neither pull immutable images over and over again nor run stale
+	// mutable ones. Unset leaves the pull policies of jobs alone.
+	ImagePullPolicyRules *ImagePullPolicyRules `json:"image_pull_policy_rules,omitempty"`
+}
+
+// ImagePullPolicyRules defines the image pull policies plank enforces. Empty
+// policies leave the pull policy of the matching containers alone.
+type ImagePullPolicyRules struct {
+	// Digest is the pull policy of containers whose image is pinned by
+	// digest, e.g. IfNotPresent, as such images never change.
+	Digest v1.PullPolicy `json:"digest,omitempty"`
+	// Latest is the pull policy of containers whose image has the latest tag
+	// or no tag at all, e.g. Always, as such images change all the time.
+	Latest v1.PullPolicy `json:"latest,omitempty"`
 }
 
 type ProwJobDefaultEntry struct {
@@ -2521,11 +2832,79 @@ func parseProwConfig(c *Config) error {
 		c.Plank.PodUnscheduledTimeout = &metav1.Duration{Duration: 5 * time.Minute}
 	}
 
+	if c.Plank.ImagePullTimeout == nil {
+		c.Plank.ImagePullTimeout = &metav1.Duration{Duration: 5 * time.Minute}
+	}
+
 	if c.Plank.MaxRevivals == nil {
 		maxRetries := 3
 		c.Plank.MaxRevivals = &maxRetries
 	}
 
+	if c.Plank.TreatOOMAsError == nil {
+		treatOOMAsError := true
+		c.Plank.TreatOOMAsError = &treatOOMAsError
+	}
+
+	if rules := c.Plank.ImagePullPolicyRules; rules != nil {
+		for field, policy := range map[string]v1.PullPolicy{"digest": rules.Digest, "latest": rules.Latest} {
+			switch policy {
+			case "", v1.PullAlways, v1.PullIfNotPresent, v1.PullNever:
+			default:
+				return fmt.Errorf("plank.image_pull_policy_rules.%s: invalid pull policy %q, must be one of %q, %q or %q", field, policy, v1.PullAlways, v1.PullIfNotPresent, v1.PullNever)
+			}
+		}
+	}
+
+	if c.Plank.GlobalMaxRunningPods < 0 {
+		return fmt.Errorf("plank.global_max_running_pods: %d must be a non-negative number", c.Plank.GlobalMaxRunningPods)
+	}
+
+	for cluster, max := range c.Plank.ClusterMaxConcurrency {
+		if max < 0 {
+			return fmt.Errorf("plank.cluster_max_concurrency.%s: %d must be a non-negative number", cluster, max)
+		}
+	}
+
+	if c.Plank.AbortGracePeriodSeconds != nil && *c.Plank.AbortGracePeriodSeconds < 0 {
+		return fmt.Errorf("plank.abort_grace_period_seconds: %d must be a non-negative number", *c.Plank.AbortGracePeriodSeconds)
+	}
+
+	for i, finalizer := range c.Plank.ExtraPodFinalizers {
+		if finalizer == "" {
+			return fmt.Errorf("plank.extra_pod_finalizers[%d] must not be empty", i)
+		}
+	}
+
+	switch c.Plank.DupePolicy {
+	case "", DupePolicyPreferNewest, DupePolicyPreferOldest:
+	default:
+		return fmt.Errorf("plank.dupe_policy: invalid policy %q, must be one of %q or %q", c.Plank.DupePolicy, DupePolicyPreferNewest, DupePolicyPreferOldest)
+	}
+
+	for cluster, windows := range c.Plank.PodCreationWindows {
+		for i, window := range windows {
+			if _, err := cronParser.Parse(window.Cron); err != nil {
+				return fmt.Errorf("plank.pod_creation_windows.%s[%d]: invalid cron expression %q: %w", cluster, i, window.Cron, err)
+			}
+			if window.Duration == nil || window.Duration.Duration <= 0 {
+				return fmt.Errorf("plank.pod_creation_windows.%s[%d]: duration must be positive", cluster, i)
+			}
+		}
+	}
+
+	if c.Plank.RequeueJitterFactor < 0 {
+		return fmt.Errorf("plank.requeue_jitter_factor: %v must be a non-negative number", c.Plank.RequeueJitterFactor)
+	}
+
+	if c.Plank.ReconcileErrorThreshold < 0 {
+		return fmt.Errorf("plank.reconcile_error_threshold: %d must be a non-negative number", c.Plank.ReconcileErrorThreshold)
+	}
+
+	if c.Plank.MaxJobLogBytes < 0 {
+		return fmt.Errorf("plank.max_job_log_bytes: %d must be a non-negative number", c.Plank.MaxJobLogBytes)
+	}
+
 	if err := c.Gerrit.DefaultAndValidate(); err != nil {
 		return fmt.Errorf("validating gerrit config: %w", err)
 	}
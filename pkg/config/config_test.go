@@ -8445,11 +8445,13 @@ moonraker:
   client_timeout: 10m0s
 pipeline: {}
 plank:
+  image_pull_timeout: 5m0s
   max_goroutines: 20
   max_revivals: 3
   pod_pending_timeout: 10m0s
   pod_running_timeout: 48h0m0s
   pod_unscheduled_timeout: 5m0s
+  treat_oom_as_error: true
 pod_namespace: default
 prowjob_namespace: default
 push_gateway:
@@ -8531,11 +8533,13 @@ moonraker:
   client_timeout: 10m0s
 pipeline: {}
 plank:
+  image_pull_timeout: 5m0s
   max_goroutines: 20
   max_revivals: 3
   pod_pending_timeout: 10m0s
   pod_running_timeout: 48h0m0s
   pod_unscheduled_timeout: 5m0s
+  treat_oom_as_error: true
 pod_namespace: default
 prowjob_namespace: default
 push_gateway:
@@ -8610,11 +8614,13 @@ moonraker:
   client_timeout: 10m0s
 pipeline: {}
 plank:
+  image_pull_timeout: 5m0s
   max_goroutines: 20
   max_revivals: 3
   pod_pending_timeout: 10m0s
   pod_running_timeout: 48h0m0s
   pod_unscheduled_timeout: 5m0s
+  treat_oom_as_error: true
 pod_namespace: default
 prowjob_namespace: default
 push_gateway:
@@ -8694,11 +8700,13 @@ moonraker:
   client_timeout: 10m0s
 pipeline: {}
 plank:
+  image_pull_timeout: 5m0s
   max_goroutines: 20
   max_revivals: 3
   pod_pending_timeout: 10m0s
   pod_running_timeout: 48h0m0s
   pod_unscheduled_timeout: 5m0s
+  treat_oom_as_error: true
 pod_namespace: default
 prowjob_namespace: default
 push_gateway:
@@ -20,6 +20,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"regexp"
 
 	"google.golang.org/grpc/metadata"
@@ -30,6 +31,10 @@ type Gangway struct {
 	// (AllowedApiClient). An AllowedApiClient has authority to trigger a subset
 	// of Prow Jobs.
 	AllowedApiClients []AllowedApiClient `json:"allowed_api_clients,omitempty"`
+
+	// DeckURL is the root URL of Deck, e.g. https://prow.k8s.io. It is used
+	// to link job executions to their view in Deck.
+	DeckURL string `json:"deck_url,omitempty"`
 }
 
 type AllowedApiClient struct {
@@ -41,6 +46,28 @@ type AllowedApiClient struct {
 	// AllowedJobsFilters contains information about what kinds of Prow jobs this
 	// API client is authorized to trigger.
 	AllowedJobsFilters []AllowedJobsFilter `json:"allowed_jobs_filters,omitempty"`
+
+	// AllowedClusters lists the build clusters that this API client may run
+	// jobs on. "*" allows all build clusters. If empty, all build clusters are
+	// allowed.
+	AllowedClusters []string `json:"allowed_clusters,omitempty"`
+
+	// QPS is the maximum rate of CreateJobExecution requests per second that
+	// this API client may make, on average. If 0, the rate is not limited.
+	QPS float64 `json:"qps,omitempty"`
+	// Burst is the maximum number of CreateJobExecution requests that this API
+	// client may make at once, if it made no requests before. Only used if QPS
+	// is set. Defaults to 1.
+	Burst int `json:"burst,omitempty"`
+}
+
+// GetAllowedClusters returns the build clusters that the API client may run
+// jobs on, defaulting to all build clusters.
+func (c *AllowedApiClient) GetAllowedClusters() []string {
+	if c == nil || len(c.AllowedClusters) == 0 {
+		return []string{"*"}
+	}
+	return c.AllowedClusters
 }
 
 // ApiClientGcp encodes GCP Cloud Endpoints-specific HTTP metadata header
@@ -226,6 +253,19 @@ func (g *Gangway) Validate() error {
 				return err
 			}
 		}
+
+		if allowedApiClient.QPS < 0 {
+			return fmt.Errorf("AllowedApiClient %q has a negative qps", cv.GetUUID())
+		}
+		if allowedApiClient.Burst < 0 {
+			return fmt.Errorf("AllowedApiClient %q has a negative burst", cv.GetUUID())
+		}
+	}
+
+	if g.DeckURL != "" {
+		if _, err := url.Parse(g.DeckURL); err != nil {
+			return fmt.Errorf("invalid value for gangway.deck_url: %w", err)
+		}
 	}
 
 	return nil
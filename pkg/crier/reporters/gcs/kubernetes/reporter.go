@@ -34,6 +34,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/scheme"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/ptr"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -44,6 +46,7 @@ import (
 	"sigs.k8s.io/prow/pkg/crier/reporters/gcs/util"
 	"sigs.k8s.io/prow/pkg/io"
 	"sigs.k8s.io/prow/pkg/io/providers"
+	"sigs.k8s.io/prow/pkg/kube"
 )
 
 type gcsK8sReporter struct {
@@ -62,6 +65,8 @@ type PodReport struct {
 
 type resourceGetter interface {
 	GetPod(ctx context.Context, cluster, namespace, name string) (*v1.Pod, error)
+	// GetCachedPod is like GetPod, but may return a stale pod from a cache.
+	GetCachedPod(ctx context.Context, cluster, namespace, name string) (*v1.Pod, error)
 	GetNode(ctx context.Context, cluster, name string) (*v1.Node, error)
 	GetEvents(ctx context.Context, cluster, namespace string, pod *v1.Pod) ([]v1.Event, error)
 	PatchPod(ctx context.Context, cluster, namespace, name string, pt types.PatchType, data []byte) error
@@ -69,6 +74,8 @@ type resourceGetter interface {
 
 type k8sResourceGetter struct {
 	podClientSets map[string]corev1.CoreV1Interface
+	podListers    map[string]listersv1.PodNamespaceLister
+	podNamespace  string
 }
 
 func NewK8sResourceGetter(podClientSets map[string]corev1.CoreV1Interface) *k8sResourceGetter {
@@ -82,6 +89,37 @@ func (rg k8sResourceGetter) GetPod(ctx context.Context, cluster, namespace, name
 	return rg.podClientSets[cluster].Pods(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
+// WatchPods makes GetCachedPod read the pods created by prow in the given
+// namespace from an informer per build cluster instead of the API servers of
+// the build clusters. It blocks until the informers synced.
+func (rg *k8sResourceGetter) WatchPods(ctx context.Context, namespace string) error {
+	listers := map[string]listersv1.PodNamespaceLister{}
+	var synced []cache.InformerSynced
+	for cluster, client := range rg.podClientSets {
+		lw := cache.NewFilteredListWatchFromClient(client.RESTClient(), "pods", namespace, func(options *metav1.ListOptions) {
+			options.LabelSelector = kube.CreatedByProw + "=true"
+		})
+		informer := cache.NewSharedIndexInformer(lw, &v1.Pod{}, 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		go informer.Run(ctx.Done())
+		listers[cluster] = listersv1.NewPodLister(informer.GetIndexer()).Pods(namespace)
+		synced = append(synced, informer.HasSynced)
+	}
+	if !cache.WaitForCacheSync(ctx.Done(), synced...) {
+		return errors.New("failed to sync the pod informers")
+	}
+	rg.podListers = listers
+	rg.podNamespace = namespace
+	return nil
+}
+
+func (rg k8sResourceGetter) GetCachedPod(ctx context.Context, cluster, namespace, name string) (*v1.Pod, error) {
+	lister, ok := rg.podListers[cluster]
+	if !ok || namespace != rg.podNamespace {
+		return rg.GetPod(ctx, cluster, namespace, name)
+	}
+	return lister.Get(name)
+}
+
 func (rg k8sResourceGetter) GetNode(ctx context.Context, cluster, name string) (*v1.Node, error) {
 	if _, ok := rg.podClientSets[cluster]; !ok {
 		return nil, fmt.Errorf("couldn't find cluster %q", cluster)
@@ -127,6 +165,11 @@ func (gr *gcsK8sReporter) report(ctx context.Context, log *logrus.Entry, pj *pro
 	}
 
 	if !pj.Complete() && pj.Status.State != prowv1.AbortedState {
+		if gr.cfg().Plank.WaitForPodInfoUpload {
+			// Plank waits for us to remove the finalizer before it completes
+			// the job, so the pod info has to be uploaded once the pod finished.
+			return gr.reportFinishedPodInfo(ctx, log, pj)
+		}
 		if err := gr.addFinalizer(ctx, pj); err != nil {
 			return nil, fmt.Errorf("failed to add finalizer to pod: %w", err)
 		}
@@ -191,6 +234,77 @@ func (gr *gcsK8sReporter) reportPodInfo(ctx context.Context, log *logrus.Entry,
 		pod = nil
 	}
 
+	// When plank waits for the pod info upload, we upload the pod info as soon
+	// as the pod finished and remove our finalizer then.
+	if gr.cfg().Plank.WaitForPodInfoUpload && pod != nil && !sets.New(pod.Finalizers...).Has(kubernetesreporterapi.FinalizerName) && gr.podInfoUploaded(ctx, pj) {
+		log.Debug("Pod info was uploaded when the pod finished, skipping")
+		return nil
+	}
+
+	return gr.uploadPodInfo(ctx, log, pj, pod)
+}
+
+// reportFinishedPodInfo reports the pod info of a job that is not complete
+// yet as soon as its pod finished, as the pod state is immutable from then on.
+// Until then, it makes sure the pod has our finalizer and checks on the pod
+// again later, as the job state doesn't change once it is pending. The pod is
+// checked on in the cache, so that only adding the finalizer and uploading the
+// pod info hit the API server of the build cluster.
+func (gr *gcsK8sReporter) reportFinishedPodInfo(ctx context.Context, log *logrus.Entry, pj *prowv1.ProwJob) (*reconcile.Result, error) {
+	cachedPod, err := gr.rg.GetCachedPod(ctx, pj.Spec.Cluster, gr.cfg().PodNamespace, pj.Name)
+	if err != nil {
+		// The cache may not have caught up with a new pod yet.
+		log.WithError(err).Debug("Couldn't fetch pod from the cache")
+		return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	if cachedPod.Status.Phase != v1.PodSucceeded && cachedPod.Status.Phase != v1.PodFailed {
+		if cachedPod.DeletionTimestamp == nil && !sets.New(cachedPod.Finalizers...).Has(kubernetesreporterapi.FinalizerName) {
+			if err := gr.addFinalizer(ctx, pj); err != nil {
+				return nil, fmt.Errorf("failed to add finalizer to pod: %w", err)
+			}
+		}
+		return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	pod, err := gr.rg.GetPod(ctx, pj.Spec.Cluster, gr.cfg().PodNamespace, pj.Name)
+	if err != nil {
+		log.WithError(err).Info("Couldn't fetch pod")
+		return nil, nil
+	}
+	if !sets.New(pod.Finalizers...).Has(kubernetesreporterapi.FinalizerName) && gr.podInfoUploaded(ctx, pj) {
+		return nil, nil
+	}
+	return nil, gr.uploadPodInfo(ctx, log, pj, pod)
+}
+
+// podInfoUploaded returns whether the pod info of the job has been uploaded.
+func (gr *gcsK8sReporter) podInfoUploaded(ctx context.Context, pj *prowv1.ProwJob) bool {
+	podInfoPath, err := gr.podInfoPath(pj)
+	if err != nil {
+		return false
+	}
+	_, err = gr.opener.Attributes(ctx, podInfoPath)
+	return err == nil
+}
+
+// podInfoPath returns the storage path of the pod info of the job.
+func (gr *gcsK8sReporter) podInfoPath(pj *prowv1.ProwJob) (string, error) {
+	bucketName, dir, err := util.GetJobDestination(gr.cfg, pj)
+	if err != nil {
+		return "", fmt.Errorf("couldn't get job destination: %w", err)
+	}
+	podInfoPath, err := providers.StoragePath(bucketName, path.Join(dir, "podinfo.json"))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve podinfo.json path: %v", err)
+	}
+	return podInfoPath, nil
+}
+
+// uploadPodInfo uploads the pod, its events and its node and removes our
+// finalizer from the pod.
+func (gr *gcsK8sReporter) uploadPodInfo(ctx context.Context, log *logrus.Entry, pj *prowv1.ProwJob, pod *v1.Pod) error {
+	var err error
 	var events []v1.Event
 	var node *v1.Node
 	if pod != nil {
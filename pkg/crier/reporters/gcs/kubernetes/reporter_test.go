@@ -17,6 +17,7 @@ limitations under the License.
 package kubernetes
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -36,6 +37,7 @@ import (
 	"sigs.k8s.io/prow/pkg/io/fakeopener"
 
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	kubernetesreporterapi "sigs.k8s.io/prow/pkg/crier/reporters/gcs/kubernetes/api"
 )
 
 type fca struct {
@@ -135,6 +137,7 @@ type testResourceGetter struct {
 	namespace string
 	cluster   string
 	pod       *v1.Pod
+	cachedPod *v1.Pod
 	events    []v1.Event
 	node      *v1.Node
 	patchData string
@@ -158,6 +161,13 @@ func (rg testResourceGetter) GetPod(_ context.Context, cluster, namespace, name
 	return rg.pod, nil
 }
 
+func (rg testResourceGetter) GetCachedPod(ctx context.Context, cluster, namespace, name string) (*v1.Pod, error) {
+	if rg.cachedPod != nil {
+		return rg.cachedPod, nil
+	}
+	return rg.GetPod(ctx, cluster, namespace, name)
+}
+
 func (rg testResourceGetter) GetNode(_ context.Context, cluster, name string) (*v1.Node, error) {
 	if rg.cluster != cluster {
 		return nil, fmt.Errorf("expected cluster %q but got cluster %q", rg.cluster, cluster)
@@ -212,6 +222,8 @@ func TestReportPodInfo(t *testing.T) {
 		pjPending               bool
 		pjState                 prowv1.ProwJobState
 		pod                     *v1.Pod
+		cachedPod               *v1.Pod
+		existingPodInfo         string
 		patchErr                error
 		events                  []v1.Event
 		node                    *v1.Node
@@ -220,6 +232,7 @@ func TestReportPodInfo(t *testing.T) {
 		expectErr               bool
 		expectedPatch           string
 		expectedReconcileResult *reconcile.Result
+		waitForPodInfoUpload    bool
 	}{
 		{
 			name:       "prowjob picks up pod and events",
@@ -372,6 +385,119 @@ func TestReportPodInfo(t *testing.T) {
 			expectReport:  true,
 			expectedPatch: `{"metadata":{"finalizers":null}}`,
 		},
+		{
+			name:                 "Pending job with running pod is checked again later when waiting for the pod info upload",
+			pjName:               "ba123965-4fd4-421f-8509-7590c129ab69",
+			pjState:              prowv1.PendingState,
+			pjPending:            true,
+			waitForPodInfoUpload: true,
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Finalizers: []string{"prow.x-k8s.io/gcsk8sreporter"},
+					Name:       "ba123965-4fd4-421f-8509-7590c129ab69",
+					Namespace:  "test-pods",
+					Labels:     map[string]string{"created-by-prow": "true"},
+				},
+				Status: v1.PodStatus{Phase: v1.PodRunning},
+			},
+			expectReport:            false,
+			expectedReconcileResult: &reconcile.Result{RequeueAfter: 10 * time.Second},
+		},
+		{
+			name:                 "Pending job with finished pod is reported when waiting for the pod info upload",
+			pjName:               "ba123965-4fd4-421f-8509-7590c129ab69",
+			pjState:              prowv1.PendingState,
+			pjPending:            true,
+			waitForPodInfoUpload: true,
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Finalizers: []string{"gcsk8sreporter"},
+					Name:       "ba123965-4fd4-421f-8509-7590c129ab69",
+					Namespace:  "test-pods",
+					Labels:     map[string]string{"created-by-prow": "true"},
+				},
+				Status: v1.PodStatus{Phase: v1.PodSucceeded},
+			},
+			expectReport:  true,
+			expectedPatch: `{"metadata":{"finalizers":null}}`,
+		},
+		{
+			name:                 "Pending job with running pod is checked on in the cache when waiting for the pod info upload",
+			pjName:               "ba123965-4fd4-421f-8509-7590c129ab69",
+			pjState:              prowv1.PendingState,
+			pjPending:            true,
+			waitForPodInfoUpload: true,
+			// The pod from the API server lacks the finalizer, so adding it
+			// would fail on the unexpected patch.
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ba123965-4fd4-421f-8509-7590c129ab69",
+					Namespace: "test-pods",
+					Labels:    map[string]string{"created-by-prow": "true"},
+				},
+				Status: v1.PodStatus{Phase: v1.PodRunning},
+			},
+			cachedPod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Finalizers: []string{kubernetesreporterapi.FinalizerName},
+					Name:       "ba123965-4fd4-421f-8509-7590c129ab69",
+					Namespace:  "test-pods",
+					Labels:     map[string]string{"created-by-prow": "true"},
+				},
+				Status: v1.PodStatus{Phase: v1.PodRunning},
+			},
+			expectReport:            false,
+			expectedReconcileResult: &reconcile.Result{RequeueAfter: 10 * time.Second},
+		},
+		{
+			name:                 "Pending job with running pod gets the finalizer when waiting for the pod info upload",
+			pjName:               "ba123965-4fd4-421f-8509-7590c129ab69",
+			pjState:              prowv1.PendingState,
+			pjPending:            true,
+			waitForPodInfoUpload: true,
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ba123965-4fd4-421f-8509-7590c129ab69",
+					Namespace: "test-pods",
+					Labels:    map[string]string{"created-by-prow": "true"},
+				},
+				Status: v1.PodStatus{Phase: v1.PodRunning},
+			},
+			expectReport:            false,
+			expectedPatch:           `{"metadata":{"finalizers":["prow.x-k8s.io/gcsk8sreporter"]}}`,
+			expectedReconcileResult: &reconcile.Result{RequeueAfter: 10 * time.Second},
+		},
+		{
+			name:                 "Complete job is not reported again once the pod info was uploaded when waiting for the pod info upload",
+			pjName:               "ba123965-4fd4-421f-8509-7590c129ab69",
+			pjComplete:           true,
+			waitForPodInfoUpload: true,
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ba123965-4fd4-421f-8509-7590c129ab69",
+					Namespace: "test-pods",
+					Labels:    map[string]string{"created-by-prow": "true"},
+				},
+				Status: v1.PodStatus{Phase: v1.PodSucceeded},
+			},
+			existingPodInfo: `{"pod":{"metadata":{"name":"ba123965-4fd4-421f-8509-7590c129ab69"}}}`,
+			expectReport:    false,
+		},
+		{
+			name:                 "Complete job is reported if the pod info was not uploaded when waiting for the pod info upload",
+			pjName:               "ba123965-4fd4-421f-8509-7590c129ab69",
+			pjComplete:           true,
+			waitForPodInfoUpload: true,
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ba123965-4fd4-421f-8509-7590c129ab69",
+					Namespace: "test-pods",
+					Labels:    map[string]string{"created-by-prow": "true"},
+				},
+				Status: v1.PodStatus{Phase: v1.PodSucceeded},
+			},
+			expectReport: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -414,6 +540,7 @@ func TestReportPodInfo(t *testing.T) {
 								DefaultRepo:  "kubernetes",
 							},
 						}}),
+					WaitForPodInfoUpload: tc.waitForPodInfoUpload,
 				},
 			}}}
 
@@ -421,6 +548,7 @@ func TestReportPodInfo(t *testing.T) {
 				namespace: "test-pods",
 				cluster:   "the-build-cluster",
 				pod:       tc.pod,
+				cachedPod: tc.cachedPod,
 				events:    tc.events,
 				node:      tc.node,
 				patchErr:  tc.patchErr,
@@ -428,6 +556,11 @@ func TestReportPodInfo(t *testing.T) {
 				patchType: types.MergePatchType,
 			}
 			fakeOpener := &fakeopener.FakeOpener{}
+			if tc.existingPodInfo != "" {
+				fakeOpener.Buffer = map[string]*bytes.Buffer{
+					"gs://kubernetes-jenkins/some-prefix/logs/12345/podinfo.json": bytes.NewBufferString(tc.existingPodInfo),
+				}
+			}
 			reporter := New(fca.Config, fakeOpener, rg, 1.0, tc.dryRun)
 			reconcileResult, err := reporter.report(context.Background(), logrus.NewEntry(logrus.StandardLogger()), pj)
 
@@ -461,7 +594,7 @@ func TestReportPodInfo(t *testing.T) {
 			}
 
 			if !tc.expectReport {
-				if len(content) > 0 {
+				if string(content) != tc.existingPodInfo {
 					t.Fatalf("Expected nothing to be written, but something was written: %s", string(content))
 				}
 				return
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/GoogleCloudPlatform/testgrid/metadata"
@@ -41,9 +42,10 @@ import (
 const reporterName = "gcsreporter"
 
 type gcsReporter struct {
-	cfg    config.Getter
-	dryRun bool
-	opener io.Opener
+	cfg              config.Getter
+	dryRun           bool
+	opener           io.Opener
+	artifactIndexDir string
 }
 
 func (gr *gcsReporter) Report(ctx context.Context, log *logrus.Entry, pj *prowv1.ProwJob) ([]*prowv1.ProwJob, *reconcile.Result, error) {
@@ -63,11 +65,12 @@ func (gr *gcsReporter) Report(ctx context.Context, log *logrus.Entry, pj *prowv1
 
 func (gr *gcsReporter) reportJobState(ctx context.Context, log *logrus.Entry, pj *prowv1.ProwJob) error {
 	startedErr := gr.reportStartedJob(ctx, log, pj)
-	var finishedErr error
+	var finishedErr, indexErr error
 	if pj.Complete() {
 		finishedErr = gr.reportFinishedJob(ctx, log, pj)
+		indexErr = gr.reportArtifactIndex(ctx, log, pj)
 	}
-	return utilerrors.NewAggregate([]error{startedErr, finishedErr})
+	return utilerrors.NewAggregate([]error{startedErr, finishedErr, indexErr})
 }
 
 // reportStartedJob uploads a started.json for the job. This will almost certainly
@@ -183,6 +186,33 @@ func (gr *gcsReporter) reportFinishedJob(ctx context.Context, log *logrus.Entry,
 	return io.WriteContent(ctx, log, gr.opener, finishedFilePath, output, overwriteOpt)
 }
 
+// reportArtifactIndex records the storage path of the artifacts of the job in
+// the artifact index, iff an artifact index is configured. The index lets
+// gangway find the artifacts of job executions whose ProwJob is gone by their
+// id alone.
+func (gr *gcsReporter) reportArtifactIndex(ctx context.Context, log *logrus.Entry, pj *prowv1.ProwJob) error {
+	if gr.artifactIndexDir == "" {
+		return nil
+	}
+
+	bucketName, dir, err := util.GetJobDestination(gr.cfg, pj)
+	if err != nil {
+		return fmt.Errorf("failed to get job destination: %w", err)
+	}
+	artifactDir, err := providers.StoragePath(bucketName, dir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve artifact path: %v", err)
+	}
+	indexFilePath := strings.TrimSuffix(gr.artifactIndexDir, "/") + "/" + pj.Name
+
+	if gr.dryRun {
+		log.WithFields(logrus.Fields{"artifactDir": artifactDir, "index": indexFilePath}).Debug("Would upload artifact index")
+		return nil
+	}
+	overwriteOpt := io.WriterOptions{PreconditionDoesNotExist: ptr.To(true)}
+	return io.WriteContent(ctx, log, gr.opener, indexFilePath, []byte(artifactDir), overwriteOpt)
+}
+
 func (gr *gcsReporter) reportProwjob(ctx context.Context, log *logrus.Entry, pj *prowv1.ProwJob) error {
 	// Unconditionally dump the ProwJob to GCS, on all job updates.
 	output, err := util.MarshalProwJob(pj)
@@ -218,10 +248,15 @@ func (gr *gcsReporter) ShouldReport(_ context.Context, _ *logrus.Entry, pj *prow
 	return pj.Status.BuildID != ""
 }
 
-func New(cfg config.Getter, opener io.Opener, dryRun bool) *gcsReporter {
+// New returns a reporter that uploads the metadata of jobs next to their
+// artifacts. If artifactIndexDir is set, it also records the storage path of
+// the artifacts of finished jobs in an index under artifactIndexDir, in an
+// object named after the ProwJob.
+func New(cfg config.Getter, opener io.Opener, artifactIndexDir string, dryRun bool) *gcsReporter {
 	return &gcsReporter{
-		cfg:    cfg,
-		dryRun: dryRun,
-		opener: opener,
+		cfg:              cfg,
+		dryRun:           dryRun,
+		opener:           opener,
+		artifactIndexDir: artifactIndexDir,
 	}
 }
@@ -101,7 +101,7 @@ func TestReportJobFinished(t *testing.T) {
 				},
 			}}.Config
 			fakeOpener := &fakeopener.FakeOpener{}
-			reporter := New(cfg, fakeOpener, false)
+			reporter := New(cfg, fakeOpener, "", false)
 
 			pj := &prowv1.ProwJob{
 				Spec: prowv1.ProwJobSpec{
@@ -390,7 +390,7 @@ func TestReportJobStarted(t *testing.T) {
 				}
 			}
 
-			reporter := New(cfg, opener, false)
+			reporter := New(cfg, opener, "", false)
 
 			pj := &prowv1.ProwJob{
 				Spec: prowv1.ProwJobSpec{
@@ -453,7 +453,7 @@ func TestReportProwJob(t *testing.T) {
 		},
 	}}.Config
 	fakeOpener := &fakeopener.FakeOpener{}
-	reporter := New(cfg, fakeOpener, false)
+	reporter := New(cfg, fakeOpener, "", false)
 
 	pj := &prowv1.ProwJob{
 		Spec: prowv1.ProwJobSpec{
@@ -531,7 +531,7 @@ func TestShouldReport(t *testing.T) {
 					BuildID:   tc.buildID,
 				},
 			}
-			gr := New(fca{}.Config, nil, false)
+			gr := New(fca{}.Config, nil, "", false)
 			result := gr.ShouldReport(context.Background(), logrus.NewEntry(logrus.StandardLogger()), pj)
 			if result != tc.shouldReport {
 				t.Errorf("Got ShouldReport() returned %v, but expected %v", result, tc.shouldReport)
@@ -539,3 +539,90 @@ func TestShouldReport(t *testing.T) {
 		})
 	}
 }
+
+func TestReportArtifactIndex(t *testing.T) {
+	cfg := fca{c: config.Config{
+		ProwConfig: config.ProwConfig{
+			Plank: config.Plank{
+				DefaultDecorationConfigs: config.DefaultDecorationMapToSliceTesting(
+					map[string]*prowv1.DecorationConfig{"*": {
+						GCSConfiguration: &prowv1.GCSConfiguration{
+							Bucket:       "kubernetes-jenkins",
+							PathPrefix:   "some-prefix",
+							PathStrategy: prowv1.PathStrategyLegacy,
+							DefaultOrg:   "kubernetes",
+							DefaultRepo:  "kubernetes",
+						},
+					}}),
+			},
+		},
+	}}.Config
+
+	testCases := []struct {
+		name             string
+		artifactIndexDir string
+		completionTime   *metav1.Time
+		expected         map[string]string
+	}{
+		{
+			name:             "finished job is indexed",
+			artifactIndexDir: "gs://index-bucket/index/",
+			completionTime:   &metav1.Time{Time: time.Date(2010, 10, 10, 19, 00, 0, 0, time.UTC)},
+			expected: map[string]string{
+				"gs://index-bucket/index/some-uuid": "gs://kubernetes-jenkins/some-prefix/pr-logs/pull/test-infra/12345/my-little-job/123",
+			},
+		},
+		{
+			name:             "running job is not indexed",
+			artifactIndexDir: "gs://index-bucket/index",
+			expected:         map[string]string{},
+		},
+		{
+			name:           "nothing is indexed without an index",
+			completionTime: &metav1.Time{Time: time.Date(2010, 10, 10, 19, 00, 0, 0, time.UTC)},
+			expected:       map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeOpener := &fakeopener.FakeOpener{}
+			reporter := New(cfg, fakeOpener, tc.artifactIndexDir, false)
+
+			pj := &prowv1.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-uuid"},
+				Spec: prowv1.ProwJobSpec{
+					Type: prowv1.PresubmitJob,
+					Refs: &prowv1.Refs{
+						Org:   "kubernetes",
+						Repo:  "test-infra",
+						Pulls: []prowv1.Pull{{Number: 12345}},
+					},
+					Agent: prowv1.KubernetesAgent,
+					Job:   "my-little-job",
+				},
+				Status: prowv1.ProwJobStatus{
+					State:          prowv1.SuccessState,
+					StartTime:      metav1.Time{Time: time.Date(2010, 10, 10, 18, 30, 0, 0, time.UTC)},
+					CompletionTime: tc.completionTime,
+					PodName:        "some-pod",
+					BuildID:        "123",
+				},
+			}
+
+			if err := reporter.reportJobState(context.Background(), logrus.NewEntry(logrus.StandardLogger()), pj); err != nil {
+				t.Fatalf("Unexpected error calling reportJobState: %v", err)
+			}
+
+			indexed := map[string]string{}
+			for p, b := range fakeOpener.Buffer {
+				if strings.HasPrefix(p, "gs://index-bucket/") {
+					indexed[p] = b.String()
+				}
+			}
+			if diff := cmp.Diff(tc.expected, indexed); diff != "" {
+				t.Errorf("Unexpected artifact index (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	cron "github.com/robfig/cron/v3"
 	"github.com/sirupsen/logrus"
@@ -186,3 +187,13 @@ func (c *Cron) removeJob(name string) error {
 	c.logger.Infof("Removed previous cron job %s.", name)
 	return nil
 }
+
+// NextRun returns the first time after the given time at which a job with the
+// given cron expression gets triggered.
+func NextRun(cronStr string, after time.Time) (time.Time, error) {
+	schedule, err := cronParser.Parse("TZ=UTC " + cronStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse cron %s: %w", cronStr, err)
+	}
+	return schedule.Next(after), nil
+}
@@ -18,6 +18,7 @@ package cron
 
 import (
 	"testing"
+	"time"
 
 	cron "github.com/robfig/cron/v3"
 	"sigs.k8s.io/prow/pkg/config"
@@ -200,3 +201,45 @@ func TestTrigger(t *testing.T) {
 		t.Error("should have triggered job 'periodic'")
 	}
 }
+
+func TestNextRun(t *testing.T) {
+	after := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	for _, tc := range []struct {
+		name      string
+		cron      string
+		expected  time.Time
+		expectErr bool
+	}{
+		{
+			name:     "hourly",
+			cron:     "0 * * * *",
+			expected: time.Date(2024, 5, 6, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "descriptor",
+			cron:     "@daily",
+			expected: time.Date(2024, 5, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "invalid",
+			cron:      "not a cron",
+			expectErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			next, err := NextRun(tc.cron, after)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !next.Equal(tc.expected) {
+				t.Errorf("expected next run at %v, got %v", tc.expected, next)
+			}
+		})
+	}
+}
@@ -23,12 +23,14 @@ import (
 	"errors"
 	"fmt"
 	stdio "io"
+	"math"
 	"net/http"
 	"slices"
 	"sort"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/golang-lru/v2/simplelru"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -36,6 +38,8 @@ import (
 
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pod-utils/decorate"
 )
 
 const (
@@ -82,11 +86,100 @@ type PodLogClient interface {
 	GetLogs(name, container string) ([]byte, error)
 }
 
+// PodLogStreamer is a PodLogClient that can also stream the pod logs instead
+// of reading them all at once.
+type PodLogStreamer interface {
+	PodLogClient
+	StreamLogs(name, container string) (stdio.ReadCloser, error)
+}
+
 // PJListingClient is an interface to list ProwJobs
 type PJListingClient interface {
 	List(context.Context, *prowapi.ProwJobList, ...ctrlruntimeclient.ListOption) error
 }
 
+// PodLogCacheKey identifies the logs of a container of a pod in a build cluster.
+type PodLogCacheKey struct {
+	Cluster   string
+	Pod       string
+	Container string
+}
+
+// PodLogCache caches the logs of the pods of completed prowjobs, which no
+// longer change, so that deck does not fetch them from the build cluster
+// again on every page load.
+type PodLogCache interface {
+	Get(key PodLogCacheKey) ([]byte, bool)
+	// Add caches the given log unless it is larger than MaxLogSize.
+	Add(key PodLogCacheKey, log []byte) bool
+	// MaxLogSize returns the size in bytes of the largest log the cache
+	// holds. Larger logs are streamed instead of being read into memory.
+	MaxLogSize() int
+}
+
+// NewPodLogCache returns a PodLogCache that holds up to maxSize bytes of logs,
+// each of them for up to ttl and of up to maxLogSize bytes. A ttl of 0 keeps
+// the logs until they are evicted to make room for others.
+func NewPodLogCache(maxSize, maxLogSize int, ttl time.Duration) PodLogCache {
+	c := &podLogCache{
+		maxSize:    maxSize,
+		maxLogSize: min(maxLogSize, maxSize),
+		ttl:        ttl,
+	}
+	// The cache is bound by the size of the logs rather than their number.
+	c.lru, _ = simplelru.NewLRU[PodLogCacheKey, podLogCacheEntry](math.MaxInt32, func(_ PodLogCacheKey, entry podLogCacheEntry) {
+		c.size -= len(entry.log)
+	})
+	return c
+}
+
+type podLogCacheEntry struct {
+	log     []byte
+	expires time.Time
+}
+
+type podLogCache struct {
+	lock       sync.Mutex
+	lru        *simplelru.LRU[PodLogCacheKey, podLogCacheEntry]
+	size       int
+	maxSize    int
+	maxLogSize int
+	ttl        time.Duration
+}
+
+func (c *podLogCache) Get(key PodLogCacheKey) ([]byte, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.lru.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if c.ttl > 0 && time.Now().After(entry.expires) {
+		c.lru.Remove(key)
+		return nil, false
+	}
+	return entry.log, true
+}
+
+func (c *podLogCache) Add(key PodLogCacheKey, log []byte) bool {
+	if len(log) > c.maxLogSize {
+		return false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.lru.Remove(key)
+	c.lru.Add(key, podLogCacheEntry{log: log, expires: time.Now().Add(c.ttl)})
+	c.size += len(log)
+	for c.size > c.maxSize {
+		c.lru.RemoveOldest()
+	}
+	return true
+}
+
+func (c *podLogCache) MaxLogSize() int {
+	return c.maxLogSize
+}
+
 // NewJobAgent is a JobAgent constructor.
 func NewJobAgent(ctx context.Context, pjLister PJListingClient, hiddenOnly, showHidden bool, tenantIDs []string, plClients map[string]PodLogClient, cfg config.Getter) *JobAgent {
 	return &JobAgent{
@@ -170,9 +263,20 @@ type JobAgent struct {
 	jobs          []Job
 	jobsMap       map[string]Job                        // pod name -> Job
 	jobsIDMap     map[string]map[string]prowapi.ProwJob // job name -> id -> ProwJob
+	repoIndex     map[string][]int                      // org/repo -> indices in prowJobs
+	jobIndex      map[string][]int                      // job name -> indices in prowJobs
+	logCache      PodLogCache
+	lastUpdate    time.Time // time of the last successful update
+	lastErr       error     // error of the last update, if it failed
 	mut           sync.Mutex
 }
 
+// SetPodLogCache makes the JobAgent cache the pod logs of completed prowjobs
+// in the given cache. Pod logs are not cached by default.
+func (ja *JobAgent) SetPodLogCache(cache PodLogCache) {
+	ja.logCache = cache
+}
+
 // Start will start the job and periodically update it.
 func (ja *JobAgent) Start() {
 	ja.tryUpdate()
@@ -184,6 +288,15 @@ func (ja *JobAgent) Start() {
 	}()
 }
 
+// Status returns when the JobAgent last updated its jobs successfully and the
+// error of the last update, if it failed. A zero time means that the jobs were
+// never updated.
+func (ja *JobAgent) Status() (time.Time, error) {
+	ja.mut.Lock()
+	defer ja.mut.Unlock()
+	return ja.lastUpdate, ja.lastErr
+}
+
 // Jobs returns a thread-safe snapshot of the current job state.
 func (ja *JobAgent) Jobs() []Job {
 	ja.mut.Lock()
@@ -202,6 +315,32 @@ func (ja *JobAgent) ProwJobs() []prowapi.ProwJob {
 	return res
 }
 
+// ProwJobsForRepo returns a thread-safe snapshot of the current prow jobs
+// whose refs are of the given repo.
+func (ja *JobAgent) ProwJobsForRepo(org, repo string) []prowapi.ProwJob {
+	ja.mut.Lock()
+	defer ja.mut.Unlock()
+	return ja.indexedProwJobs(ja.repoIndex[org+"/"+repo])
+}
+
+// ProwJobsForJob returns a thread-safe snapshot of the current prow jobs of
+// the job with the given name.
+func (ja *JobAgent) ProwJobsForJob(name string) []prowapi.ProwJob {
+	ja.mut.Lock()
+	defer ja.mut.Unlock()
+	return ja.indexedProwJobs(ja.jobIndex[name])
+}
+
+// indexedProwJobs returns copies of the prow jobs at the given indices. The
+// caller must hold the lock.
+func (ja *JobAgent) indexedProwJobs(indices []int) []prowapi.ProwJob {
+	res := make([]prowapi.ProwJob, 0, len(indices))
+	for _, i := range indices {
+		res = append(res, ja.prowJobs[i])
+	}
+	return res
+}
+
 // GetProwJob finds the corresponding Prowjob resource from the provided job name and build ID
 func (ja *JobAgent) GetProwJob(job, id string) (prowapi.ProwJob, error) {
 	if ja == nil {
@@ -222,20 +361,185 @@ func (ja *JobAgent) GetProwJob(job, id string) (prowapi.ProwJob, error) {
 
 // GetJobLog returns the job logs, works for both kubernetes and jenkins agent types.
 func (ja *JobAgent) GetJobLog(job, id string, container string) ([]byte, error) {
-	j, err := ja.GetProwJob(job, id)
+	j, err := ja.getLoggableProwJob(job, id)
 	if err != nil {
-		return nil, fmt.Errorf("error getting prowjob: %w", err)
+		return nil, err
 	}
-	if (j.Spec.Hidden || pjHasHiddenRefs(ja.hiddenRepos, j)) && !ja.includeHidden {
-		return nil, fmt.Errorf("prowjob: %q hidden and deck is not configed to show hidden jobs", id)
+	if j.Spec.Agent == prowapi.KubernetesAgent {
+		return ja.getPodLogs(j, container)
+	}
+	body, err := ja.getExternalAgentLog(j)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return stdio.ReadAll(body)
+}
+
+// GetJobLogForContainer returns the logs of the given container of the pod of
+// a prowjob of the kubernetes agent. Unlike GetJobLog, it returns an error if
+// the pod of the prowjob has no such container.
+func (ja *JobAgent) GetJobLogForContainer(job, id, container string) ([]byte, error) {
+	j, err := ja.getLoggableProwJob(job, id)
+	if err != nil {
+		return nil, err
+	}
+	if j.Spec.Agent != prowapi.KubernetesAgent {
+		return nil, fmt.Errorf("cannot get container logs for prowjob %q with agent %q: only the %q agent runs containers", j.ObjectMeta.Name, j.Spec.Agent, prowapi.KubernetesAgent)
+	}
+	if !podContainerNames(j).Has(container) {
+		return nil, fmt.Errorf("cannot get logs for prowjob %q: its pod has no container %q", j.ObjectMeta.Name, container)
+	}
+	return ja.getPodLogs(j, container)
+}
+
+// podContainerNames returns the names of the containers and init containers of
+// the pod of the given prowjob, including the ones added by decoration.
+func podContainerNames(j prowapi.ProwJob) sets.Set[string] {
+	names := sets.New[string]()
+	if j.Spec.PodSpec == nil {
+		return names
+	}
+	if len(j.Spec.PodSpec.Containers) == 1 {
+		// The only container is always renamed when the pod is created.
+		names.Insert(kube.TestContainerName)
+	} else {
+		for _, c := range j.Spec.PodSpec.Containers {
+			names.Insert(c.Name)
+		}
+	}
+	for _, c := range j.Spec.PodSpec.InitContainers {
+		names.Insert(c.Name)
+	}
+	if j.Spec.DecorationConfig != nil {
+		names = names.Union(decorate.PodUtilsContainerNames())
+	}
+	return names
+}
+
+// StreamJobLog returns a reader of the job logs, works for both kubernetes and
+// jenkins agent types. Unlike GetJobLog, it does not read the whole log into
+// memory if the pod log client is a PodLogStreamer and the logs of the prowjob
+// are not cached. The caller must close the reader.
+func (ja *JobAgent) StreamJobLog(job, id string, container string) (stdio.ReadCloser, error) {
+	j, err := ja.getLoggableProwJob(job, id)
+	if err != nil {
+		return nil, err
 	}
 	if j.Spec.Agent == prowapi.KubernetesAgent {
-		client, ok := ja.pkcs[j.ClusterAlias()]
-		if !ok {
-			return nil, fmt.Errorf("cannot get logs for prowjob %q with agent %q: unknown cluster alias %q", j.ObjectMeta.Name, j.Spec.Agent, j.ClusterAlias())
+		client, err := ja.podLogClient(j)
+		if err != nil {
+			return nil, err
+		}
+		if streamer, ok := client.(PodLogStreamer); ok {
+			if !ja.cachesPodLogs(j) {
+				return streamer.StreamLogs(j.Status.PodName, container)
+			}
+			key := PodLogCacheKey{Cluster: j.ClusterAlias(), Pod: j.Status.PodName, Container: container}
+			if log, ok := ja.logCache.Get(key); ok {
+				return stdio.NopCloser(bytes.NewReader(log)), nil
+			}
+			stream, err := streamer.StreamLogs(j.Status.PodName, container)
+			if err != nil {
+				return nil, err
+			}
+			return &cachingLogReader{ReadCloser: stream, cache: ja.logCache, key: key}, nil
+		}
+		log, err := ja.getPodLogs(j, container)
+		if err != nil {
+			return nil, err
 		}
+		return stdio.NopCloser(bytes.NewReader(log)), nil
+	}
+	return ja.getExternalAgentLog(j)
+}
+
+// getLoggableProwJob finds the Prowjob resource from the provided job name and
+// build ID, unless deck may not show its logs.
+func (ja *JobAgent) getLoggableProwJob(job, id string) (prowapi.ProwJob, error) {
+	j, err := ja.GetProwJob(job, id)
+	if err != nil {
+		return prowapi.ProwJob{}, fmt.Errorf("error getting prowjob: %w", err)
+	}
+	if (j.Spec.Hidden || pjHasHiddenRefs(ja.hiddenRepos, j)) && !ja.includeHidden {
+		return prowapi.ProwJob{}, fmt.Errorf("prowjob: %q hidden and deck is not configed to show hidden jobs", id)
+	}
+	return j, nil
+}
+
+// podLogClient returns the pod log client for the build cluster of the
+// given prowjob of the kubernetes agent.
+func (ja *JobAgent) podLogClient(j prowapi.ProwJob) (PodLogClient, error) {
+	client, ok := ja.pkcs[j.ClusterAlias()]
+	if !ok {
+		return nil, fmt.Errorf("cannot get logs for prowjob %q with agent %q: unknown cluster alias %q", j.ObjectMeta.Name, j.Spec.Agent, j.ClusterAlias())
+	}
+	return client, nil
+}
+
+// getPodLogs returns the logs of the given container of the pod of the given
+// prowjob of the kubernetes agent, from the pod log cache if the prowjob is
+// complete.
+func (ja *JobAgent) getPodLogs(j prowapi.ProwJob, container string) ([]byte, error) {
+	client, err := ja.podLogClient(j)
+	if err != nil {
+		return nil, err
+	}
+	if !ja.cachesPodLogs(j) {
 		return client.GetLogs(j.Status.PodName, container)
 	}
+	key := PodLogCacheKey{Cluster: j.ClusterAlias(), Pod: j.Status.PodName, Container: container}
+	if log, ok := ja.logCache.Get(key); ok {
+		return log, nil
+	}
+	log, err := client.GetLogs(j.Status.PodName, container)
+	if err != nil {
+		return nil, err
+	}
+	ja.logCache.Add(key, log)
+	return log, nil
+}
+
+// cachingLogReader adds the log it reads to the pod log cache once it has read
+// all of it, unless the log is too large to be cached. It does not hold more
+// of the log in memory than the cache would. skip is set once the log turns
+// out to be too large or has been cached.
+type cachingLogReader struct {
+	stdio.ReadCloser
+	cache PodLogCache
+	key   PodLogCacheKey
+	buf   bytes.Buffer
+	skip  bool
+}
+
+func (r *cachingLogReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if !r.skip {
+		if r.buf.Len()+n > r.cache.MaxLogSize() {
+			r.skip = true
+			r.buf = bytes.Buffer{}
+		} else {
+			r.buf.Write(p[:n])
+		}
+	}
+	if err == stdio.EOF && !r.skip {
+		r.cache.Add(r.key, bytes.Clone(r.buf.Bytes()))
+		r.skip = true
+		r.buf = bytes.Buffer{}
+	}
+	return n, err
+}
+
+// cachesPodLogs returns whether the pod logs of the given prowjob are cached.
+// The pods of prowjobs that are still pending or triggered may still log.
+func (ja *JobAgent) cachesPodLogs(j prowapi.ProwJob) bool {
+	return ja.logCache != nil && j.Complete()
+}
+
+// getExternalAgentLog requests the logs of the given prowjob of an external
+// agent, like jenkins, from the URL configured for the agent. The caller must
+// close the returned body.
+func (ja *JobAgent) getExternalAgentLog(j prowapi.ProwJob) (stdio.ReadCloser, error) {
 	for _, agentToTmpl := range ja.config().Deck.ExternalAgentLogs {
 		if agentToTmpl.Agent != string(j.Spec.Agent) {
 			continue
@@ -251,9 +555,7 @@ func (ja *JobAgent) GetJobLog(job, id string, container string) ([]byte, error)
 		if err != nil {
 			return nil, err
 		}
-		defer resp.Body.Close()
-		return stdio.ReadAll(resp.Body)
-
+		return resp.Body, nil
 	}
 	return nil, fmt.Errorf("cannot get logs for prowjob %q with agent %q: the agent is missing from the prow config file", j.ObjectMeta.Name, j.Spec.Agent)
 }
@@ -294,15 +596,20 @@ func (a byPJStartTime) Less(i, j int) bool {
 func (ja *JobAgent) update() error {
 	pjs, err := ja.kc.ListProwJobs(labels.Everything().String(), ja.hiddenRepos)
 	if err != nil {
+		ja.mut.Lock()
+		ja.lastErr = err
+		ja.mut.Unlock()
 		return err
 	}
 	var njs []Job
 	njsMap := make(map[string]Job)
 	njsIDMap := make(map[string]map[string]prowapi.ProwJob)
+	repoIndex := make(map[string][]int)
+	jobIndex := make(map[string][]int)
 
 	sort.Sort(byPJStartTime(pjs))
 
-	for _, j := range pjs {
+	for i, j := range pjs {
 		ft := time.Time{}
 		if j.Status.CompletionTime != nil {
 			ft = j.Status.CompletionTime.Time
@@ -334,7 +641,10 @@ func (ja *JobAgent) update() error {
 		if j.Spec.Refs != nil {
 			nj.Refs = *j.Spec.Refs
 			nj.RefsKey = j.Spec.Refs.String()
+			orgRepo := j.Spec.Refs.Org + "/" + j.Spec.Refs.Repo
+			repoIndex[orgRepo] = append(repoIndex[orgRepo], i)
 		}
+		jobIndex[j.Spec.Job] = append(jobIndex[j.Spec.Job], i)
 		njs = append(njs, nj)
 		if nj.PodName != "" {
 			njsMap[nj.PodName] = nj
@@ -351,5 +661,9 @@ func (ja *JobAgent) update() error {
 	ja.jobs = njs
 	ja.jobsMap = njsMap
 	ja.jobsIDMap = njsIDMap
+	ja.repoIndex = repoIndex
+	ja.jobIndex = jobIndex
+	ja.lastUpdate = time.Now()
+	ja.lastErr = nil
 	return nil
 }
@@ -17,14 +17,17 @@ limitations under the License.
 package jobs
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"sort"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	coreapi "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -48,6 +51,19 @@ func (f fkc) ListProwJobs(s string, hiddenRepos func() sets.Set[string]) ([]prow
 	return f, nil
 }
 
+// flakyKc is a serviceClusterClient that fails to list prowjobs while err is set.
+type flakyKc struct {
+	fkc
+	err error
+}
+
+func (f *flakyKc) ListProwJobs(s string, hiddenRepos func() sets.Set[string]) ([]prowapi.ProwJob, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.fkc.ListProwJobs(s, hiddenRepos)
+}
+
 type fpkc string
 
 func (f fpkc) GetLogs(name, container string) ([]byte, error) {
@@ -57,6 +73,49 @@ func (f fpkc) GetLogs(name, container string) ([]byte, error) {
 	return nil, fmt.Errorf("pod not found: %s", name)
 }
 
+// fspkc is the streaming variant of fpkc. It generates the logs lazily and
+// refuses to return them all at once.
+type fspkc struct {
+	size   int
+	reader *lazyLog
+}
+
+func (f *fspkc) GetLogs(name, container string) ([]byte, error) {
+	return nil, errors.New("logs must be streamed")
+}
+
+func (f *fspkc) StreamLogs(name, container string) (io.ReadCloser, error) {
+	if name != "wowowow" {
+		return nil, fmt.Errorf("pod not found: %s", name)
+	}
+	f.reader = &lazyLog{size: f.size}
+	return f.reader, nil
+}
+
+// lazyLog generates a log of the given size, a byte at a time as it is read.
+type lazyLog struct {
+	size   int
+	served int
+	closed bool
+}
+
+func (l *lazyLog) Read(p []byte) (int, error) {
+	if l.served == l.size {
+		return 0, io.EOF
+	}
+	n := min(len(p), l.size-l.served)
+	for i := range n {
+		p[i] = byte('a' + (l.served+i)%26)
+	}
+	l.served += n
+	return n, nil
+}
+
+func (l *lazyLog) Close() error {
+	l.closed = true
+	return nil
+}
+
 func TestGetJobLog(t *testing.T) {
 	kc := fkc{
 		prowapi.ProwJob{
@@ -92,6 +151,20 @@ func TestGetJobLog(t *testing.T) {
 				BuildID: "123",
 			},
 		},
+		prowapi.ProwJob{
+			Spec: prowapi.ProwJobSpec{
+				Agent:            prowapi.KubernetesAgent,
+				Job:              "multi",
+				DecorationConfig: &prowapi.DecorationConfig{},
+				PodSpec: &coreapi.PodSpec{
+					Containers: []coreapi.Container{{Name: "test"}, {Name: "helper"}},
+				},
+			},
+			Status: prowapi.ProwJobStatus{
+				PodName: "wowowow",
+				BuildID: "123",
+			},
+		},
 	}
 	ja := &JobAgent{
 		kc:   kc,
@@ -100,6 +173,19 @@ func TestGetJobLog(t *testing.T) {
 	if err := ja.update(); err != nil {
 		t.Fatalf("Updating: %v", err)
 	}
+	if res, err := ja.GetJobLogForContainer("multi", "123", "helper"); err != nil {
+		t.Fatalf("Failed to get log: %v", err)
+	} else if got, expect := string(res), "clusterA.helper"; got != expect {
+		t.Errorf("Unexpected result getting logs for job 'multi'. Expected %q, but got %q.", expect, got)
+	}
+	if res, err := ja.GetJobLogForContainer("multi", "123", "sidecar"); err != nil {
+		t.Fatalf("Failed to get log: %v", err)
+	} else if got, expect := string(res), "clusterA.sidecar"; got != expect {
+		t.Errorf("Unexpected result getting logs for job 'multi'. Expected %q, but got %q.", expect, got)
+	}
+	if _, err := ja.GetJobLogForContainer("multi", "123", "unknown"); err == nil {
+		t.Fatalf("expected error getting logs of an unknown container")
+	}
 	if res, err := ja.GetJobLog("job", "123", kube.TestContainerName); err != nil {
 		t.Fatalf("Failed to get log: %v", err)
 	} else if got, expect := string(res), fmt.Sprintf("clusterA.%s", kube.TestContainerName); got != expect {
@@ -131,6 +217,356 @@ func TestGetJobLog(t *testing.T) {
 	}
 }
 
+func TestStatus(t *testing.T) {
+	kc := &flakyKc{
+		fkc: fkc{
+			prowapi.ProwJob{
+				Spec: prowapi.ProwJobSpec{
+					Agent: prowapi.KubernetesAgent,
+					Job:   "job",
+				},
+				Status: prowapi.ProwJobStatus{
+					PodName: "wowowow",
+					BuildID: "123",
+				},
+			},
+		},
+	}
+	ja := &JobAgent{kc: kc}
+	if lastUpdate, err := ja.Status(); !lastUpdate.IsZero() || err != nil {
+		t.Fatalf("Expected zero status before the first update, but got %v, %v.", lastUpdate, err)
+	}
+
+	if err := ja.update(); err != nil {
+		t.Fatalf("Updating: %v", err)
+	}
+	lastUpdate, err := ja.Status()
+	if lastUpdate.IsZero() || err != nil {
+		t.Fatalf("Expected successful status after the update, but got %v, %v.", lastUpdate, err)
+	}
+
+	kc.err = errors.New("injected error")
+	if err := ja.update(); err == nil {
+		t.Fatal("Expected update to fail.")
+	}
+	if gotUpdate, gotErr := ja.Status(); !gotUpdate.Equal(lastUpdate) || !errors.Is(gotErr, kc.err) {
+		t.Errorf("Expected status %v, %v after the failed update, but got %v, %v.", lastUpdate, kc.err, gotUpdate, gotErr)
+	}
+	if _, err := ja.GetProwJob("job", "123"); err != nil {
+		t.Errorf("Expected the jobs of the previous update to be kept: %v", err)
+	}
+	if got := len(ja.Jobs()); got != 1 {
+		t.Errorf("Expected 1 job from the previous update, but got %d.", got)
+	}
+
+	kc.err = nil
+	if err := ja.update(); err != nil {
+		t.Fatalf("Updating: %v", err)
+	}
+	if _, err := ja.Status(); err != nil {
+		t.Errorf("Expected the error to be cleared by a successful update, but got %v.", err)
+	}
+}
+
+// cpkc is a PodLogClient that counts how often it is asked for logs.
+type cpkc struct {
+	calls int
+}
+
+func (c *cpkc) GetLogs(name, container string) ([]byte, error) {
+	c.calls++
+	return fmt.Appendf(nil, "%s.%s", name, container), nil
+}
+
+type fakePodLogCache map[PodLogCacheKey][]byte
+
+func (c fakePodLogCache) Get(key PodLogCacheKey) ([]byte, bool) {
+	log, ok := c[key]
+	return log, ok
+}
+
+func (c fakePodLogCache) Add(key PodLogCacheKey, log []byte) bool {
+	c[key] = log
+	return true
+}
+
+func (c fakePodLogCache) MaxLogSize() int {
+	return 1 << 20
+}
+
+func TestGetJobLogCache(t *testing.T) {
+	completionTime := metav1.Now()
+	kc := fkc{
+		prowapi.ProwJob{
+			Spec: prowapi.ProwJobSpec{
+				Agent: prowapi.KubernetesAgent,
+				Job:   "complete",
+			},
+			Status: prowapi.ProwJobStatus{
+				State:          prowapi.SuccessState,
+				CompletionTime: &completionTime,
+				PodName:        "complete-pod",
+				BuildID:        "123",
+			},
+		},
+		prowapi.ProwJob{
+			Spec: prowapi.ProwJobSpec{
+				Agent: prowapi.KubernetesAgent,
+				Job:   "pending",
+			},
+			Status: prowapi.ProwJobStatus{
+				State:   prowapi.PendingState,
+				PodName: "pending-pod",
+				BuildID: "123",
+			},
+		},
+	}
+	testCases := []struct {
+		name          string
+		job           string
+		expectedLog   string
+		expectedCalls int
+	}{
+		{
+			name:          "logs of complete jobs are cached",
+			job:           "complete",
+			expectedLog:   "complete-pod.test",
+			expectedCalls: 1,
+		},
+		{
+			name:          "logs of pending jobs are not cached",
+			job:           "pending",
+			expectedLog:   "pending-pod.test",
+			expectedCalls: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &cpkc{}
+			ja := &JobAgent{
+				kc:   kc,
+				pkcs: map[string]PodLogClient{kube.DefaultClusterAlias: client},
+			}
+			ja.SetPodLogCache(fakePodLogCache{})
+			if err := ja.update(); err != nil {
+				t.Fatalf("Updating: %v", err)
+			}
+			for range 2 {
+				res, err := ja.GetJobLog(tc.job, "123", kube.TestContainerName)
+				if err != nil {
+					t.Fatalf("Failed to get log: %v", err)
+				}
+				if got := string(res); got != tc.expectedLog {
+					t.Errorf("Expected log %q, but got %q.", tc.expectedLog, got)
+				}
+			}
+			if client.calls != tc.expectedCalls {
+				t.Errorf("Expected %d calls to the pod log client, but got %d.", tc.expectedCalls, client.calls)
+			}
+		})
+	}
+}
+
+func TestStreamJobLog(t *testing.T) {
+	kc := fkc{
+		prowapi.ProwJob{
+			Spec: prowapi.ProwJobSpec{
+				Agent: prowapi.KubernetesAgent,
+				Job:   "job",
+			},
+			Status: prowapi.ProwJobStatus{
+				PodName: "wowowow",
+				BuildID: "123",
+			},
+		},
+		prowapi.ProwJob{
+			Spec: prowapi.ProwJobSpec{
+				Agent:   prowapi.KubernetesAgent,
+				Job:     "jib",
+				Cluster: "trusted",
+			},
+			Status: prowapi.ProwJobStatus{
+				PodName: "powowow",
+				BuildID: "123",
+			},
+		},
+	}
+	const logSize = 64 << 20
+	streamer := &fspkc{size: logSize}
+	ja := &JobAgent{
+		kc:   kc,
+		pkcs: map[string]PodLogClient{kube.DefaultClusterAlias: streamer, "trusted": fpkc("clusterB")},
+	}
+	if err := ja.update(); err != nil {
+		t.Fatalf("Updating: %v", err)
+	}
+
+	reader, err := ja.StreamJobLog("job", "123", kube.TestContainerName)
+	if err != nil {
+		t.Fatalf("Failed to stream log: %v", err)
+	}
+	head := make([]byte, 5)
+	if _, err := io.ReadFull(reader, head); err != nil {
+		t.Fatalf("Failed to read the start of the log: %v", err)
+	}
+	if expect, got := "abcde", string(head); expect != got {
+		t.Errorf("Expected the log to start with %q, but got %q.", expect, got)
+	}
+	if expect, got := len(head), streamer.reader.served; expect != got {
+		t.Errorf("Expected only %d bytes of the log to be generated, but got %d.", expect, got)
+	}
+	n, err := io.Copy(io.Discard, reader)
+	if err != nil {
+		t.Fatalf("Failed to read the rest of the log: %v", err)
+	}
+	if expect, got := int64(logSize-len(head)), n; expect != got {
+		t.Errorf("Expected %d more bytes of the log, but got %d.", expect, got)
+	}
+	if err := reader.Close(); err != nil {
+		t.Errorf("Failed to close the log: %v", err)
+	}
+	if !streamer.reader.closed {
+		t.Error("Expected the pod log stream to be closed.")
+	}
+
+	// Pod log clients that can't stream still work.
+	reader, err = ja.StreamJobLog("jib", "123", kube.TestContainerName)
+	if err != nil {
+		t.Fatalf("Failed to stream log: %v", err)
+	}
+	defer reader.Close()
+	log, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to read log: %v", err)
+	}
+	if expect, got := fmt.Sprintf("clusterB.%s", kube.TestContainerName), string(log); expect != got {
+		t.Errorf("Unexpected log of job 'jib'. Expected %q, but got %q.", expect, got)
+	}
+}
+
+func TestStreamJobLogCache(t *testing.T) {
+	completionTime := metav1.Now()
+	kc := fkc{
+		prowapi.ProwJob{
+			Spec: prowapi.ProwJobSpec{
+				Agent: prowapi.KubernetesAgent,
+				Job:   "job",
+			},
+			Status: prowapi.ProwJobStatus{
+				State:          prowapi.SuccessState,
+				CompletionTime: &completionTime,
+				PodName:        "wowowow",
+				BuildID:        "123",
+			},
+		},
+	}
+	testCases := []struct {
+		name        string
+		logSize     int
+		expectCache bool
+	}{
+		{
+			name:        "small logs of complete jobs are cached",
+			logSize:     1 << 10,
+			expectCache: true,
+		},
+		{
+			name:    "large logs of complete jobs are streamed",
+			logSize: 64 << 20,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			streamer := &fspkc{size: tc.logSize}
+			cache := fakePodLogCache{}
+			ja := &JobAgent{
+				kc:   kc,
+				pkcs: map[string]PodLogClient{kube.DefaultClusterAlias: streamer},
+			}
+			ja.SetPodLogCache(cache)
+			if err := ja.update(); err != nil {
+				t.Fatalf("Updating: %v", err)
+			}
+
+			reader, err := ja.StreamJobLog("job", "123", kube.TestContainerName)
+			if err != nil {
+				t.Fatalf("Failed to stream log: %v", err)
+			}
+			head := make([]byte, 5)
+			if _, err := io.ReadFull(reader, head); err != nil {
+				t.Fatalf("Failed to read the start of the log: %v", err)
+			}
+			if expect, got := len(head), streamer.reader.served; expect != got {
+				t.Errorf("Expected only %d bytes of the log to be generated, but got %d.", expect, got)
+			}
+			n, err := io.Copy(io.Discard, reader)
+			if err != nil {
+				t.Fatalf("Failed to read the rest of the log: %v", err)
+			}
+			if expect, got := int64(tc.logSize-len(head)), n; expect != got {
+				t.Errorf("Expected %d more bytes of the log, but got %d.", expect, got)
+			}
+			reader.Close()
+
+			key := PodLogCacheKey{Cluster: kube.DefaultClusterAlias, Pod: "wowowow", Container: kube.TestContainerName}
+			log, cached := cache[key]
+			if cached != tc.expectCache {
+				t.Fatalf("Expected the log to be cached: %t, but got %t.", tc.expectCache, cached)
+			}
+			if !cached {
+				return
+			}
+			if len(log) != tc.logSize {
+				t.Errorf("Expected a cached log of %d bytes, but got %d.", tc.logSize, len(log))
+			}
+			streamer.reader = nil
+			reader, err = ja.StreamJobLog("job", "123", kube.TestContainerName)
+			if err != nil {
+				t.Fatalf("Failed to stream log: %v", err)
+			}
+			defer reader.Close()
+			if streamer.reader != nil {
+				t.Error("Expected the cached log to be served without streaming it from the pod.")
+			}
+			if got, err := io.ReadAll(reader); err != nil {
+				t.Errorf("Failed to read the cached log: %v", err)
+			} else if !bytes.Equal(got, log) {
+				t.Error("Expected the cached log to be served.")
+			}
+		})
+	}
+}
+
+func TestPodLogCache(t *testing.T) {
+	cache := NewPodLogCache(10, 4, 0)
+	key := func(pod string) PodLogCacheKey {
+		return PodLogCacheKey{Cluster: kube.DefaultClusterAlias, Pod: pod, Container: kube.TestContainerName}
+	}
+	if cache.Add(key("large"), []byte("12345")) {
+		t.Error("Expected a log larger than the maximum log size not to be cached.")
+	}
+	for _, pod := range []string{"a", "b", "c"} {
+		if !cache.Add(key(pod), []byte("1234")) {
+			t.Errorf("Expected the log of pod %q to be cached.", pod)
+		}
+	}
+	if _, ok := cache.Get(key("a")); ok {
+		t.Error("Expected the oldest log to be evicted to stay within the maximum size.")
+	}
+	for _, pod := range []string{"b", "c"} {
+		if log, ok := cache.Get(key(pod)); !ok || string(log) != "1234" {
+			t.Errorf("Expected the log of pod %q to be cached, but got %q, %t.", pod, log, ok)
+		}
+	}
+
+	cache = NewPodLogCache(10, 4, time.Nanosecond)
+	cache.Add(key("a"), []byte("1234"))
+	time.Sleep(time.Millisecond)
+	if _, ok := cache.Get(key("a")); ok {
+		t.Error("Expected an expired log not to be served.")
+	}
+}
+
 func TestProwJobs(t *testing.T) {
 	kc := fkc{
 		prowapi.ProwJob{
@@ -178,6 +614,21 @@ func TestProwJobs(t *testing.T) {
 				StartTime: createTime(time.RFC3339, "2007-01-02T15:04:05.999Z"),
 			},
 		},
+		prowapi.ProwJob{
+			Spec: prowapi.ProwJobSpec{
+				Agent: prowapi.KubernetesAgent,
+				Job:   "jobSecond",
+				Refs: &prowapi.Refs{
+					Org:  "kubernetes",
+					Repo: "kubernetes",
+				},
+			},
+			Status: prowapi.ProwJobStatus{
+				PodName:   "otherrepo",
+				BuildID:   "1237",
+				StartTime: createTime(time.RFC3339, "2005-01-02T15:04:05.999Z"),
+			},
+		},
 	}
 	ja := &JobAgent{
 		kc:   kc,
@@ -188,7 +639,7 @@ func TestProwJobs(t *testing.T) {
 	}
 
 	pjs := ja.ProwJobs()
-	if expect, got := 3, len(pjs); expect != got {
+	if expect, got := 4, len(pjs); expect != got {
 		t.Fatalf("Expected %d prowjobs, but got %d.", expect, got)
 	}
 	if expect, got := "kubernetes", pjs[0].Spec.Refs.Org; expect != got {
@@ -203,6 +654,26 @@ func TestProwJobs(t *testing.T) {
 	if expect, got := "jobThird", pjs[2].Spec.Job; expect != got {
 		t.Errorf("Expected third prowjob to have job name %q, but got %q.", expect, got)
 	}
+
+	buildIDs := func(pjs []prowapi.ProwJob) []string {
+		var ids []string
+		for _, pj := range pjs {
+			ids = append(ids, pj.Status.BuildID)
+		}
+		return ids
+	}
+	if diff := cmp.Diff([]string{"1236", "1235", "1234"}, buildIDs(ja.ProwJobsForRepo("kubernetes", "test-infra"))); diff != "" {
+		t.Errorf("Unexpected prowjobs for kubernetes/test-infra (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"1237"}, buildIDs(ja.ProwJobsForRepo("kubernetes", "kubernetes"))); diff != "" {
+		t.Errorf("Unexpected prowjobs for kubernetes/kubernetes (-want +got):\n%s", diff)
+	}
+	if got := ja.ProwJobsForRepo("kubernetes", "missing"); len(got) != 0 {
+		t.Errorf("Expected no prowjobs for kubernetes/missing, but got %d.", len(got))
+	}
+	if diff := cmp.Diff([]string{"1235", "1237"}, buildIDs(ja.ProwJobsForJob("jobSecond"))); diff != "" {
+		t.Errorf("Unexpected prowjobs for jobSecond (-want +got):\n%s", diff)
+	}
 }
 
 func TestJobs(t *testing.T) {
@@ -90,6 +90,13 @@ const (
 	// utilities expect to find a full JSON configuration
 	// in when run.
 	JSONConfigEnvVar = "ENTRYPOINT_OPTIONS"
+
+	// MaxLogBytesEnvVar is the environment variable that
+	// holds the maximum number of bytes of output the
+	// entrypoint writes. It is set by plank rather than
+	// being part of the options, so that the limit can be
+	// configured centrally for all jobs.
+	MaxLogBytesEnvVar = "ENTRYPOINT_MAX_LOG_BYTES"
 )
 
 // ConfigVar exposes the environment variable used
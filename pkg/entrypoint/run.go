@@ -107,7 +107,15 @@ func (o Options) ExecuteProcess(signaledInterrupt chan os.Signal) (int, error) {
 	}
 	defer processLogFile.Close()
 
-	output := io.MultiWriter(os.Stdout, processLogFile)
+	var output io.Writer = io.MultiWriter(os.Stdout, processLogFile)
+	if maxLogBytes := os.Getenv(MaxLogBytesEnvVar); maxLogBytes != "" {
+		limit, err := strconv.ParseInt(maxLogBytes, 10, 64)
+		if err != nil || limit <= 0 {
+			logrus.Warnf("Ignoring invalid %s %q.", MaxLogBytesEnvVar, maxLogBytes)
+		} else {
+			output = &limitedWriter{w: output, remaining: limit}
+		}
+	}
 	logrus.SetOutput(output)
 	defer logrus.SetOutput(os.Stdout)
 
@@ -270,3 +278,32 @@ func gracefullyTerminate(command *exec.Cmd, done <-chan error, gracePeriod time.
 		}
 	}
 }
+
+// limitedWriter writes up to a limited number of bytes to the underlying
+// writer and drops everything after that. It never fails because of the
+// limit, so that the test process does not get interrupted by it.
+type limitedWriter struct {
+	w         io.Writer
+	remaining int64
+	truncated bool
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.truncated {
+		return len(p), nil
+	}
+	if int64(len(p)) <= l.remaining {
+		n, err := l.w.Write(p)
+		l.remaining -= int64(n)
+		return n, err
+	}
+	if _, err := l.w.Write(p[:l.remaining]); err != nil {
+		return 0, err
+	}
+	l.remaining = 0
+	l.truncated = true
+	if _, err := io.WriteString(l.w, "\nOutput truncated, the log size limit was reached.\n"); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package entrypoint
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -246,6 +247,47 @@ wait`},
 	}
 }
 
+func TestLimitedWriter(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		limit    int64
+		writes   []string
+		expected string
+	}{
+		{
+			name:     "output within the limit is written",
+			limit:    10,
+			writes:   []string{"hello", "world"},
+			expected: "helloworld",
+		},
+		{
+			name:     "output beyond the limit is dropped",
+			limit:    7,
+			writes:   []string{"hello", "world", "again"},
+			expected: "hellowo\nOutput truncated, the log size limit was reached.\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := &limitedWriter{w: &buf, remaining: testCase.limit}
+			for _, write := range testCase.writes {
+				n, err := w.Write([]byte(write))
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if n != len(write) {
+					t.Errorf("expected %d bytes to be reported as written, got %d", len(write), n)
+				}
+			}
+			if buf.String() != testCase.expected {
+				t.Errorf("expected output %q, got %q", testCase.expected, buf.String())
+			}
+		})
+	}
+}
+
 func compareFileContents(name, file, expected string, t *testing.T) {
 	data, err := os.ReadFile(file)
 	if err != nil {
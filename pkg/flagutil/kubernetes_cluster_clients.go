@@ -472,6 +472,17 @@ func (o *KubernetesOptions) BuildClusters(dryRun bool, requiredTestPodVerbs []st
 // CheckAuthorizations checks if we are able to perform the required actions
 // against test pods for the provided pod verbs (requiredTestPodVerbs).
 func CheckAuthorizations(client authorizationv1.SelfSubjectAccessReviewInterface, namespace string, requiredTestPodVerbs []string) error {
+	return checkAuthorizations(client, namespace, "pods", requiredTestPodVerbs)
+}
+
+// CheckNodeAuthorizations checks if we are able to perform the required
+// actions against the nodes of a cluster for the provided node verbs
+// (requiredNodeVerbs). Nodes are cluster-scoped, so no namespace is given.
+func CheckNodeAuthorizations(client authorizationv1.SelfSubjectAccessReviewInterface, requiredNodeVerbs []string) error {
+	return checkAuthorizations(client, "", "nodes", requiredNodeVerbs)
+}
+
+func checkAuthorizations(client authorizationv1.SelfSubjectAccessReviewInterface, namespace, resource string, requiredVerbs []string) error {
 
 	var errs []error
 	// Unfortunately we have to do multiple API requests because there is no way
@@ -501,13 +512,13 @@ func CheckAuthorizations(client authorizationv1.SelfSubjectAccessReviewInterface
 	// See
 	// https://kubernetes.io/docs/reference/access-authn-authz/authorization/#checking-api-access
 	// for more information.
-	for _, verb := range requiredTestPodVerbs {
+	for _, verb := range requiredVerbs {
 		ssar := k8sauthorizationv1.SelfSubjectAccessReview{
 			Spec: k8sauthorizationv1.SelfSubjectAccessReviewSpec{
 				ResourceAttributes: &k8sauthorizationv1.ResourceAttributes{
 					Namespace: namespace,
 					Verb:      verb,
-					Resource:  "pods",
+					Resource:  resource,
 				},
 			},
 		}
@@ -518,7 +529,7 @@ func CheckAuthorizations(client authorizationv1.SelfSubjectAccessReviewInterface
 		}
 
 		if !ssarExpanded.Status.Allowed {
-			errs = append(errs, fmt.Errorf("%w: unable to %q pods", MissingPermissions, verb))
+			errs = append(errs, fmt.Errorf("%w: unable to %q %s", MissingPermissions, verb, resource))
 		}
 	}
 
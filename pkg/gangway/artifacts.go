@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gangway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	prowcrd "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+// ArtifactLookup looks up job executions that are finished and whose ProwJob
+// is gone, e.g. because sinker garbage-collected it, from their artifacts.
+type ArtifactLookup interface {
+	// GetFinishedJob returns the finished job execution with the given id, or
+	// nil if it knows of no such job execution.
+	GetFinishedJob(ctx context.Context, id string) (*JobExecution, error)
+}
+
+// GCSArtifactLookup reconstructs finished job executions from the started.json
+// and finished.json files that the pod utilities upload with their artifacts.
+//
+// The storage path of the artifacts depends on the job and its build ID, which
+// are only known from the ProwJob. GCSArtifactLookup therefore expects an index
+// with an object per job execution under IndexDir, named after the id of the
+// job execution and holding the storage path of its artifacts, e.g.
+// gs://bucket/logs/my-job/1234. Crier's blob storage reporter writes this index
+// when it is started with the same --artifact-index-dir.
+type GCSArtifactLookup struct {
+	Opener   io.Opener
+	IndexDir string
+}
+
+// GetFinishedJob returns the finished job execution with the given id, or nil
+// if the index has no entry for it or its job did not finish.
+func (l *GCSArtifactLookup) GetFinishedJob(ctx context.Context, id string) (*JobExecution, error) {
+	log := logrus.WithField("id", id)
+
+	index, err := io.ReadContent(ctx, log, l.Opener, strings.TrimSuffix(l.IndexDir, "/")+"/"+id)
+	if err != nil {
+		if io.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read the artifact index of %q: %w", id, err)
+	}
+	artifactDir := strings.TrimSuffix(strings.TrimSpace(string(index)), "/")
+	if artifactDir == "" {
+		return nil, nil
+	}
+
+	var started metadata.Started
+	if found, err := l.readJSON(ctx, log, artifactDir+"/"+prowcrd.StartedStatusFile, &started); err != nil || !found {
+		return nil, err
+	}
+	var finished metadata.Finished
+	if found, err := l.readJSON(ctx, log, artifactDir+"/"+prowcrd.FinishedStatusFile, &finished); err != nil || !found {
+		return nil, err
+	}
+
+	jobExec := &JobExecution{
+		Id:        id,
+		JobName:   getJobNameFromArtifactDir(artifactDir),
+		JobStatus: getFinishedJobStatus(&finished),
+		GcsPrefix: artifactDir,
+	}
+	if started.Timestamp != 0 {
+		jobExec.CreateTime = timestamppb.New(time.Unix(started.Timestamp, 0))
+	}
+	if finished.Timestamp != nil {
+		jobExec.CompletionTime = timestamppb.New(time.Unix(*finished.Timestamp, 0))
+	}
+	return jobExec, nil
+}
+
+// readJSON unmarshals the object at the given path into v. It returns false if
+// there is no such object.
+func (l *GCSArtifactLookup) readJSON(ctx context.Context, log *logrus.Entry, path string, v interface{}) (bool, error) {
+	content, err := io.ReadContent(ctx, log, l.Opener, path)
+	if err != nil {
+		if io.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		return false, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// getJobNameFromArtifactDir returns the name of the job from the storage path of
+// its artifacts. The pod utilities always upload the artifacts of a job to
+// <...>/<job name>/<build ID>.
+func getJobNameFromArtifactDir(artifactDir string) string {
+	return path.Base(path.Dir(artifactDir))
+}
+
+// getFinishedJobStatus translates the result recorded in finished.json into a
+// job execution status.
+func getFinishedJobStatus(finished *metadata.Finished) JobExecutionStatus {
+	switch finished.Result {
+	case "SUCCESS":
+		return JobExecutionStatus_SUCCESS
+	case "FAILURE":
+		return JobExecutionStatus_FAILURE
+	case "ABORTED":
+		return JobExecutionStatus_ABORTED
+	case "ERROR":
+		return JobExecutionStatus_ERROR
+	}
+	if finished.Passed != nil && *finished.Passed {
+		return JobExecutionStatus_SUCCESS
+	}
+	return JobExecutionStatus_FAILURE
+}
@@ -18,27 +18,46 @@ package gangway
 
 import (
 	context "context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"maps"
+	"net/url"
+	"path"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
 	codes "google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	status "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	prowcrd "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/cron"
+	"sigs.k8s.io/prow/pkg/gcsupload"
 	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/pjutil"
+	"sigs.k8s.io/prow/pkg/pod-utils/clone"
+	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
 	"sigs.k8s.io/prow/pkg/version"
 )
 
@@ -54,15 +73,39 @@ type Gangway struct {
 	ConfigAgent        *config.Agent
 	ProwJobClient      ProwJobClient
 	InRepoConfigGetter config.InRepoConfigGetter
+	// PodClients look up the pods of job executions in the pod namespace of
+	// every build cluster, to return the statuses of their containers. May
+	// be nil.
+	PodClients map[string]PodClient
+	// ArtifactLookup looks up finished job executions whose ProwJob is gone.
+	// May be nil.
+	ArtifactLookup ArtifactLookup
+
+	// bulkJobStatusChanges tracks the progress of the BulkJobStatusChange
+	// requests with a correlation id, keyed by that id.
+	bulkJobStatusChanges     map[string]*bulkJobStatusChange
+	bulkJobStatusChangesLock sync.Mutex
+
+	// rateLimiters limit the rate of the CreateJobExecution requests of the
+	// API clients with a QPS, keyed by the UUID of the client.
+	rateLimiters     map[string]*rate.Limiter
+	rateLimitersLock sync.Mutex
+}
+
+// PodClient describes a Kubernetes client for the pods of job executions. It
+// only expects a Get() method.
+type PodClient interface {
+	Get(context.Context, string, metav1.GetOptions) (*v1.Pod, error)
 }
 
 // ProwJobClient describes a Kubernetes client for the Prow Job CR. Unlike a
-// general-purpose client, it only expects 4 methods, Create(), Get(), List() and Update().
+// general-purpose client, it only expects 5 methods, Create(), Get(), List(), Update() and Patch().
 type ProwJobClient interface {
 	Create(context.Context, *prowcrd.ProwJob, metav1.CreateOptions) (*prowcrd.ProwJob, error)
 	Get(context.Context, string, metav1.GetOptions) (*prowcrd.ProwJob, error)
 	List(context.Context, metav1.ListOptions) (*prowcrd.ProwJobList, error)
 	Update(context.Context, *prowcrd.ProwJob, metav1.UpdateOptions) (*prowcrd.ProwJob, error)
+	Patch(ctx context.Context, name string, pt ktypes.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*prowcrd.ProwJob, error)
 }
 
 // CreateJobExecution triggers a new Prow job.
@@ -80,13 +123,6 @@ func (gw *Gangway) CreateJobExecution(ctx context.Context, cjer *CreateJobExecut
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	// FIXME (listx) Add execution token generation database call, so that we can
-	// reduce the delay between the initial call and the creation of the ProwJob
-	// CR. We should probably use UUIDv7 (see
-	// https://www.ietf.org/archive/id/draft-peabody-dispatch-new-uuid-format-01.html).
-	// Also see FireBase's PushID for comparison:
-	// https://firebase.blog/posts/2015/02/the-2120-ways-to-ensure-unique_68.
-
 	// Identify the client from the request metadata.
 	mainConfig := ProwCfgAdapter{gw.ConfigAgent.Config()}
 	allowedApiClient, err := mainConfig.IdentifyAllowedClient(md)
@@ -100,7 +136,35 @@ func (gw *Gangway) CreateJobExecution(ctx context.Context, cjer *CreateJobExecut
 		l = logrus.NewEntry(logrus.New())
 	}
 
-	allowedClusters := []string{"*"}
+	if !gw.allowRequest(allowedApiClient) {
+		l.Info("client exceeded its rate limit")
+		return nil, status.Error(codes.ResourceExhausted, "client exceeded its rate limit, try again later")
+	}
+
+	if key := cjer.GetIdempotencyKey(); key != "" {
+		idempotencyLabel := getIdempotencyLabel(allowedApiClient, key)
+		existing, err := gw.findIdempotentJobExecution(ctx, idempotencyLabel)
+		if err != nil {
+			l.WithError(err).Error("failed to look up job executions by idempotency key")
+			return nil, status.Error(codes.Internal, "failed to look up job executions by idempotency key")
+		}
+		if existing != nil {
+			l.WithField("name", existing.GetId()).Info("Job execution already created with this idempotency key.")
+			return existing, nil
+		}
+		// The request was validated already, so it is fine to add a label that
+		// clients may not set themselves.
+		cjer = proto.Clone(cjer).(*CreateJobExecutionRequest)
+		if cjer.PodSpecOptions == nil {
+			cjer.PodSpecOptions = &PodSpecOptions{}
+		}
+		if cjer.PodSpecOptions.Labels == nil {
+			cjer.PodSpecOptions.Labels = map[string]string{}
+		}
+		cjer.PodSpecOptions.Labels[kube.IdempotencyKeyLabel] = idempotencyLabel
+	}
+
+	allowedClusters := allowedApiClient.GetAllowedClusters()
 	var reporterFunc ReporterFunc = nil
 	requireTenantID := true
 
@@ -109,38 +173,458 @@ func (gw *Gangway) CreateJobExecution(ctx context.Context, cjer *CreateJobExecut
 		logrus.WithError(err).Debugf("failed to create job %q", cjer.GetJobName())
 		return nil, err
 	}
+	fillAudit(jobExec.GetAudit(), allowedApiClient, md)
 
 	return jobExec, nil
 }
 
+// getIdempotencyLabel returns the value of the IdempotencyKeyLabel for the
+// given idempotency key of the given API client. The key is hashed together
+// with the client, so that keys of different clients never collide and any key
+// makes a valid label value.
+func getIdempotencyLabel(allowedApiClient *config.AllowedApiClient, key string) string {
+	var client string
+	if cv, err := allowedApiClient.GetApiClientCloudVendor(); err == nil {
+		client = cv.GetUUID()
+	}
+	hash := sha256.Sum256([]byte(client + "\x00" + key))
+	return hex.EncodeToString(hash[:])[:validation.LabelValueMaxLength]
+}
+
+// findIdempotentJobExecution returns the job execution whose ProwJob carries the
+// given IdempotencyKeyLabel value, or nil if there is none. Concurrent requests
+// with the same key may still both create a ProwJob.
+func (gw *Gangway) findIdempotentJobExecution(ctx context.Context, idempotencyLabel string) (*JobExecution, error) {
+	prowJobCRs, err := gw.ProwJobClient.List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{kube.IdempotencyKeyLabel: idempotencyLabel}).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(prowJobCRs.Items) == 0 {
+		return nil, nil
+	}
+	return gw.GetJobExecution(ctx, &GetJobExecutionRequest{Id: prowJobCRs.Items[0].Name})
+}
+
+// fillAudit records the API client that created a job execution and the
+// headers it got identified by in audit.
+func fillAudit(audit *Audit, allowedApiClient *config.AllowedApiClient, md *metadata.MD) {
+	if audit == nil || allowedApiClient == nil {
+		return
+	}
+	cv, err := allowedApiClient.GetApiClientCloudVendor()
+	if err != nil {
+		return
+	}
+	audit.Client = cv.GetUUID()
+	headers := getKnownRequestHeaders(cv, md)
+	audit.ConsumerType = headers[HEADER_API_CONSUMER_TYPE]
+	audit.ConsumerId = headers[HEADER_API_CONSUMER_ID]
+}
+
+// allowRequest returns whether the given API client may make another
+// CreateJobExecution request now, according to its rate limit.
+func (gw *Gangway) allowRequest(allowedApiClient *config.AllowedApiClient) bool {
+	if allowedApiClient == nil || allowedApiClient.QPS <= 0 {
+		return true
+	}
+	cv, err := allowedApiClient.GetApiClientCloudVendor()
+	if err != nil {
+		return true
+	}
+	limit, burst := rate.Limit(allowedApiClient.QPS), max(allowedApiClient.Burst, 1)
+
+	gw.rateLimitersLock.Lock()
+	defer gw.rateLimitersLock.Unlock()
+	limiter, exists := gw.rateLimiters[cv.GetUUID()]
+	if !exists {
+		if gw.rateLimiters == nil {
+			gw.rateLimiters = map[string]*rate.Limiter{}
+		}
+		limiter = rate.NewLimiter(limit, burst)
+		gw.rateLimiters[cv.GetUUID()] = limiter
+	} else if limiter.Limit() != limit || limiter.Burst() != burst {
+		// The rate limit of the client changed with the config.
+		limiter.SetLimit(limit)
+		limiter.SetBurst(burst)
+	}
+	return limiter.Allow()
+}
+
 // GetJobExecution returns a Prow job execution. It currently does this by
 // looking at all of the existing Prow Job CR (custom resource) objects to find
 // a match, and then does a translation from the CR into our JobExecution type.
-// In the future this function will also perform a lookup in GCS or some other
-// more permanent location as a fallback.
+// If there is no such CR, e.g. because sinker garbage-collected it, it falls back
+// to the ArtifactLookup, if any, to reconstruct the job execution from its
+// artifacts.
 func (gw *Gangway) GetJobExecution(ctx context.Context, gjer *GetJobExecutionRequest) (*JobExecution, error) {
 	prowJobCR, err := gw.ProwJobClient.Get(context.TODO(), gjer.Id, metav1.GetOptions{})
 	if err != nil {
+		if kerrors.IsNotFound(err) && gw.ArtifactLookup != nil {
+			jobExec, lookupErr := gw.ArtifactLookup.GetFinishedJob(ctx, gjer.Id)
+			if lookupErr != nil {
+				logrus.WithError(lookupErr).Warnf("failed to look up the artifacts of job execution %q", gjer.Id)
+			} else if jobExec != nil {
+				return jobExec, nil
+			}
+		}
 		return nil, err
 	}
 
 	jobExec := &JobExecution{
-		Id:        prowJobCR.Name,
-		JobName:   prowJobCR.Spec.Job,
-		JobType:   TranslateProwJobType(prowJobCR.Spec.Type),
-		JobStatus: TranslateProwJobStatus(&prowJobCR.Status),
-		JobUrl:    prowJobCR.Status.URL,
+		Id:               prowJobCR.Name,
+		JobName:          prowJobCR.Spec.Job,
+		JobType:          TranslateProwJobType(prowJobCR.Spec.Type),
+		JobStatus:        TranslateProwJobStatus(&prowJobCR.Status),
+		JobUrl:           prowJobCR.Status.URL,
+		CloneUrls:        getCloneUrls(&prowJobCR.Spec),
+		InfraFailure:     isInfraFailure(prowJobCR),
+		DeckUrl:          getDeckUrl(gw.ConfigAgent.Config().Gangway.DeckURL, prowJobCR.Name),
+		Decorated:        prowJobCR.Spec.DecorationConfig != nil,
+		RerunCommand:     prowJobCR.Spec.RerunCommand,
+		Env:              getMainContainerEnv(prowJobCR.Spec.PodSpec),
+		Uid:              string(prowJobCR.UID),
+		Cluster:          prowJobCR.Spec.Cluster,
+		CrossRepo:        isCrossRepo(&prowJobCR.Spec),
+		ExtraRefs:        getExtraRefs(&prowJobCR.Spec),
+		MaxConcurrency:   int32(prowJobCR.Spec.MaxConcurrency),
+		JobQueueCapacity: getJobQueueCapacity(gw.ConfigAgent.Config().Plank.JobQueueCapacities, prowJobCR.Spec.JobQueueName),
+		GcsPrefix:        getGcsPrefix(prowJobCR),
+		ReportLinks:      getReportLinks(prowJobCR),
+	}
+	jobExec.Command, jobExec.Args = getMainContainerCommand(prowJobCR.Spec.PodSpec)
+	jobExec.PrivilegedAccessReasons = getPrivilegedAccessReasons(prowJobCR.Spec.PodSpec)
+	jobExec.PrivilegedAccess = len(jobExec.PrivilegedAccessReasons) > 0
+	jobExec.ContainerStatuses = gw.getContainerStatuses(ctx, prowJobCR)
+	jobExec.AheadInQueue = gw.getAheadInQueue(ctx, prowJobCR)
+
+	if !prowJobCR.CreationTimestamp.IsZero() {
+		jobExec.SubmitTime = timestamppb.New(prowJobCR.CreationTimestamp.Time)
 	}
-
 	if !prowJobCR.Status.StartTime.IsZero() {
 		jobExec.CreateTime = timestamppb.New(prowJobCR.Status.StartTime.Time)
 	}
 	if prowJobCR.Status.CompletionTime != nil && !prowJobCR.Status.CompletionTime.IsZero() {
 		jobExec.CompletionTime = timestamppb.New(prowJobCR.Status.CompletionTime.Time)
 	}
+	if lastUpdateTime := getLastUpdateTime(&prowJobCR.Status); !lastUpdateTime.IsZero() {
+		jobExec.LastUpdateTime = timestamppb.New(lastUpdateTime)
+	}
 	return jobExec, nil
 }
 
+// getGcsPrefix returns the storage prefix the pod utilities upload the
+// artifacts of a decorated job to, or an empty string if the job is not
+// decorated, has nowhere to upload to or did not get a build ID yet.
+func getGcsPrefix(pj *prowcrd.ProwJob) string {
+	decorationConfig := pj.Spec.DecorationConfig
+	if decorationConfig == nil || decorationConfig.GCSConfiguration == nil || decorationConfig.GCSConfiguration.Bucket == "" || pj.Status.BuildID == "" {
+		return ""
+	}
+	if pj.Spec.Type == prowcrd.PresubmitJob && (pj.Spec.Refs == nil || len(pj.Spec.Refs.Pulls) == 0) {
+		return ""
+	}
+	prowPath, err := prowcrd.ParsePath(decorationConfig.GCSConfiguration.Bucket)
+	if err != nil {
+		logrus.WithError(err).WithField("prowjob", pj.Name).Debug("Failed to parse the bucket of the job.")
+		return ""
+	}
+	spec := downwardapi.NewJobSpec(pj.Spec, pj.Status.BuildID, pj.Name)
+	_, gcsPath, _ := gcsupload.PathsForJob(decorationConfig.GCSConfiguration, &spec, "")
+	return fmt.Sprintf("%s://%s", prowPath.StorageProvider(), path.Join(prowPath.FullPath(), gcsPath))
+}
+
+// getReportLinks returns the links to where crier reports the results of the
+// job, keyed by the report target. Jobs scheduled by the Gerrit adapter are
+// reported to the change they run against, other presubmits and postsubmits
+// to the commit they run against on GitHub.
+func getReportLinks(pj *prowcrd.ProwJob) map[string]string {
+	refs := pj.Spec.Refs
+	if !pj.Spec.Report || refs == nil {
+		return nil
+	}
+	links := map[string]string{}
+	switch {
+	case pj.Annotations[kube.GerritInstance] != "" && pj.Labels[kube.GerritRevision] != "":
+		if len(refs.Pulls) > 0 && refs.Pulls[0].Link != "" {
+			links["gerrit"] = refs.Pulls[0].Link
+		}
+	case pj.Labels[kube.GerritReportLabel] != "":
+		// The GitHub reporter leaves out jobs that vote on a Gerrit label.
+	case pj.Spec.Type == prowcrd.PresubmitJob:
+		if len(refs.Pulls) == 0 {
+			break
+		}
+		if link := refs.Pulls[0].CommitLink; link != "" {
+			links["github"] = link
+		} else if refs.RepoLink != "" {
+			links["github"] = fmt.Sprintf("%s/commit/%s", refs.RepoLink, refs.Pulls[0].SHA)
+		}
+	case pj.Spec.Type == prowcrd.PostsubmitJob:
+		if refs.BaseLink != "" {
+			links["github"] = refs.BaseLink
+		} else if refs.RepoLink != "" && refs.BaseSHA != "" {
+			links["github"] = fmt.Sprintf("%s/commit/%s", refs.RepoLink, refs.BaseSHA)
+		}
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	return links
+}
+
+// getAheadInQueue returns the number of triggered jobs that are subject to
+// the same concurrency limits as the triggered job pj and were created
+// before it. Plank starts jobs held back by these limits in the order of
+// their creation, so these jobs get started before pj. Only the jobs of the
+// same job and the jobs in the same queue are listed, by their labels.
+func (gw *Gangway) getAheadInQueue(ctx context.Context, pj *prowcrd.ProwJob) int32 {
+	if pj.Status.State != prowcrd.TriggeredState || (pj.Spec.MaxConcurrency == 0 && pj.Spec.JobQueueName == "") {
+		return 0
+	}
+	var selectors []map[string]string
+	if pj.Spec.MaxConcurrency > 0 {
+		selectors = append(selectors, map[string]string{kube.ProwJobAnnotation: pj.Labels[kube.ProwJobAnnotation]})
+	}
+	if pj.Spec.JobQueueName != "" {
+		selectors = append(selectors, map[string]string{kube.JobQueueLabel: pj.Labels[kube.JobQueueLabel]})
+	}
+
+	counted := sets.New[string]()
+	for _, selector := range selectors {
+		prowJobCRs, err := gw.ProwJobClient.List(ctx, getListOptions(&metav1.LabelSelector{MatchLabels: selector}))
+		if err != nil {
+			logrus.WithError(err).WithField("prowjob", pj.Name).Warn("Failed to list ProwJobs to count the jobs ahead in the queue.")
+			return 0
+		}
+		for _, sibling := range prowJobCRs.Items {
+			if sibling.Complete() || sibling.Status.State != prowcrd.TriggeredState {
+				continue
+			}
+			if sibling.Name == pj.Name || counted.Has(sibling.Name) || !sibling.CreationTimestamp.Before(&pj.CreationTimestamp) {
+				continue
+			}
+			sameJob := pj.Spec.MaxConcurrency > 0 && sibling.Spec.Job == pj.Spec.Job
+			sameQueue := pj.Spec.JobQueueName != "" && sibling.Spec.JobQueueName == pj.Spec.JobQueueName
+			if sameJob || sameQueue {
+				counted.Insert(sibling.Name)
+			}
+		}
+	}
+	return int32(counted.Len())
+}
+
+// getContainerStatuses returns the statuses of the containers of the pod of
+// the job, or nil if the pod doesn't exist (anymore) or can't be looked up.
+func (gw *Gangway) getContainerStatuses(ctx context.Context, pj *prowcrd.ProwJob) []*ContainerStatus {
+	if pj.Status.PodName == "" {
+		return nil
+	}
+	podClient, ok := gw.PodClients[pj.ClusterAlias()]
+	if !ok {
+		return nil
+	}
+	pod, err := podClient.Get(ctx, pj.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			logrus.WithError(err).WithField("prowjob", pj.Name).Warn("Failed to get the pod of the job.")
+		}
+		return nil
+	}
+
+	var containerStatuses []*ContainerStatus
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		containerStatus := &ContainerStatus{
+			Name:         status.Name,
+			State:        "unknown",
+			Ready:        status.Ready,
+			RestartCount: status.RestartCount,
+		}
+		switch {
+		case status.State.Waiting != nil:
+			containerStatus.State = "waiting"
+		case status.State.Running != nil:
+			containerStatus.State = "running"
+		case status.State.Terminated != nil:
+			containerStatus.State = "terminated"
+		}
+		containerStatuses = append(containerStatuses, containerStatus)
+	}
+	return containerStatuses
+}
+
+// getLastUpdateTime returns the last time the status of a job changed. Jobs
+// that plank did not update yet fall back to the latest timestamp in their
+// status.
+func getLastUpdateTime(pjStatus *prowcrd.ProwJobStatus) time.Time {
+	if pjStatus.LastReconcileTime != nil {
+		return pjStatus.LastReconcileTime.Time
+	}
+	lastUpdateTime := pjStatus.StartTime.Time
+	for _, t := range []*metav1.Time{pjStatus.PendingTime, pjStatus.CompletionTime} {
+		if t != nil && t.After(lastUpdateTime) {
+			lastUpdateTime = t.Time
+		}
+	}
+	return lastUpdateTime
+}
+
+// GetJobExecutionStatus returns only the status of a Prow job execution. It is
+// cheaper than GetJobExecution for clients that poll for the completion of a
+// job, as none of the other fields of the job execution are built.
+func (gw *Gangway) GetJobExecutionStatus(ctx context.Context, request *GetJobExecutionStatusRequest) (*JobStatusResponse, error) {
+	prowJobCR, err := gw.ProwJobClient.Get(context.TODO(), request.GetId(), metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "job execution %q not found", request.GetId())
+		}
+		logrus.WithError(err).Errorf("failed to get ProwJob %q", request.GetId())
+		return nil, status.Error(codes.Internal, "failed to get job execution status")
+	}
+
+	return &JobStatusResponse{
+		Status:   TranslateProwJobStatus(&prowJobCR.Status),
+		Complete: prowJobCR.Complete(),
+		Url:      prowJobCR.Status.URL,
+	}, nil
+}
+
+// CancelJobExecution aborts a job execution of the calling tenant that is not
+// complete yet. Plank takes care of deleting the pod of the aborted job.
+func (gw *Gangway) CancelJobExecution(ctx context.Context, request *CancelJobExecutionRequest) (*JobStatusResponse, error) {
+	err, md := getHttpRequestHeaders(ctx)
+	if err != nil {
+		logrus.WithError(err).Debug("could not find request HTTP headers")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if request.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id field cannot be empty")
+	}
+
+	mainConfig := gw.ConfigAgent.Config()
+	allowedApiClient, err := mainConfig.IdentifyAllowedClient(md)
+	if err != nil {
+		logrus.WithError(err).Debug("could not find client in allowlist")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	prowJobCR, err := gw.ProwJobClient.Get(ctx, request.GetId(), metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "job execution %q not found", request.GetId())
+		}
+		logrus.WithError(err).Errorf("failed to get ProwJob %q", request.GetId())
+		return nil, status.Error(codes.Internal, "failed to get job execution")
+	}
+
+	if !ClientAuthorized(allowedApiClient, *prowJobCR) {
+		logrus.WithField("name", prowJobCR.Name).Error("client is not authorized to cancel the given job")
+		return nil, status.Error(codes.PermissionDenied, "client is not authorized to cancel the given job")
+	}
+	if prowJobCR.Complete() {
+		return nil, status.Errorf(codes.FailedPrecondition, "job execution %q is already complete", request.GetId())
+	}
+
+	// Patch instead of updating, so that a concurrent write to the ProwJob, e.g.
+	// by plank, does not fail the cancellation with a conflict.
+	abortedProwJobCR := prowJobCR.DeepCopy()
+	abortedProwJobCR.Status.State = prowcrd.AbortedState
+	updatedProwJobCR, err := pjutil.PatchProwjob(ctx, gw.ProwJobClient, logrus.WithField("name", prowJobCR.Name), *prowJobCR, *abortedProwJobCR)
+	if err != nil {
+		logrus.WithError(err).Errorf("failed to abort ProwJob %q", request.GetId())
+		return nil, status.Error(codes.Internal, "failed to cancel job execution")
+	}
+	logrus.WithField("name", prowJobCR.Name).Info("ProwJob aborted.")
+
+	return &JobStatusResponse{
+		Status:   TranslateProwJobStatus(&updatedProwJobCR.Status),
+		Complete: updatedProwJobCR.Complete(),
+		Url:      updatedProwJobCR.Status.URL,
+	}, nil
+}
+
+// GetJobExecutions returns several Prow job executions at once. Ids no job
+// execution is found for are reported as missing instead of failing the whole
+// request.
+func (gw *Gangway) GetJobExecutions(ctx context.Context, request *GetJobExecutionsRequest) (*JobExecutions, error) {
+	if len(request.GetIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "ids field cannot be empty")
+	}
+
+	jobExecs := &JobExecutions{}
+	for _, id := range request.GetIds() {
+		jobExec, err := gw.GetJobExecution(ctx, &GetJobExecutionRequest{Id: id})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				jobExecs.MissingIds = append(jobExecs.MissingIds, id)
+				continue
+			}
+			logrus.WithError(err).Errorf("failed to get ProwJob %q", id)
+			return nil, status.Error(codes.Internal, "failed to get job executions")
+		}
+		jobExecs.JobExecution = append(jobExecs.JobExecution, jobExec)
+	}
+	return jobExecs, nil
+}
+
+// getCloneUrls returns the URLs of all the repositories that a job with the
+// given spec clones, the base repository first, followed by the extra refs.
+func getCloneUrls(prowJobSpec *prowcrd.ProwJobSpec) []string {
+	var cloneUrls []string
+	if prowJobSpec.Refs != nil {
+		cloneUrls = append(cloneUrls, clone.RepositoryURIForRefs(*prowJobSpec.Refs))
+	}
+	for _, refs := range prowJobSpec.ExtraRefs {
+		cloneUrls = append(cloneUrls, clone.RepositoryURIForRefs(refs))
+	}
+	return cloneUrls
+}
+
+// getExtraRefs returns the extra refs of a job with the given spec.
+func getExtraRefs(prowJobSpec *prowcrd.ProwJobSpec) []*Refs {
+	var extraRefs []*Refs
+	for i := range prowJobSpec.ExtraRefs {
+		// FromCrdRefs only fails for nil refs.
+		refs, _ := FromCrdRefs(&prowJobSpec.ExtraRefs[i])
+		extraRefs = append(extraRefs, refs)
+	}
+	return extraRefs
+}
+
+// getJobQueueCapacity returns the capacity of the job queue with the given
+// name like plank applies it, or -1 if there is no limit. Jobs in queues that
+// are not configured are never started by plank.
+func getJobQueueCapacity(capacities map[string]int, queueName string) int32 {
+	if queueName == "" {
+		return -1
+	}
+	capacity, ok := capacities[queueName]
+	if !ok {
+		return 0
+	}
+	if capacity < 0 {
+		return -1
+	}
+	return int32(capacity)
+}
+
+// getDeckUrl returns the URL of the view of the ProwJob in Deck, or an empty
+// string if no Deck is configured.
+func getDeckUrl(deckURL, prowJobName string) string {
+	if deckURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/prowjob?prowjob=%s", strings.TrimSuffix(deckURL, "/"), url.QueryEscape(prowJobName))
+}
+
+// isInfraFailure returns true if plank errored the job because of a problem
+// with the infrastructure, as recorded in the error reason annotation.
+func isInfraFailure(prowJobCR *prowcrd.ProwJob) bool {
+	return prowJobCR.Status.State == prowcrd.ErrorState && kube.IsInfraErrorReason(prowJobCR.Annotations[kube.ErrorReasonAnnotation])
+}
+
 // Translate ProwJobStatus.State in the Prow Job CR into a JobExecutionStatus.
 func TranslateProwJobStatus(prowJobStatus *prowcrd.ProwJobStatus) JobExecutionStatus {
 	var jobStatus JobExecutionStatus
@@ -193,10 +677,22 @@ func TranslateProwJobType(prowJobType prowcrd.ProwJobType) JobExecutionType {
 // In the future this function will also perform a lookup in GCS or some other
 // more permanent location as a fallback.
 func (gw *Gangway) ListJobExecutions(ctx context.Context, ljer *ListJobExecutionsRequest) (*JobExecutions, error) {
+	if err := ljer.Validate(); err != nil {
+		logrus.WithError(err).Debug("could not validate request fields")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	options := getListOptions(getListRequestLabelSelector(ljer))
+	options.Limit = int64(ljer.GetPageSize())
+	options.Continue = ljer.GetPageToken()
 	prowJobCRs, err := gw.ProwJobClient.List(context.TODO(), options)
 	if err != nil {
+		// The API server rejects malformed and expired continue tokens.
+		if ljer.GetPageToken() != "" && (kerrors.IsBadRequest(err) || kerrors.IsResourceExpired(err)) {
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired page_token")
+		}
 		logrus.WithError(err).Errorf("failed to list ProwJobs")
+		return nil, status.Error(codes.Internal, "failed to list job executions")
 	}
 
 	var jobList []*JobExecution
@@ -204,29 +700,385 @@ func (gw *Gangway) ListJobExecutions(ctx context.Context, ljer *ListJobExecution
 		if ljer.Status != JobExecutionStatus_JOB_EXECUTION_STATUS_UNSPECIFIED && TranslateProwJobStatus(&pj.Status) != ljer.Status {
 			continue
 		}
-		jobList = append(jobList, &JobExecution{
-			Id:        pj.Name,
-			JobName:   pj.Spec.Job,
-			JobStatus: TranslateProwJobStatus(&pj.Status),
-			JobType:   TranslateProwJobType(pj.Spec.Type),
-		})
+		jobList = append(jobList, listedJobExecution(&pj))
 
 	}
 
 	jobExecs := &JobExecutions{
-		JobExecution: jobList,
+		JobExecution:  jobList,
+		NextPageToken: prowJobCRs.Continue,
 	}
 	return jobExecs, nil
 }
 
+// streamListPageSize is how many ProwJobs StreamJobExecutions lists at once.
+const streamListPageSize = 500
+
+// StreamJobExecutions sends the job executions ListJobExecutions returns one
+// by one. The ProwJobs are listed in pages, so that neither gangway nor the
+// client has to hold all of them at once, and the listing stops as soon as
+// the client goes away.
+func (gw *Gangway) StreamJobExecutions(ljer *ListJobExecutionsRequest, stream Prow_StreamJobExecutionsServer) error {
+	ctx := stream.Context()
+	options := getListOptions(getListRequestLabelSelector(ljer))
+	options.Limit = streamListPageSize
+	for {
+		prowJobCRs, err := gw.ProwJobClient.List(ctx, options)
+		if err != nil {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			logrus.WithError(err).Errorf("failed to list ProwJobs")
+			return status.Error(codes.Internal, "failed to list job executions")
+		}
+		for i := range prowJobCRs.Items {
+			if err := ctx.Err(); err != nil {
+				return status.FromContextError(err).Err()
+			}
+			pj := &prowJobCRs.Items[i]
+			if ljer.Status != JobExecutionStatus_JOB_EXECUTION_STATUS_UNSPECIFIED && TranslateProwJobStatus(&pj.Status) != ljer.Status {
+				continue
+			}
+			if err := stream.Send(listedJobExecution(pj)); err != nil {
+				return err
+			}
+		}
+		if prowJobCRs.Continue == "" {
+			return nil
+		}
+		options.Continue = prowJobCRs.Continue
+	}
+}
+
+// listedJobExecution returns the summary of a job execution that listing job
+// executions returns.
+func listedJobExecution(pj *prowcrd.ProwJob) *JobExecution {
+	return &JobExecution{
+		Id:        pj.Name,
+		JobName:   pj.Spec.Job,
+		JobStatus: TranslateProwJobStatus(&pj.Status),
+		JobType:   TranslateProwJobType(pj.Spec.Type),
+	}
+}
+
 func getListRequestLabelSelector(request *ListJobExecutionsRequest) *metav1.LabelSelector {
 	labelSelector := &metav1.LabelSelector{MatchLabels: make(map[string]string)}
 	if request.JobName != "" {
 		labelSelector.MatchLabels["prow.k8s.io/job"] = request.JobName
 	}
+	if org := request.GetOrg(); org != "" {
+		labelSelector.MatchLabels[kube.OrgLabel] = org
+	}
+	if repo := request.GetRepo(); repo != "" {
+		labelSelector.MatchLabels[kube.RepoLabel] = repo
+	}
+	if pull := request.GetPull(); pull != 0 {
+		labelSelector.MatchLabels[kube.PullLabel] = strconv.Itoa(int(pull))
+	}
 	return labelSelector
 }
 
+// GetJobFailureSummary aggregates the failure reasons of the recent executions
+// of a job, i.e. of all the Prow Job CRs of the job that are still around. The
+// failure reason of an execution is the description plank recorded when it
+// completed the job, e.g. that the pod got OOM killed or timed out. Executions
+// the client is not authorized to see are left out.
+func (gw *Gangway) GetJobFailureSummary(ctx context.Context, request *GetJobFailureSummaryRequest) (*JobFailureSummary, error) {
+	err, md := getHttpRequestHeaders(ctx)
+	if err != nil {
+		logrus.WithError(err).Debug("could not find request HTTP headers")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if request.GetJobName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "job_name field cannot be empty")
+	}
+
+	mainConfig := gw.ConfigAgent.Config()
+	allowedApiClient, err := mainConfig.IdentifyAllowedClient(md)
+	if err != nil {
+		logrus.WithError(err).Debug("could not find client in allowlist")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	options := getListOptions(&metav1.LabelSelector{MatchLabels: map[string]string{kube.ProwJobAnnotation: request.GetJobName()}})
+	prowJobCRs, err := gw.ProwJobClient.List(ctx, options)
+	if err != nil {
+		logrus.WithError(err).Errorf("failed to list ProwJobs")
+		return nil, status.Error(codes.Internal, "failed to list job executions")
+	}
+
+	summary := &JobFailureSummary{JobName: request.GetJobName()}
+	reasonCounts := map[string]int32{}
+	for _, pj := range prowJobCRs.Items {
+		if !pj.Complete() {
+			continue
+		}
+		if allowedApiClient != nil && !ClientAuthorized(allowedApiClient, pj) {
+			continue
+		}
+		summary.CompletedExecutions++
+		if pj.Status.State != prowcrd.FailureState && pj.Status.State != prowcrd.ErrorState {
+			continue
+		}
+		summary.FailedExecutions++
+		reason := pj.Status.Description
+		if reason == "" {
+			reason = string(pj.Status.State)
+		}
+		reasonCounts[reason]++
+	}
+
+	for reason, count := range reasonCounts {
+		summary.FailureReasons = append(summary.FailureReasons, &JobFailureReasonCount{Reason: reason, Count: count})
+	}
+	sort.Slice(summary.FailureReasons, func(i, j int) bool {
+		if summary.FailureReasons[i].Count != summary.FailureReasons[j].Count {
+			return summary.FailureReasons[i].Count > summary.FailureReasons[j].Count
+		}
+		return summary.FailureReasons[i].Reason < summary.FailureReasons[j].Reason
+	})
+
+	return summary, nil
+}
+
+// GetTenantInFlightCount counts the executions of the calling tenant that are
+// not complete yet, so that clients can throttle themselves.
+func (gw *Gangway) GetTenantInFlightCount(ctx context.Context, request *GetTenantInFlightCountRequest) (*TenantInFlightCount, error) {
+	err, md := getHttpRequestHeaders(ctx)
+	if err != nil {
+		logrus.WithError(err).Debug("could not find request HTTP headers")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	mainConfig := gw.ConfigAgent.Config()
+	allowedApiClient, err := mainConfig.IdentifyAllowedClient(md)
+	if err != nil {
+		logrus.WithError(err).Debug("could not find client in allowlist")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	prowJobCRs, err := gw.ProwJobClient.List(ctx, getListOptions(&metav1.LabelSelector{}))
+	if err != nil {
+		logrus.WithError(err).Errorf("failed to list ProwJobs")
+		return nil, status.Error(codes.Internal, "failed to list job executions")
+	}
+
+	inFlight := &TenantInFlightCount{}
+	statusCounts := map[JobExecutionStatus]int32{}
+	for _, pj := range prowJobCRs.Items {
+		if pj.Complete() {
+			continue
+		}
+		if allowedApiClient != nil && !ClientAuthorized(allowedApiClient, pj) {
+			continue
+		}
+		inFlight.Count++
+		statusCounts[TranslateProwJobStatus(&pj.Status)]++
+	}
+
+	for jobStatus, count := range statusCounts {
+		inFlight.StatusCounts = append(inFlight.StatusCounts, &JobExecutionStatusCount{Status: jobStatus, Count: count})
+	}
+	sort.Slice(inFlight.StatusCounts, func(i, j int) bool {
+		return inFlight.StatusCounts[i].Status < inFlight.StatusCounts[j].Status
+	})
+
+	return inFlight, nil
+}
+
+// GetRefsStatus rolls up the status of the presubmits of a pull request, so
+// that clients can tell whether all of them are green without listing them.
+// Only the latest execution of each presubmit is taken into account.
+// Executions the client is not authorized to see are left out.
+func (gw *Gangway) GetRefsStatus(ctx context.Context, request *GetRefsStatusRequest) (*RefsStatus, error) {
+	err, md := getHttpRequestHeaders(ctx)
+	if err != nil {
+		logrus.WithError(err).Debug("could not find request HTTP headers")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if request.GetOrg() == "" || request.GetRepo() == "" || request.GetPull() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "org, repo and pull fields must be set")
+	}
+
+	mainConfig := gw.ConfigAgent.Config()
+	allowedApiClient, err := mainConfig.IdentifyAllowedClient(md)
+	if err != nil {
+		logrus.WithError(err).Debug("could not find client in allowlist")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	options := getListOptions(&metav1.LabelSelector{MatchLabels: map[string]string{
+		kube.ProwJobTypeLabel: string(prowcrd.PresubmitJob),
+		kube.OrgLabel:         request.GetOrg(),
+		kube.RepoLabel:        request.GetRepo(),
+		kube.PullLabel:        strconv.Itoa(int(request.GetPull())),
+	}})
+	prowJobCRs, err := gw.ProwJobClient.List(ctx, options)
+	if err != nil {
+		logrus.WithError(err).Errorf("failed to list ProwJobs")
+		return nil, status.Error(codes.Internal, "failed to list job executions")
+	}
+
+	latest := map[string]prowcrd.ProwJob{}
+	for _, pj := range prowJobCRs.Items {
+		if allowedApiClient != nil && !ClientAuthorized(allowedApiClient, pj) {
+			continue
+		}
+		if current, ok := latest[pj.Spec.Job]; ok && !current.CreationTimestamp.Before(&pj.CreationTimestamp) {
+			continue
+		}
+		latest[pj.Spec.Job] = pj
+	}
+
+	refsStatus := &RefsStatus{AllComplete: len(latest) > 0}
+	statusCounts := map[JobExecutionStatus]int32{}
+	for _, pj := range latest {
+		if !pj.Complete() {
+			refsStatus.AllComplete = false
+		}
+		if pj.Status.State == prowcrd.FailureState || pj.Status.State == prowcrd.ErrorState {
+			refsStatus.AnyFailed = true
+		}
+		statusCounts[TranslateProwJobStatus(&pj.Status)]++
+	}
+
+	for jobStatus, count := range statusCounts {
+		refsStatus.StatusCounts = append(refsStatus.StatusCounts, &JobExecutionStatusCount{Status: jobStatus, Count: count})
+	}
+	sort.Slice(refsStatus.StatusCounts, func(i, j int) bool {
+		return refsStatus.StatusCounts[i].Status < refsStatus.StatusCounts[j].Status
+	})
+
+	return refsStatus, nil
+}
+
+const (
+	// defaultDurationStatsLookback is how far GetJobDurationStats looks back
+	// for completed executions if the request doesn't say.
+	defaultDurationStatsLookback = 7 * 24 * time.Hour
+	// maxDurationStatsLookback bounds how far GetJobDurationStats looks back.
+	maxDurationStatsLookback = 30 * 24 * time.Hour
+)
+
+// GetJobDurationStats computes percentiles of the durations of the executions
+// of a job that completed within the lookback window, i.e. of the Prow Job CRs
+// of the job that are still around. Aborted executions are left out, as they
+// did not run to the end, as are executions the client is not authorized to
+// see.
+func (gw *Gangway) GetJobDurationStats(ctx context.Context, request *GetJobDurationStatsRequest) (*DurationStats, error) {
+	err, md := getHttpRequestHeaders(ctx)
+	if err != nil {
+		logrus.WithError(err).Debug("could not find request HTTP headers")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if request.GetJobName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "job_name field cannot be empty")
+	}
+	lookback := defaultDurationStatsLookback
+	if request.GetLookback() != nil {
+		if err := request.GetLookback().CheckValid(); err != nil || request.GetLookback().AsDuration() <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "lookback field must be a positive duration")
+		}
+		lookback = min(request.GetLookback().AsDuration(), maxDurationStatsLookback)
+	}
+
+	mainConfig := gw.ConfigAgent.Config()
+	allowedApiClient, err := mainConfig.IdentifyAllowedClient(md)
+	if err != nil {
+		logrus.WithError(err).Debug("could not find client in allowlist")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	options := getListOptions(&metav1.LabelSelector{MatchLabels: map[string]string{kube.ProwJobAnnotation: request.GetJobName()}})
+	prowJobCRs, err := gw.ProwJobClient.List(ctx, options)
+	if err != nil {
+		logrus.WithError(err).Errorf("failed to list ProwJobs")
+		return nil, status.Error(codes.Internal, "failed to list job executions")
+	}
+
+	since := time.Now().Add(-lookback)
+	var durations []time.Duration
+	for _, pj := range prowJobCRs.Items {
+		if !pj.Complete() || pj.Status.State == prowcrd.AbortedState || pj.Status.StartTime.IsZero() || pj.Status.CompletionTime.Time.Before(since) {
+			continue
+		}
+		if allowedApiClient != nil && !ClientAuthorized(allowedApiClient, pj) {
+			continue
+		}
+		durations = append(durations, pj.Status.CompletionTime.Sub(pj.Status.StartTime.Time))
+	}
+
+	stats := &DurationStats{JobName: request.GetJobName(), Executions: int32(len(durations))}
+	if len(durations) == 0 {
+		return stats, nil
+	}
+	slices.Sort(durations)
+	stats.P50 = durationpb.New(durationPercentile(durations, 50))
+	stats.P90 = durationpb.New(durationPercentile(durations, 90))
+	stats.P99 = durationpb.New(durationPercentile(durations, 99))
+	return stats, nil
+}
+
+// durationPercentile returns the given percentile of the sorted durations
+// using the nearest-rank method, so that it is always one of the durations.
+func durationPercentile(sorted []time.Duration, percentile int) time.Duration {
+	rank := (percentile*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// IsPeriodicDue tells whether a periodic is due to run per its interval or
+// cron schedule and when its next run is scheduled, based on its latest run.
+// This mirrors how horologium decides to trigger periodics.
+func (gw *Gangway) IsPeriodicDue(ctx context.Context, request *IsPeriodicDueRequest) (*PeriodicDue, error) {
+	if request.GetJobName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "job_name field cannot be empty")
+	}
+
+	periodic := findPeriodic(&ProwCfgAdapter{gw.ConfigAgent.Config()}, request.GetJobName())
+	if periodic == nil {
+		return nil, status.Errorf(codes.NotFound, "failed to find associated periodic job %q", request.GetJobName())
+	}
+
+	options := getListOptions(&metav1.LabelSelector{MatchLabels: map[string]string{kube.ProwJobAnnotation: request.GetJobName()}})
+	prowJobCRs, err := gw.ProwJobClient.List(ctx, options)
+	if err != nil {
+		logrus.WithError(err).Errorf("failed to list ProwJobs")
+		return nil, status.Error(codes.Internal, "failed to list job executions")
+	}
+
+	now := time.Now()
+	latest, found := pjutil.GetLatestProwJobs(prowJobCRs.Items, prowcrd.PeriodicJob)[request.GetJobName()]
+	if !found {
+		// Horologium triggers periodics that never ran right away.
+		return &PeriodicDue{Due: true, NextRun: timestamppb.New(now)}, nil
+	}
+
+	var nextRun time.Time
+	if periodic.Cron == "" {
+		nextRun = latest.Status.StartTime.Add(periodic.GetInterval())
+		if periodic.MinimumInterval != "" {
+			if !latest.Complete() {
+				// The minimum interval only starts once the latest run completed.
+				return &PeriodicDue{}, nil
+			}
+			nextRun = latest.Status.CompletionTime.Add(periodic.GetMinimumInterval())
+		}
+	} else {
+		if nextRun, err = cron.NextRun(periodic.Cron, latest.Status.StartTime.Time); err != nil {
+			logrus.WithError(err).Errorf("failed to compute next run of periodic %q", periodic.Name)
+			return nil, status.Error(codes.Internal, "failed to compute next run")
+		}
+	}
+
+	return &PeriodicDue{
+		Due:     latest.Complete() && !nextRun.After(now),
+		NextRun: timestamppb.New(nextRun),
+	}, nil
+}
+
 func (gw *Gangway) BulkJobStatusChange(ctx context.Context, request *BulkJobStatusChangeRequest) (*emptypb.Empty, error) {
 
 	err, md := getHttpRequestHeaders(ctx)
@@ -247,6 +1099,13 @@ func (gw *Gangway) BulkJobStatusChange(ctx context.Context, request *BulkJobStat
 		return &emptypb.Empty{}, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	id := request.GetId()
+	if id != "" {
+		if err := gw.startBulkJobStatusChange(id); err != nil {
+			return &emptypb.Empty{}, err
+		}
+	}
+
 	go func() {
 		options := getListOptions(getRequestLabelSelector(request))
 		// TODO(Prucek):
@@ -260,15 +1119,20 @@ func (gw *Gangway) BulkJobStatusChange(ctx context.Context, request *BulkJobStat
 		pjList, err := gw.ProwJobClient.List(context, options)
 		if err != nil {
 			logrus.WithError(err).Errorf("failed to list ProwJobs")
+			gw.finishBulkJobStatusChange(id, BulkJobStatusChangeState_BULK_JOB_STATUS_CHANGE_FAILED)
+			cancel()
+			return
 		}
 		for _, pj := range pjList.Items {
 			if !isMatchingCondition(pj, request) {
 				continue
 			}
+			gw.updateBulkJobStatusChange(id, func(progress *BulkJobStatusChangeProgress) { progress.Matched++ })
 			if allowedApiClient != nil {
 				authorized := ClientAuthorized(allowedApiClient, pj)
 				if !authorized {
 					logrus.Error("client is not authorized to modify the given job")
+					gw.updateBulkJobStatusChange(id, func(progress *BulkJobStatusChangeProgress) { progress.Skipped++ })
 					continue
 				}
 			}
@@ -276,16 +1140,128 @@ func (gw *Gangway) BulkJobStatusChange(ctx context.Context, request *BulkJobStat
 			updatedPj, err := gw.ProwJobClient.Update(context, &pj, metav1.UpdateOptions{})
 			if err != nil {
 				logrus.WithError(err).Errorf("failed to update ProwJob status")
+				gw.updateBulkJobStatusChange(id, func(progress *BulkJobStatusChangeProgress) { progress.Failed++ })
 				continue
 			}
 			logrus.WithField("name", pj.Name).Infof("ProwJob status updated to: %s", updatedPj.Status.State)
+			gw.updateBulkJobStatusChange(id, func(progress *BulkJobStatusChangeProgress) { progress.Updated++ })
 		}
+		gw.finishBulkJobStatusChange(id, BulkJobStatusChangeState_BULK_JOB_STATUS_CHANGE_SUCCEEDED)
 		cancel()
 	}()
 
 	return &emptypb.Empty{}, nil
 }
 
+const (
+	// bulkJobStatusChangeRetention is how long the progress of a finished
+	// bulk job status change is kept.
+	bulkJobStatusChangeRetention = time.Hour
+	// maxBulkJobStatusChanges is the maximum number of bulk job status
+	// changes whose progress is kept.
+	maxBulkJobStatusChanges = 1000
+)
+
+// bulkJobStatusChange is the progress of a bulk job status change, and when it
+// finished, if it did.
+type bulkJobStatusChange struct {
+	progress *BulkJobStatusChangeProgress
+	finished time.Time
+}
+
+// startBulkJobStatusChange starts tracking the progress of the bulk job status
+// change with the given correlation id. It fails if there already is a bulk job
+// status change with that id, or if too many are running to track another one.
+func (gw *Gangway) startBulkJobStatusChange(id string) error {
+	gw.bulkJobStatusChangesLock.Lock()
+	defer gw.bulkJobStatusChangesLock.Unlock()
+	gw.pruneBulkJobStatusChanges(time.Now())
+	if _, exists := gw.bulkJobStatusChanges[id]; exists {
+		return status.Errorf(codes.AlreadyExists, "bulk job status change %q already exists", id)
+	}
+	if len(gw.bulkJobStatusChanges) >= maxBulkJobStatusChanges {
+		// Make room by forgetting the bulk job status change that finished first.
+		oldest := ""
+		for existingID, change := range gw.bulkJobStatusChanges {
+			if !change.finished.IsZero() && (oldest == "" || change.finished.Before(gw.bulkJobStatusChanges[oldest].finished)) {
+				oldest = existingID
+			}
+		}
+		if oldest == "" {
+			return status.Errorf(codes.ResourceExhausted, "too many bulk job status changes are running, at most %d can be tracked", maxBulkJobStatusChanges)
+		}
+		delete(gw.bulkJobStatusChanges, oldest)
+	}
+	if gw.bulkJobStatusChanges == nil {
+		gw.bulkJobStatusChanges = map[string]*bulkJobStatusChange{}
+	}
+	gw.bulkJobStatusChanges[id] = &bulkJobStatusChange{
+		progress: &BulkJobStatusChangeProgress{
+			Id:    id,
+			State: BulkJobStatusChangeState_BULK_JOB_STATUS_CHANGE_RUNNING,
+		},
+	}
+	return nil
+}
+
+// updateBulkJobStatusChange updates the progress of the bulk job status change
+// with the given correlation id, if any.
+func (gw *Gangway) updateBulkJobStatusChange(id string, update func(*BulkJobStatusChangeProgress)) {
+	if id == "" {
+		return
+	}
+	gw.bulkJobStatusChangesLock.Lock()
+	defer gw.bulkJobStatusChangesLock.Unlock()
+	if change, exists := gw.bulkJobStatusChanges[id]; exists {
+		update(change.progress)
+	}
+}
+
+// finishBulkJobStatusChange records that the bulk job status change with the
+// given correlation id, if any, finished in the given state.
+func (gw *Gangway) finishBulkJobStatusChange(id string, state BulkJobStatusChangeState) {
+	if id == "" {
+		return
+	}
+	gw.bulkJobStatusChangesLock.Lock()
+	defer gw.bulkJobStatusChangesLock.Unlock()
+	if change, exists := gw.bulkJobStatusChanges[id]; exists {
+		change.progress.State = state
+		change.finished = time.Now()
+	}
+}
+
+// pruneBulkJobStatusChanges forgets the bulk job status changes that finished
+// longer than bulkJobStatusChangeRetention before now. The caller must hold
+// bulkJobStatusChangesLock.
+func (gw *Gangway) pruneBulkJobStatusChanges(now time.Time) {
+	for id, change := range gw.bulkJobStatusChanges {
+		if !change.finished.IsZero() && now.Sub(change.finished) > bulkJobStatusChangeRetention {
+			delete(gw.bulkJobStatusChanges, id)
+		}
+	}
+}
+
+// GetBulkJobStatusChange returns the progress of the BulkJobStatusChange
+// request with the given correlation id. The progress is only kept in memory,
+// so it is lost when gangway restarts. It is kept for an hour after the change
+// finished, and for at most 1000 changes, of which the ones that finished
+// first are forgotten first.
+func (gw *Gangway) GetBulkJobStatusChange(ctx context.Context, request *GetBulkJobStatusChangeRequest) (*BulkJobStatusChangeProgress, error) {
+	if request.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id field cannot be empty")
+	}
+
+	gw.bulkJobStatusChangesLock.Lock()
+	defer gw.bulkJobStatusChangesLock.Unlock()
+	gw.pruneBulkJobStatusChanges(time.Now())
+	change, exists := gw.bulkJobStatusChanges[request.GetId()]
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "bulk job status change %q not found", request.GetId())
+	}
+	return proto.Clone(change.progress).(*BulkJobStatusChangeProgress), nil
+}
+
 func getRequestLabelSelector(request *BulkJobStatusChangeRequest) *metav1.LabelSelector {
 	labelSelector := &metav1.LabelSelector{MatchLabels: make(map[string]string)}
 	switch request.JobType {
@@ -355,6 +1331,9 @@ func isMatchingCondition(pj prowcrd.ProwJob, request *BulkJobStatusChangeRequest
 // allowlist (allowed_api_clients) allows it.
 func ClientAuthorized(allowedApiClient *config.AllowedApiClient, prowJobCR prowcrd.ProwJob) bool {
 	pjd := prowJobCR.Spec.ProwJobDefault
+	if pjd == nil {
+		return false
+	}
 	for _, allowedJobsFilter := range allowedApiClient.AllowedJobsFilters {
 		if allowedJobsFilter.TenantID == pjd.TenantID {
 			return true
@@ -363,26 +1342,27 @@ func ClientAuthorized(allowedApiClient *config.AllowedApiClient, prowJobCR prowc
 	return false
 }
 
-// FIXME: Add roundtrip tests to ensure that the conversion between gitRefs and
-// refs is lossless.
 func ToCrdRefs(gitRefs *Refs) (*prowcrd.Refs, error) {
 	if gitRefs == nil {
 		return nil, errors.New("gitRefs is nil")
 	}
 
 	refs := prowcrd.Refs{
-		Org:            gitRefs.Org,
-		Repo:           gitRefs.Repo,
-		RepoLink:       gitRefs.RepoLink,
-		BaseRef:        gitRefs.BaseRef,
-		BaseSHA:        gitRefs.BaseSha,
-		BaseLink:       gitRefs.BaseLink,
-		PathAlias:      gitRefs.PathAlias,
-		WorkDir:        gitRefs.WorkDir,
-		CloneURI:       gitRefs.CloneUri,
-		SkipSubmodules: gitRefs.SkipSubmodules,
-		CloneDepth:     int(gitRefs.CloneDepth),
-		SkipFetchHead:  gitRefs.SkipFetchHead,
+		Org:                 gitRefs.Org,
+		Repo:                gitRefs.Repo,
+		RepoLink:            gitRefs.RepoLink,
+		BaseRef:             gitRefs.BaseRef,
+		BaseSHA:             gitRefs.BaseSha,
+		BaseLink:            gitRefs.BaseLink,
+		PathAlias:           gitRefs.PathAlias,
+		WorkDir:             gitRefs.WorkDir,
+		CloneURI:            gitRefs.CloneUri,
+		SkipSubmodules:      gitRefs.SkipSubmodules,
+		CloneDepth:          int(gitRefs.CloneDepth),
+		SkipFetchHead:       gitRefs.SkipFetchHead,
+		Auxiliary:           gitRefs.Auxiliary,
+		BloblessFetch:       gitRefs.BloblessFetch,
+		SparseCheckoutFiles: gitRefs.SparseCheckoutFiles,
 	}
 
 	var pulls []prowcrd.Pull
@@ -399,6 +1379,8 @@ func ToCrdRefs(gitRefs *Refs) (*prowcrd.Refs, error) {
 			Link:       pull.Link,
 			CommitLink: pull.CommitLink,
 			AuthorLink: pull.AuthorLink,
+			HeadRepo:   pull.HeadRepo,
+			HeadRef:    pull.HeadRef,
 		}
 		pulls = append(pulls, p)
 	}
@@ -414,18 +1396,21 @@ func FromCrdRefs(refs *prowcrd.Refs) (*Refs, error) {
 	}
 
 	gitRefs := Refs{
-		Org:            refs.Org,
-		Repo:           refs.Repo,
-		RepoLink:       refs.RepoLink,
-		BaseRef:        refs.BaseRef,
-		BaseSha:        refs.BaseSHA,
-		BaseLink:       refs.BaseLink,
-		PathAlias:      refs.PathAlias,
-		WorkDir:        refs.WorkDir,
-		CloneUri:       refs.CloneURI,
-		SkipSubmodules: refs.SkipSubmodules,
-		CloneDepth:     int32(refs.CloneDepth),
-		SkipFetchHead:  refs.SkipFetchHead,
+		Org:                 refs.Org,
+		Repo:                refs.Repo,
+		RepoLink:            refs.RepoLink,
+		BaseRef:             refs.BaseRef,
+		BaseSha:             refs.BaseSHA,
+		BaseLink:            refs.BaseLink,
+		PathAlias:           refs.PathAlias,
+		WorkDir:             refs.WorkDir,
+		CloneUri:            refs.CloneURI,
+		SkipSubmodules:      refs.SkipSubmodules,
+		CloneDepth:          int32(refs.CloneDepth),
+		SkipFetchHead:       refs.SkipFetchHead,
+		Auxiliary:           refs.Auxiliary,
+		BloblessFetch:       refs.BloblessFetch,
+		SparseCheckoutFiles: refs.SparseCheckoutFiles,
 	}
 
 	var pulls []*Pull
@@ -439,6 +1424,8 @@ func FromCrdRefs(refs *prowcrd.Refs) (*Refs, error) {
 			Link:       pull.Link,
 			CommitLink: pull.CommitLink,
 			AuthorLink: pull.AuthorLink,
+			HeadRepo:   pull.HeadRepo,
+			HeadRef:    pull.HeadRef,
 		}
 		pulls = append(pulls, &p)
 	}
@@ -457,6 +1444,22 @@ func getHttpRequestHeaders(ctx context.Context) (error, *metadata.MD) {
 	return nil, &md
 }
 
+// getKnownRequestHeaders returns the values of the HTTP headers that identify
+// clients of the given cloud vendor, keyed by header.
+func getKnownRequestHeaders(cv config.ApiClientCloudVendor, md *metadata.MD) map[string]string {
+	headers := map[string]string{}
+	for _, header := range cv.GetRequiredMdHeaders() {
+		values := md.Get(header)
+		// Only use the first value. MD stores multiple values in case other
+		// entities attempt to overwrite an existing key (it prevents this by
+		// storing values as a list of strings).
+		if len(values) > 0 {
+			headers[header] = values[0]
+		}
+	}
+	return headers
+}
+
 // getDecoratedLoggerEntry captures all known (interesting) HTTP headers of a
 // gRPC request. We use these headers as log fields in the caller so that the
 // logs can be very precise.
@@ -466,20 +1469,12 @@ func getDecoratedLoggerEntry(allowedApiClient *config.AllowedApiClient, md *meta
 		return nil, err
 	}
 
-	knownHeaders := cv.GetRequiredMdHeaders()
 	fields := make(map[string]any)
-	for _, header := range knownHeaders {
-		values := md.Get(header)
-		// Only use the first value. MD stores multiple values in case other
-		// entities attempt to overwrite an existing key (it prevents this by
-		// storing values as a list of strings).
-		//
+	for header, value := range getKnownRequestHeaders(cv, md) {
 		// Prefix the field with "http-header/" so that all of the headers here
 		// get displayed neatly together (when the fields are sorted by logrus's
 		// own output to the console).
-		if len(values) > 0 {
-			fields[fmt.Sprintf("http-header/%s", header)] = values[0]
-		}
+		fields[fmt.Sprintf("http-header/%s", header)] = value
 	}
 	fields["component"] = version.Name
 
@@ -532,10 +1527,14 @@ func (cjer *CreateJobExecutionRequest) Validate() error {
 		}
 
 		labels := podSpecOptions.GetLabels()
-		for k, v := range labels {
+		for _, k := range slices.Sorted(maps.Keys(labels)) {
+			v := labels[k]
 			if len(k) == 0 || len(v) == 0 {
 				return fmt.Errorf("invalid label key/value pair: %q, %q", k, v)
 			}
+			if prefix, reserved := getReservedKeyPrefix(k); reserved {
+				return fmt.Errorf("invalid label: key %q uses the prefix %q reserved for Prow", k, prefix)
+			}
 
 			errs := validation.IsValidLabelValue(v)
 			if len(errs) > 0 {
@@ -544,16 +1543,48 @@ func (cjer *CreateJobExecutionRequest) Validate() error {
 		}
 
 		annotations := podSpecOptions.GetAnnotations()
-		for k, v := range annotations {
+		for _, k := range slices.Sorted(maps.Keys(annotations)) {
+			v := annotations[k]
 			if len(k) == 0 || len(v) == 0 {
 				return fmt.Errorf("invalid annotation key/value pair: %q, %q", k, v)
 			}
+			if prefix, reserved := getReservedKeyPrefix(k); reserved {
+				return fmt.Errorf("invalid annotation: key %q uses the prefix %q reserved for Prow", k, prefix)
+			}
+		}
+		if err := apivalidation.ValidateAnnotationsSize(annotations); err != nil {
+			return fmt.Errorf("invalid annotations: %w", err)
 		}
 	}
 
 	return nil
 }
 
+// reservedKeyPrefixes are the prefixes of the label and annotation keys that
+// Prow sets on its own, which clients must not override.
+var reservedKeyPrefixes = []string{"prow.k8s.io/", kube.CreatedByProw}
+
+// getReservedKeyPrefix returns the reserved prefix of the given label or
+// annotation key, if any.
+func getReservedKeyPrefix(key string) (string, bool) {
+	for _, prefix := range reservedKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+// maxListPageSize is the largest page size ListJobExecutions accepts.
+const maxListPageSize = 1000
+
+func (ljer *ListJobExecutionsRequest) Validate() error {
+	if ljer.GetPageSize() < 0 || ljer.GetPageSize() > maxListPageSize {
+		return fmt.Errorf("page_size must be between 0 and %d, got %d", maxListPageSize, ljer.GetPageSize())
+	}
+	return nil
+}
+
 func (bjscr *BulkJobStatusChangeRequest) Validate() error {
 
 	if bjscr.GetJobStatusChange().GetCurrent() == JobExecutionStatus_JOB_EXECUTION_STATUS_UNSPECIFIED {
@@ -623,6 +1654,8 @@ type prowCfgClient interface {
 	GetPostsubmitsStatic(identifier string) []config.Postsubmit
 	GetProwJobDefault(repo, cluster string) *prowcrd.ProwJobDefault
 	GetScheduler() config.Scheduler
+	GetDeckURL() string
+	GetJobQueueCapacities() map[string]int
 }
 
 type ProwCfgAdapter struct {
@@ -631,6 +1664,10 @@ type ProwCfgAdapter struct {
 
 func (c *ProwCfgAdapter) GetScheduler() config.Scheduler { return c.Scheduler }
 
+func (c *ProwCfgAdapter) GetDeckURL() string { return c.Gangway.DeckURL }
+
+func (c *ProwCfgAdapter) GetJobQueueCapacities() map[string]int { return c.Plank.JobQueueCapacities }
+
 type ReporterFunc func(pj *prowcrd.ProwJob, state prowcrd.ProwJobState, err error)
 
 func (cjer *CreateJobExecutionRequest) getJobHandler() (jobHandler, error) {
@@ -688,13 +1725,13 @@ func HandleProwJob(l *logrus.Entry,
 	if err != nil {
 		return nil, err
 	}
-	prowJobSpec, labels, annotations, err := jh.getProwJobSpec(mainConfig, ircg, cjer)
+	prowJobSpec, labels, annotations, warnings, err := jh.getProwJobSpec(mainConfig, ircg, cjer)
 	if err != nil {
 		// These are user errors, i.e. missing fields, requested prowjob doesn't exist etc.
 		// These errors are already surfaced to user via pubsub two lines below.
 		l.WithError(err).WithField("name", cjer.GetJobName()).Info("Failed getting prowjob spec")
 		prowJobCR = pjutil.NewProwJob(prowcrd.ProwJobSpec{}, nil, cjer.GetPodSpecOptions().GetAnnotations(),
-			pjutil.RequireScheduling(mainConfig.GetScheduler().Enabled))
+			pjutil.RequireScheduling(mainConfig.GetScheduler().Enabled), pjutil.TimeOrderedName())
 
 		if reporterFunc != nil {
 			reporterFunc(&prowJobCR, prowcrd.ErrorState, err)
@@ -705,9 +1742,19 @@ func HandleProwJob(l *logrus.Entry,
 		return nil, fmt.Errorf("failed getting prowjob spec") // This should not happen
 	}
 
+	// Only look at the metadata from the config, the request can't mark
+	// jobs as deprecated.
+	deprecated, deprecationMessage := getDeprecation(labels, annotations)
+	if deprecated {
+		l.WithField("name", cjer.GetJobName()).Info("Deprecated job requested")
+	}
+
 	combinedLabels, combinedAnnotations := mergeMapFields(cjer, labels, annotations)
+	// Name the ProwJob CR with a UUIDv7 (see
+	// https://www.rfc-editor.org/rfc/rfc9562#name-uuid-version-7), so that
+	// the ids of job executions sort by the time they were created.
 	prowJobCR = pjutil.NewProwJob(*prowJobSpec, combinedLabels, combinedAnnotations,
-		pjutil.RequireScheduling(mainConfig.GetScheduler().Enabled))
+		pjutil.RequireScheduling(mainConfig.GetScheduler().Enabled), pjutil.TimeOrderedName())
 	// Adds / Updates Environments to containers
 	if prowJobCR.Spec.PodSpec != nil {
 		for i, c := range prowJobCR.Spec.PodSpec.Containers {
@@ -727,6 +1774,10 @@ func HandleProwJob(l *logrus.Entry,
 		}
 	}
 	// This is a user error, not sure whether we want to return error here.
+	if !clusterIsAllowed && allowedApiClient != nil {
+		l.WithField("cluster", prowJobSpec.Cluster).Warn("cluster not allowed for client")
+		return nil, status.Errorf(codes.PermissionDenied, "client is not authorized to run jobs on cluster %q", prowJobSpec.Cluster)
+	}
 	if !clusterIsAllowed {
 		err := fmt.Errorf("cluster %s is not allowed. Can be fixed by defining this cluster under pubsub_triggers -> allowed_clusters", prowJobSpec.Cluster)
 		l.WithField("cluster", prowJobSpec.Cluster).Warn("cluster not allowed")
@@ -739,8 +1790,8 @@ func HandleProwJob(l *logrus.Entry,
 	// Figure out the tenantID defined for this job by looking it up in its
 	// config, or if that's missing, finding the default one specified in the
 	// main Config.
+	var jobTenantID string
 	if requireTenantID {
-		var jobTenantID string
 		if prowJobCR.Spec.ProwJobDefault != nil && prowJobCR.Spec.ProwJobDefault.TenantID != "" {
 			jobTenantID = prowJobCR.Spec.ProwJobDefault.TenantID
 		} else {
@@ -777,7 +1828,12 @@ func HandleProwJob(l *logrus.Entry,
 		}
 	}
 
-	if _, err := pjc.Create(context.TODO(), &prowJobCR, metav1.CreateOptions{}); err != nil {
+	// Look for a duplicate before creating the job, so that it doesn't find
+	// itself.
+	duplicateOf := findDuplicate(l, pjc, &prowJobCR)
+
+	createdProwJobCR, err := pjc.Create(context.TODO(), &prowJobCR, metav1.CreateOptions{})
+	if err != nil {
 		l.WithError(err).Errorf("failed to create job %q as %q", cjer.GetJobName(), prowJobCR.Name)
 		if reporterFunc != nil {
 			reporterFunc(&prowJobCR, prowcrd.ErrorState, err)
@@ -800,38 +1856,184 @@ func HandleProwJob(l *logrus.Entry,
 	// (https://prow.k8s.io/prowjob?prowjob=c2891365-621c-11ed-88b0-da2d50b4915c)
 	// but also for naming the test pod itself (prowcrd.ProwJob.Status.pod_name
 	// field).
-	jobExec := &JobExecution{
-		Id:             prowJobCR.Name,
-		JobName:        cjer.GetJobName(),
-		JobType:        cjer.GetJobExecutionType(),
-		JobStatus:      JobExecutionStatus_TRIGGERED,
-		Refs:           cjer.GetRefs(),
-		PodSpecOptions: cjer.GetPodSpecOptions(),
+	//
+	// Echo the refs the job actually runs against instead of the requested
+	// ones, as resolving the job may have filled in further details like the
+	// base SHA or the clone URI.
+	refs := cjer.GetRefs()
+	if prowJobCR.Spec.Refs != nil {
+		if refs, err = FromCrdRefs(prowJobCR.Spec.Refs); err != nil {
+			return nil, err
+		}
 	}
+	jobExec := &JobExecution{
+		Id:                 prowJobCR.Name,
+		JobName:            cjer.GetJobName(),
+		JobType:            cjer.GetJobExecutionType(),
+		JobStatus:          JobExecutionStatus_TRIGGERED,
+		Refs:               refs,
+		PodSpecOptions:     cjer.GetPodSpecOptions(),
+		CloneUrls:          getCloneUrls(&prowJobCR.Spec),
+		DeckUrl:            getDeckUrl(mainConfig.GetDeckURL(), prowJobCR.Name),
+		Decorated:          prowJobCR.Spec.DecorationConfig != nil,
+		Warnings:           warnings,
+		RerunCommand:       prowJobCR.Spec.RerunCommand,
+		Env:                getMainContainerEnv(prowJobCR.Spec.PodSpec),
+		Uid:                string(createdProwJobCR.UID),
+		Cluster:            prowJobCR.Spec.Cluster,
+		CrossRepo:          isCrossRepo(&prowJobCR.Spec),
+		ExtraRefs:          getExtraRefs(&prowJobCR.Spec),
+		DuplicateOf:        duplicateOf,
+		MaxConcurrency:     int32(prowJobCR.Spec.MaxConcurrency),
+		JobQueueCapacity:   getJobQueueCapacity(mainConfig.GetJobQueueCapacities(), prowJobCR.Spec.JobQueueName),
+		Deprecated:         deprecated,
+		DeprecationMessage: deprecationMessage,
+		ReportLinks:        getReportLinks(&prowJobCR),
+		Audit: &Audit{
+			TenantId:   jobTenantID,
+			CreateTime: timestamppb.Now(),
+		},
+	}
+	jobExec.Command, jobExec.Args = getMainContainerCommand(prowJobCR.Spec.PodSpec)
+	jobExec.PrivilegedAccessReasons = getPrivilegedAccessReasons(prowJobCR.Spec.PodSpec)
+	jobExec.PrivilegedAccess = len(jobExec.PrivilegedAccessReasons) > 0
 
 	return jobExec, nil
 }
 
+// getDeprecation returns whether a job is deprecated and its deprecation
+// message, as configured in the labels and annotations of the job config.
+func getDeprecation(labels, annotations map[string]string) (bool, string) {
+	if labels[kube.DeprecatedLabel] != "true" && annotations[kube.DeprecatedLabel] != "true" {
+		return false, ""
+	}
+	return true, annotations[kube.DeprecationMessageAnnotation]
+}
+
+// isCrossRepo returns whether any of the pull requests in the refs of a job
+// comes from a different repository than the one it is merged into. Pulls
+// without a known head repository are assumed to come from the same one.
+func isCrossRepo(spec *prowcrd.ProwJobSpec) bool {
+	var refs []prowcrd.Refs
+	if spec.Refs != nil {
+		refs = append(refs, *spec.Refs)
+	}
+	refs = append(refs, spec.ExtraRefs...)
+	for _, ref := range refs {
+		for _, pull := range ref.Pulls {
+			if pull.HeadRepo != "" && pull.HeadRepo != ref.Org+"/"+ref.Repo {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findDuplicate returns the name of a ProwJob of the same job against the same
+// refs as prowJobCR that is not complete yet, or an empty string if there is
+// none. Failing to look for one is not fatal, as it is only informational.
+func findDuplicate(l *logrus.Entry, pjc ProwJobClient, prowJobCR *prowcrd.ProwJob) string {
+	options := getListOptions(&metav1.LabelSelector{MatchLabels: map[string]string{
+		kube.ProwJobAnnotation: prowJobCR.Labels[kube.ProwJobAnnotation],
+		kube.ProwJobTypeLabel:  prowJobCR.Labels[kube.ProwJobTypeLabel],
+	}})
+	prowJobCRs, err := pjc.List(context.TODO(), options)
+	if err != nil {
+		l.WithError(err).Warnf("failed to look for duplicates of job %q", prowJobCR.Spec.Job)
+		return ""
+	}
+	for _, pj := range prowJobCRs.Items {
+		if pj.Complete() || pj.Spec.Job != prowJobCR.Spec.Job || pj.Spec.Type != prowJobCR.Spec.Type {
+			continue
+		}
+		if sameRefs(pj.Spec.Refs, prowJobCR.Spec.Refs) {
+			return pj.Name
+		}
+	}
+	return ""
+}
+
+// sameRefs returns whether two refs make a job test the same code.
+func sameRefs(a, b *prowcrd.Refs) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Org != b.Org || a.Repo != b.Repo || a.BaseRef != b.BaseRef || a.BaseSHA != b.BaseSHA || len(a.Pulls) != len(b.Pulls) {
+		return false
+	}
+	for i := range a.Pulls {
+		if a.Pulls[i].Number != b.Pulls[i].Number || a.Pulls[i].SHA != b.Pulls[i].SHA {
+			return false
+		}
+	}
+	return true
+}
+
+// getMainContainerEnv returns the environment variables of the main test
+// container that have a literal value. Later definitions of a variable take
+// precedence, like they do in Kubernetes.
+func getMainContainerEnv(podSpec *v1.PodSpec) map[string]string {
+	if podSpec == nil || len(podSpec.Containers) == 0 {
+		return nil
+	}
+	env := map[string]string{}
+	for _, e := range podSpec.Containers[0].Env {
+		if e.ValueFrom != nil {
+			delete(env, e.Name)
+			continue
+		}
+		env[e.Name] = e.Value
+	}
+	return env
+}
+
+// getMainContainerCommand returns the command and the arguments of the main
+// test container. The pod spec of a ProwJob is not decorated yet, so these are
+// the ones configured for the job.
+func getMainContainerCommand(podSpec *v1.PodSpec) ([]string, []string) {
+	if podSpec == nil || len(podSpec.Containers) == 0 {
+		return nil, nil
+	}
+	return podSpec.Containers[0].Command, podSpec.Containers[0].Args
+}
+
+// getPrivilegedAccessReasons returns what grants the pod of a job privileged
+// access to the node it runs on: the host namespaces it shares and its
+// privileged containers, init containers first.
+func getPrivilegedAccessReasons(podSpec *v1.PodSpec) []string {
+	if podSpec == nil {
+		return nil
+	}
+	var reasons []string
+	if podSpec.HostNetwork {
+		reasons = append(reasons, "hostNetwork")
+	}
+	if podSpec.HostPID {
+		reasons = append(reasons, "hostPID")
+	}
+	if podSpec.HostIPC {
+		reasons = append(reasons, "hostIPC")
+	}
+	for _, containers := range [][]v1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for _, container := range containers {
+			if sc := container.SecurityContext; sc != nil && sc.Privileged != nil && *sc.Privileged {
+				reasons = append(reasons, fmt.Sprintf("container %s: privileged", container.Name))
+			}
+		}
+	}
+	return reasons
+}
+
 // jobHandler handles job type specific logic
 type jobHandler interface {
-	getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, labels map[string]string, annotations map[string]string, err error)
+	getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, labels map[string]string, annotations map[string]string, warnings []string, err error)
 }
 
 // periodicJobHandler implements jobHandler
 type periodicJobHandler struct{}
 
-func (peh *periodicJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, labels map[string]string, annotations map[string]string, err error) {
-	var periodicJob *config.Periodic
-	// TODO(chaodaiG): do we want to support inrepoconfig when
-	// https://github.com/kubernetes/test-infra/issues/21729 is done?
-	for _, job := range mainConfig.AllPeriodics() {
-		if job.Name == cjer.GetJobName() {
-			// Directly followed by break, so this is ok
-			// nolint: exportloopref
-			periodicJob = &job
-			break
-		}
-	}
+func (peh *periodicJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, labels map[string]string, annotations map[string]string, warnings []string, err error) {
+	periodicJob := findPeriodic(mainConfig, cjer.GetJobName())
 	if periodicJob == nil {
 		err = fmt.Errorf("failed to find associated periodic job %q", cjer.GetJobName())
 		return
@@ -843,6 +2045,46 @@ func (peh *periodicJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg con
 	return
 }
 
+// findPeriodic returns the periodic with the given name or nil if there is
+// none.
+func findPeriodic(mainConfig prowCfgClient, name string) *config.Periodic {
+	// TODO(chaodaiG): do we want to support inrepoconfig when
+	// https://github.com/kubernetes/test-infra/issues/21729 is done?
+	for _, job := range mainConfig.AllPeriodics() {
+		if job.Name == name {
+			return &job
+		}
+	}
+	return nil
+}
+
+// pickJob picks the job to run from all the jobs of orgRepo matching the
+// requested job name. If the name is ambiguous, the job context given in the
+// request is used to pick the job and a warning about the ambiguity is
+// returned.
+func pickJob[T any](cjer *CreateJobExecutionRequest, orgRepo string, matches []T, contextOf func(T) string) (*T, string, error) {
+	switch len(matches) {
+	case 0:
+		return nil, "", nil
+	case 1:
+		return &matches[0], "", nil
+	}
+
+	if jobContext := cjer.GetJobContext(); jobContext != "" {
+		var picked []T
+		for _, job := range matches {
+			if contextOf(job) == jobContext {
+				picked = append(picked, job)
+			}
+		}
+		if len(picked) == 1 {
+			warning := fmt.Sprintf("%s matches %d prow jobs from orgRepo %q, picked the one with context %q; consider giving the jobs unique names", cjer.GetJobName(), len(matches), orgRepo, jobContext)
+			return &picked[0], warning, nil
+		}
+	}
+	return nil, "", fmt.Errorf("%s matches multiple prow jobs from orgRepo %q", cjer.GetJobName(), orgRepo)
+}
+
 // presubmitJobHandler implements jobHandler
 type presubmitJobHandler struct {
 }
@@ -882,7 +2124,7 @@ func validateRefs(jobType JobExecutionType, refs *prowcrd.Refs) error {
 	return nil
 }
 
-func (prh *presubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, labels map[string]string, annotations map[string]string, err error) {
+func (prh *presubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, labels map[string]string, annotations map[string]string, warnings []string, err error) {
 	// presubmit jobs require Refs and Refs.Pulls to be set
 	refs, err := ToCrdRefs(cjer.GetRefs())
 	if err != nil {
@@ -908,6 +2150,8 @@ func (prh *presubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg co
 	logger := logrus.WithFields(logrus.Fields{"org": org, "repo": repo, "branch": branch, "orgRepo": orgRepo})
 	// Get presubmits from Config alone.
 	presubmits := mainConfig.GetPresubmitsStatic(orgRepo)
+	// inRepoConfigErr is the error getting the inrepoconfig, if any.
+	var inRepoConfigErr error
 	// If InRepoConfigGetter is provided, then it means that we also want to fetch
 	// from an inrepoconfig.
 	if ircg != nil {
@@ -917,28 +2161,32 @@ func (prh *presubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg co
 		prowYAML, err := ircg.GetInRepoConfig(orgRepo, branch, baseSHAGetter, headSHAGetters...)
 		if err != nil {
 			logger.WithError(err).Info("Failed to get presubmits")
+			inRepoConfigErr = err
 		} else {
 			logger.WithField("static-jobs", len(presubmits)).WithField("jobs-with-inrepoconfig", len(presubmitsWithInrepoconfig)).Debug("Jobs found.")
 			presubmits = append(presubmits, prowYAML.Presubmits...)
 		}
 	}
 
+	var matchingPresubmits []config.Presubmit
 	for _, job := range presubmits {
 		if !job.CouldRun(branch) { // filter out jobs that are not branch matching
 			continue
 		}
 		if job.Name == cjer.GetJobName() {
-			if presubmitJob != nil {
-				err = fmt.Errorf("%s matches multiple prow jobs from orgRepo %q", cjer.GetJobName(), orgRepo)
-				return
-			}
-			presubmitJob = &job
+			matchingPresubmits = append(matchingPresubmits, job)
 		}
 	}
-	// This also captures the case where fetching jobs from inrepoconfig failed.
-	// However doesn't not distinguish between this case and a wrong prow job name.
+	var warning string
+	presubmitJob, warning, err = pickJob(cjer, orgRepo, matchingPresubmits, func(job config.Presubmit) string { return job.Context })
+	if err != nil {
+		return
+	}
+	if warning != "" {
+		warnings = append(warnings, warning)
+	}
 	if presubmitJob == nil {
-		err = fmt.Errorf("failed to find associated presubmit job %q from orgRepo %q", cjer.GetJobName(), orgRepo)
+		err = jobNotFoundError("presubmit", cjer.GetJobName(), orgRepo, inRepoConfigErr)
 		return
 	}
 
@@ -947,11 +2195,21 @@ func (prh *presubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg co
 	return
 }
 
+// jobNotFoundError returns the error for a job of the given type that is not
+// among the jobs of orgRepo. If getting the inrepoconfig of orgRepo failed, the
+// job may be defined there, so the error is retryable.
+func jobNotFoundError(jobType, jobName, orgRepo string, inRepoConfigErr error) error {
+	if inRepoConfigErr != nil {
+		return status.Errorf(codes.Unavailable, "failed to find associated %s job %q from orgRepo %q, possibly because getting its inrepoconfig failed: %v", jobType, jobName, orgRepo, inRepoConfigErr)
+	}
+	return status.Errorf(codes.NotFound, "failed to find associated %s job %q from orgRepo %q", jobType, jobName, orgRepo)
+}
+
 // postsubmitJobHandler implements jobHandler
 type postsubmitJobHandler struct {
 }
 
-func (poh *postsubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, labels map[string]string, annotations map[string]string, err error) {
+func (poh *postsubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, labels map[string]string, annotations map[string]string, warnings []string, err error) {
 	// postsubmit jobs require Refs to be set
 	refs, err := ToCrdRefs(cjer.GetRefs())
 	if err != nil {
@@ -977,6 +2235,8 @@ func (poh *postsubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg c
 
 	logger := logrus.WithFields(logrus.Fields{"org": org, "repo": repo, "branch": branch, "orgRepo": orgRepo})
 	postsubmits := mainConfig.GetPostsubmitsStatic(orgRepo)
+	// inRepoConfigErr is the error getting the inrepoconfig, if any.
+	var inRepoConfigErr error
 	if ircg != nil {
 		logger.Debug("Getting prow jobs.")
 		var postsubmitsWithInrepoconfig []config.Postsubmit
@@ -984,27 +2244,32 @@ func (poh *postsubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg c
 		prowYAML, err := ircg.GetInRepoConfig(orgRepo, branch, baseSHAGetter)
 		if err != nil {
 			logger.WithError(err).Info("Failed to get postsubmits from inrepoconfig")
+			inRepoConfigErr = err
 		} else {
 			logger.WithField("static-jobs", len(postsubmits)).WithField("jobs-with-inrepoconfig", len(postsubmitsWithInrepoconfig)).Debug("Jobs found.")
 			postsubmits = append(postsubmits, prowYAML.Postsubmits...)
 		}
 	}
 
+	var matchingPostsubmits []config.Postsubmit
 	for _, job := range postsubmits {
 		if !job.CouldRun(branch) { // filter out jobs that are not branch matching
 			continue
 		}
 		if job.Name == cjer.GetJobName() {
-			if postsubmitJob != nil {
-				return nil, nil, nil, fmt.Errorf("%s matches multiple prow jobs from orgRepo %q", cjer.GetJobName(), orgRepo)
-			}
-			postsubmitJob = &job
+			matchingPostsubmits = append(matchingPostsubmits, job)
 		}
 	}
-	// This also captures the case where fetching jobs from inrepoconfig failed.
-	// However doesn't not distinguish between this case and a wrong prow job name.
+	var warning string
+	postsubmitJob, warning, err = pickJob(cjer, orgRepo, matchingPostsubmits, func(job config.Postsubmit) string { return job.Context })
+	if err != nil {
+		return
+	}
+	if warning != "" {
+		warnings = append(warnings, warning)
+	}
 	if postsubmitJob == nil {
-		err = fmt.Errorf("failed to find associated postsubmit job %q from orgRepo %q", cjer.GetJobName(), orgRepo)
+		err = jobNotFoundError("postsubmit", cjer.GetJobName(), orgRepo, inRepoConfigErr)
 		return
 	}
 
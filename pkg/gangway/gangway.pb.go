@@ -10,6 +10,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
@@ -146,6 +147,59 @@ func (JobExecutionType) EnumDescriptor() ([]byte, []int) {
 	return file_gangway_proto_rawDescGZIP(), []int{1}
 }
 
+type BulkJobStatusChangeState int32
+
+const (
+	BulkJobStatusChangeState_BULK_JOB_STATUS_CHANGE_STATE_UNSPECIFIED BulkJobStatusChangeState = 0
+	BulkJobStatusChangeState_BULK_JOB_STATUS_CHANGE_RUNNING           BulkJobStatusChangeState = 1
+	BulkJobStatusChangeState_BULK_JOB_STATUS_CHANGE_SUCCEEDED         BulkJobStatusChangeState = 2
+	// The jobs could not be listed, so no job was changed.
+	BulkJobStatusChangeState_BULK_JOB_STATUS_CHANGE_FAILED BulkJobStatusChangeState = 3
+)
+
+// Enum value maps for BulkJobStatusChangeState.
+var (
+	BulkJobStatusChangeState_name = map[int32]string{
+		0: "BULK_JOB_STATUS_CHANGE_STATE_UNSPECIFIED",
+		1: "BULK_JOB_STATUS_CHANGE_RUNNING",
+		2: "BULK_JOB_STATUS_CHANGE_SUCCEEDED",
+		3: "BULK_JOB_STATUS_CHANGE_FAILED",
+	}
+	BulkJobStatusChangeState_value = map[string]int32{
+		"BULK_JOB_STATUS_CHANGE_STATE_UNSPECIFIED": 0,
+		"BULK_JOB_STATUS_CHANGE_RUNNING":           1,
+		"BULK_JOB_STATUS_CHANGE_SUCCEEDED":         2,
+		"BULK_JOB_STATUS_CHANGE_FAILED":            3,
+	}
+)
+
+func (x BulkJobStatusChangeState) Enum() *BulkJobStatusChangeState {
+	p := new(BulkJobStatusChangeState)
+	*p = x
+	return p
+}
+
+func (x BulkJobStatusChangeState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BulkJobStatusChangeState) Descriptor() protoreflect.EnumDescriptor {
+	return file_gangway_proto_enumTypes[2].Descriptor()
+}
+
+func (BulkJobStatusChangeState) Type() protoreflect.EnumType {
+	return &file_gangway_proto_enumTypes[2]
+}
+
+func (x BulkJobStatusChangeState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BulkJobStatusChangeState.Descriptor instead.
+func (BulkJobStatusChangeState) EnumDescriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{2}
+}
+
 type CreateJobExecutionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -155,6 +209,13 @@ type CreateJobExecutionRequest struct {
 	JobExecutionType JobExecutionType `protobuf:"varint,2,opt,name=job_execution_type,json=jobExecutionType,proto3,enum=JobExecutionType" json:"job_execution_type,omitempty"`
 	Refs             *Refs            `protobuf:"bytes,3,opt,name=refs,proto3" json:"refs,omitempty"`
 	PodSpecOptions   *PodSpecOptions  `protobuf:"bytes,4,opt,name=pod_spec_options,json=podSpecOptions,proto3" json:"pod_spec_options,omitempty"`
+	// The context of the job to run. Only needed to pick the right job if
+	// several presubmits or postsubmits share the same name.
+	JobContext string `protobuf:"bytes,5,opt,name=job_context,json=jobContext,proto3" json:"job_context,omitempty"`
+	// An optional key identifying the request. Retrying a request with the same
+	// key returns the job execution created by the first request instead of
+	// creating another one. Keys are scoped to the calling API client.
+	IdempotencyKey string `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 }
 
 func (x *CreateJobExecutionRequest) Reset() {
@@ -217,6 +278,20 @@ func (x *CreateJobExecutionRequest) GetPodSpecOptions() *PodSpecOptions {
 	return nil
 }
 
+func (x *CreateJobExecutionRequest) GetJobContext() string {
+	if x != nil {
+		return x.JobContext
+	}
+	return ""
+}
+
+func (x *CreateJobExecutionRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type PodSpecOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -328,18 +403,17 @@ func (x *GetJobExecutionRequest) GetId() string {
 	return ""
 }
 
-// Look up all Prow Job executions that match all fields given here.
-type ListJobExecutionsRequest struct {
+// Look up several Prow Job executions at once.
+type GetJobExecutionsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	JobName string             `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`         // Mapped to URL query parameter `job_name`.
-	Status  JobExecutionStatus `protobuf:"varint,2,opt,name=status,proto3,enum=JobExecutionStatus" json:"status,omitempty"` // Mapped to URL query parameter `status`.
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
 }
 
-func (x *ListJobExecutionsRequest) Reset() {
-	*x = ListJobExecutionsRequest{}
+func (x *GetJobExecutionsRequest) Reset() {
+	*x = GetJobExecutionsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_gangway_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -347,13 +421,13 @@ func (x *ListJobExecutionsRequest) Reset() {
 	}
 }
 
-func (x *ListJobExecutionsRequest) String() string {
+func (x *GetJobExecutionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListJobExecutionsRequest) ProtoMessage() {}
+func (*GetJobExecutionsRequest) ProtoMessage() {}
 
-func (x *ListJobExecutionsRequest) ProtoReflect() protoreflect.Message {
+func (x *GetJobExecutionsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_gangway_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -365,35 +439,30 @@ func (x *ListJobExecutionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListJobExecutionsRequest.ProtoReflect.Descriptor instead.
-func (*ListJobExecutionsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetJobExecutionsRequest.ProtoReflect.Descriptor instead.
+func (*GetJobExecutionsRequest) Descriptor() ([]byte, []int) {
 	return file_gangway_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *ListJobExecutionsRequest) GetJobName() string {
-	if x != nil {
-		return x.JobName
-	}
-	return ""
-}
-
-func (x *ListJobExecutionsRequest) GetStatus() JobExecutionStatus {
+func (x *GetJobExecutionsRequest) GetIds() []string {
 	if x != nil {
-		return x.Status
+		return x.Ids
 	}
-	return JobExecutionStatus_JOB_EXECUTION_STATUS_UNSPECIFIED
+	return nil
 }
 
-type JobExecutions struct {
+// Look up only the status of a single Prow Job execution, e.g. for frequent
+// polling.
+type GetJobExecutionStatusRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	JobExecution []*JobExecution `protobuf:"bytes,1,rep,name=job_execution,json=jobExecution,proto3" json:"job_execution,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *JobExecutions) Reset() {
-	*x = JobExecutions{}
+func (x *GetJobExecutionStatusRequest) Reset() {
+	*x = GetJobExecutionStatusRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_gangway_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -401,13 +470,13 @@ func (x *JobExecutions) Reset() {
 	}
 }
 
-func (x *JobExecutions) String() string {
+func (x *GetJobExecutionStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JobExecutions) ProtoMessage() {}
+func (*GetJobExecutionStatusRequest) ProtoMessage() {}
 
-func (x *JobExecutions) ProtoReflect() protoreflect.Message {
+func (x *GetJobExecutionStatusRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_gangway_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -419,37 +488,29 @@ func (x *JobExecutions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JobExecutions.ProtoReflect.Descriptor instead.
-func (*JobExecutions) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetJobExecutionStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetJobExecutionStatusRequest) Descriptor() ([]byte, []int) {
 	return file_gangway_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *JobExecutions) GetJobExecution() []*JobExecution {
+func (x *GetJobExecutionStatusRequest) GetId() string {
 	if x != nil {
-		return x.JobExecution
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type JobExecution struct {
+// Abort a single Prow Job execution that is not complete yet.
+type CancelJobExecutionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	JobName        string                 `protobuf:"bytes,2,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
-	JobType        JobExecutionType       `protobuf:"varint,3,opt,name=job_type,json=jobType,proto3,enum=JobExecutionType" json:"job_type,omitempty"`
-	JobStatus      JobExecutionStatus     `protobuf:"varint,4,opt,name=job_status,json=jobStatus,proto3,enum=JobExecutionStatus" json:"job_status,omitempty"`
-	Refs           *Refs                  `protobuf:"bytes,5,opt,name=refs,proto3" json:"refs,omitempty"`
-	PodSpecOptions *PodSpecOptions        `protobuf:"bytes,6,opt,name=pod_spec_options,json=podSpecOptions,proto3" json:"pod_spec_options,omitempty"`
-	GcsPath        string                 `protobuf:"bytes,7,opt,name=gcs_path,json=gcsPath,proto3" json:"gcs_path,omitempty"`
-	CreateTime     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
-	CompletionTime *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=completion_time,json=completionTime,proto3" json:"completion_time,omitempty"`
-	JobUrl         string                 `protobuf:"bytes,10,opt,name=job_url,json=jobUrl,proto3" json:"job_url,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *JobExecution) Reset() {
-	*x = JobExecution{}
+func (x *CancelJobExecutionRequest) Reset() {
+	*x = CancelJobExecutionRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_gangway_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -457,13 +518,13 @@ func (x *JobExecution) Reset() {
 	}
 }
 
-func (x *JobExecution) String() string {
+func (x *CancelJobExecutionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JobExecution) ProtoMessage() {}
+func (*CancelJobExecutionRequest) ProtoMessage() {}
 
-func (x *JobExecution) ProtoReflect() protoreflect.Message {
+func (x *CancelJobExecutionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_gangway_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -475,120 +536,123 @@ func (x *JobExecution) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JobExecution.ProtoReflect.Descriptor instead.
-func (*JobExecution) Descriptor() ([]byte, []int) {
+// Deprecated: Use CancelJobExecutionRequest.ProtoReflect.Descriptor instead.
+func (*CancelJobExecutionRequest) Descriptor() ([]byte, []int) {
 	return file_gangway_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *JobExecution) GetId() string {
+func (x *CancelJobExecutionRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *JobExecution) GetJobName() string {
-	if x != nil {
-		return x.JobName
-	}
-	return ""
-}
+type JobStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *JobExecution) GetJobType() JobExecutionType {
-	if x != nil {
-		return x.JobType
-	}
-	return JobExecutionType_JOB_EXECUTION_TYPE_UNSPECIFIED
+	Status JobExecutionStatus `protobuf:"varint,1,opt,name=status,proto3,enum=JobExecutionStatus" json:"status,omitempty"`
+	// Whether the job execution reached a final status.
+	Complete bool `protobuf:"varint,2,opt,name=complete,proto3" json:"complete,omitempty"`
+	// The link to the logs of the job execution.
+	Url string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
 }
 
-func (x *JobExecution) GetJobStatus() JobExecutionStatus {
-	if x != nil {
-		return x.JobStatus
+func (x *JobStatusResponse) Reset() {
+	*x = JobStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return JobExecutionStatus_JOB_EXECUTION_STATUS_UNSPECIFIED
 }
 
-func (x *JobExecution) GetRefs() *Refs {
-	if x != nil {
-		return x.Refs
-	}
-	return nil
+func (x *JobStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *JobExecution) GetPodSpecOptions() *PodSpecOptions {
-	if x != nil {
-		return x.PodSpecOptions
+func (*JobStatusResponse) ProtoMessage() {}
+
+func (x *JobStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *JobExecution) GetGcsPath() string {
-	if x != nil {
-		return x.GcsPath
-	}
-	return ""
+// Deprecated: Use JobStatusResponse.ProtoReflect.Descriptor instead.
+func (*JobStatusResponse) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *JobExecution) GetCreateTime() *timestamppb.Timestamp {
+func (x *JobStatusResponse) GetStatus() JobExecutionStatus {
 	if x != nil {
-		return x.CreateTime
+		return x.Status
 	}
-	return nil
+	return JobExecutionStatus_JOB_EXECUTION_STATUS_UNSPECIFIED
 }
 
-func (x *JobExecution) GetCompletionTime() *timestamppb.Timestamp {
+func (x *JobStatusResponse) GetComplete() bool {
 	if x != nil {
-		return x.CompletionTime
+		return x.Complete
 	}
-	return nil
+	return false
 }
 
-func (x *JobExecution) GetJobUrl() string {
+func (x *JobStatusResponse) GetUrl() string {
 	if x != nil {
-		return x.JobUrl
+		return x.Url
 	}
 	return ""
 }
 
-// Refs is a direct, 1:1 translation of the existing "Refs" struct defined in
-// prow/apis/prowjobs/v1/types.go.
-type Refs struct {
+// Look up all Prow Job executions that match all fields given here.
+type ListJobExecutionsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Org            string  `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
-	Repo           string  `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`
-	RepoLink       string  `protobuf:"bytes,3,opt,name=repo_link,json=repoLink,proto3" json:"repo_link,omitempty"`
-	BaseRef        string  `protobuf:"bytes,4,opt,name=base_ref,json=baseRef,proto3" json:"base_ref,omitempty"`
-	BaseSha        string  `protobuf:"bytes,5,opt,name=base_sha,json=baseSha,proto3" json:"base_sha,omitempty"`
-	BaseLink       string  `protobuf:"bytes,6,opt,name=base_link,json=baseLink,proto3" json:"base_link,omitempty"`
-	Pulls          []*Pull `protobuf:"bytes,7,rep,name=pulls,proto3" json:"pulls,omitempty"`
-	PathAlias      string  `protobuf:"bytes,8,opt,name=path_alias,json=pathAlias,proto3" json:"path_alias,omitempty"`
-	WorkDir        bool    `protobuf:"varint,9,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
-	CloneUri       string  `protobuf:"bytes,10,opt,name=clone_uri,json=cloneUri,proto3" json:"clone_uri,omitempty"`
-	SkipSubmodules bool    `protobuf:"varint,11,opt,name=skip_submodules,json=skipSubmodules,proto3" json:"skip_submodules,omitempty"`
-	CloneDepth     int32   `protobuf:"varint,12,opt,name=clone_depth,json=cloneDepth,proto3" json:"clone_depth,omitempty"`
-	SkipFetchHead  bool    `protobuf:"varint,13,opt,name=skip_fetch_head,json=skipFetchHead,proto3" json:"skip_fetch_head,omitempty"`
+	JobName string             `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`         // Mapped to URL query parameter `job_name`.
+	Status  JobExecutionStatus `protobuf:"varint,2,opt,name=status,proto3,enum=JobExecutionStatus" json:"status,omitempty"` // Mapped to URL query parameter `status`.
+	// The maximum number of job executions to return at once, up to 1000. 0
+	// returns all of them. Fewer job executions may be returned even if there
+	// are more, as the status is only filtered on after listing a page.
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of the previous page, to get the page after it.
+	// StreamJobExecutions ignores page_size and page_token, as it pages on its
+	// own.
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Only return the job executions for the given repository and pull request.
+	// Mapped to URL query parameters `org`, `repo` and `pull`.
+	Org  string `protobuf:"bytes,5,opt,name=org,proto3" json:"org,omitempty"`
+	Repo string `protobuf:"bytes,6,opt,name=repo,proto3" json:"repo,omitempty"`
+	Pull int32  `protobuf:"varint,7,opt,name=pull,proto3" json:"pull,omitempty"`
 }
 
-func (x *Refs) Reset() {
-	*x = Refs{}
+func (x *ListJobExecutionsRequest) Reset() {
+	*x = ListJobExecutionsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gangway_proto_msgTypes[6]
+		mi := &file_gangway_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Refs) String() string {
+func (x *ListJobExecutionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Refs) ProtoMessage() {}
+func (*ListJobExecutionsRequest) ProtoMessage() {}
 
-func (x *Refs) ProtoReflect() protoreflect.Message {
-	mi := &file_gangway_proto_msgTypes[6]
+func (x *ListJobExecutionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -599,123 +663,1595 @@ func (x *Refs) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Refs.ProtoReflect.Descriptor instead.
-func (*Refs) Descriptor() ([]byte, []int) {
-	return file_gangway_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ListJobExecutionsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobExecutionsRequest) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *Refs) GetOrg() string {
+func (x *ListJobExecutionsRequest) GetJobName() string {
 	if x != nil {
-		return x.Org
+		return x.JobName
 	}
 	return ""
 }
 
-func (x *Refs) GetRepo() string {
+func (x *ListJobExecutionsRequest) GetStatus() JobExecutionStatus {
 	if x != nil {
-		return x.Repo
+		return x.Status
 	}
-	return ""
+	return JobExecutionStatus_JOB_EXECUTION_STATUS_UNSPECIFIED
 }
 
-func (x *Refs) GetRepoLink() string {
+func (x *ListJobExecutionsRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.RepoLink
+		return x.PageSize
 	}
-	return ""
+	return 0
 }
 
-func (x *Refs) GetBaseRef() string {
+func (x *ListJobExecutionsRequest) GetPageToken() string {
 	if x != nil {
-		return x.BaseRef
+		return x.PageToken
 	}
 	return ""
 }
 
-func (x *Refs) GetBaseSha() string {
+func (x *ListJobExecutionsRequest) GetOrg() string {
 	if x != nil {
-		return x.BaseSha
+		return x.Org
 	}
 	return ""
 }
 
-func (x *Refs) GetBaseLink() string {
+func (x *ListJobExecutionsRequest) GetRepo() string {
 	if x != nil {
-		return x.BaseLink
+		return x.Repo
 	}
 	return ""
 }
 
-func (x *Refs) GetPulls() []*Pull {
+func (x *ListJobExecutionsRequest) GetPull() int32 {
 	if x != nil {
-		return x.Pulls
+		return x.Pull
 	}
-	return nil
+	return 0
 }
 
-func (x *Refs) GetPathAlias() string {
-	if x != nil {
-		return x.PathAlias
-	}
-	return ""
+// Summarize why recent executions of a single Prow Job failed.
+type GetJobFailureSummaryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobName string `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
 }
 
-func (x *Refs) GetWorkDir() bool {
-	if x != nil {
-		return x.WorkDir
+func (x *GetJobFailureSummaryRequest) Reset() {
+	*x = GetJobFailureSummaryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return false
 }
 
-func (x *Refs) GetCloneUri() string {
-	if x != nil {
-		return x.CloneUri
-	}
-	return ""
+func (x *GetJobFailureSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Refs) GetSkipSubmodules() bool {
-	if x != nil {
-		return x.SkipSubmodules
+func (*GetJobFailureSummaryRequest) ProtoMessage() {}
+
+func (x *GetJobFailureSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *Refs) GetCloneDepth() int32 {
-	if x != nil {
-		return x.CloneDepth
-	}
-	return 0
+// Deprecated: Use GetJobFailureSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetJobFailureSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Refs) GetSkipFetchHead() bool {
+func (x *GetJobFailureSummaryRequest) GetJobName() string {
 	if x != nil {
-		return x.SkipFetchHead
+		return x.JobName
 	}
-	return false
+	return ""
 }
 
-// Pull is a direct, 1:1 translation of the existing "Pull" struct defined in
-// prow/apis/prowjobs/v1/types.go.
-type Pull struct {
+type JobFailureSummary struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Number     int32  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
-	Author     string `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
-	Sha        string `protobuf:"bytes,3,opt,name=sha,proto3" json:"sha,omitempty"`
+	JobName string `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	// The number of completed executions that were looked at.
+	CompletedExecutions int32 `protobuf:"varint,2,opt,name=completed_executions,json=completedExecutions,proto3" json:"completed_executions,omitempty"`
+	// The number of completed executions that did not succeed.
+	FailedExecutions int32 `protobuf:"varint,3,opt,name=failed_executions,json=failedExecutions,proto3" json:"failed_executions,omitempty"`
+	// The failed executions grouped by failure reason, most frequent first.
+	FailureReasons []*JobFailureReasonCount `protobuf:"bytes,4,rep,name=failure_reasons,json=failureReasons,proto3" json:"failure_reasons,omitempty"`
+}
+
+func (x *JobFailureSummary) Reset() {
+	*x = JobFailureSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobFailureSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobFailureSummary) ProtoMessage() {}
+
+func (x *JobFailureSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobFailureSummary.ProtoReflect.Descriptor instead.
+func (*JobFailureSummary) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *JobFailureSummary) GetJobName() string {
+	if x != nil {
+		return x.JobName
+	}
+	return ""
+}
+
+func (x *JobFailureSummary) GetCompletedExecutions() int32 {
+	if x != nil {
+		return x.CompletedExecutions
+	}
+	return 0
+}
+
+func (x *JobFailureSummary) GetFailedExecutions() int32 {
+	if x != nil {
+		return x.FailedExecutions
+	}
+	return 0
+}
+
+func (x *JobFailureSummary) GetFailureReasons() []*JobFailureReasonCount {
+	if x != nil {
+		return x.FailureReasons
+	}
+	return nil
+}
+
+type JobFailureReasonCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Count  int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *JobFailureReasonCount) Reset() {
+	*x = JobFailureReasonCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobFailureReasonCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobFailureReasonCount) ProtoMessage() {}
+
+func (x *JobFailureReasonCount) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobFailureReasonCount.ProtoReflect.Descriptor instead.
+func (*JobFailureReasonCount) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *JobFailureReasonCount) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *JobFailureReasonCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Count the Prow Job executions of the calling tenant that are not complete yet.
+type GetTenantInFlightCountRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetTenantInFlightCountRequest) Reset() {
+	*x = GetTenantInFlightCountRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTenantInFlightCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantInFlightCountRequest) ProtoMessage() {}
+
+func (x *GetTenantInFlightCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantInFlightCountRequest.ProtoReflect.Descriptor instead.
+func (*GetTenantInFlightCountRequest) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{11}
+}
+
+type TenantInFlightCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of executions that are not complete yet.
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// The executions that are not complete yet grouped by status.
+	StatusCounts []*JobExecutionStatusCount `protobuf:"bytes,2,rep,name=status_counts,json=statusCounts,proto3" json:"status_counts,omitempty"`
+}
+
+func (x *TenantInFlightCount) Reset() {
+	*x = TenantInFlightCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TenantInFlightCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantInFlightCount) ProtoMessage() {}
+
+func (x *TenantInFlightCount) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantInFlightCount.ProtoReflect.Descriptor instead.
+func (*TenantInFlightCount) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TenantInFlightCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *TenantInFlightCount) GetStatusCounts() []*JobExecutionStatusCount {
+	if x != nil {
+		return x.StatusCounts
+	}
+	return nil
+}
+
+type JobExecutionStatusCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status JobExecutionStatus `protobuf:"varint,1,opt,name=status,proto3,enum=JobExecutionStatus" json:"status,omitempty"`
+	Count  int32              `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *JobExecutionStatusCount) Reset() {
+	*x = JobExecutionStatusCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobExecutionStatusCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobExecutionStatusCount) ProtoMessage() {}
+
+func (x *JobExecutionStatusCount) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobExecutionStatusCount.ProtoReflect.Descriptor instead.
+func (*JobExecutionStatusCount) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *JobExecutionStatusCount) GetStatus() JobExecutionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return JobExecutionStatus_JOB_EXECUTION_STATUS_UNSPECIFIED
+}
+
+func (x *JobExecutionStatusCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Look up whether a periodic Prow Job is due to run per its schedule.
+type IsPeriodicDueRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobName string `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+}
+
+func (x *IsPeriodicDueRequest) Reset() {
+	*x = IsPeriodicDueRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IsPeriodicDueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsPeriodicDueRequest) ProtoMessage() {}
+
+func (x *IsPeriodicDueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsPeriodicDueRequest.ProtoReflect.Descriptor instead.
+func (*IsPeriodicDueRequest) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *IsPeriodicDueRequest) GetJobName() string {
+	if x != nil {
+		return x.JobName
+	}
+	return ""
+}
+
+type PeriodicDue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether the periodic is due, i.e. would be triggered by now unless its
+	// previous run is still running.
+	Due bool `protobuf:"varint,1,opt,name=due,proto3" json:"due,omitempty"`
+	// The time the next run of the periodic is scheduled for. It is in the past
+	// if the periodic is due. Unset if it depends on when the running execution
+	// of the periodic completes.
+	NextRun *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
+}
+
+func (x *PeriodicDue) Reset() {
+	*x = PeriodicDue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeriodicDue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeriodicDue) ProtoMessage() {}
+
+func (x *PeriodicDue) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeriodicDue.ProtoReflect.Descriptor instead.
+func (*PeriodicDue) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PeriodicDue) GetDue() bool {
+	if x != nil {
+		return x.Due
+	}
+	return false
+}
+
+func (x *PeriodicDue) GetNextRun() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRun
+	}
+	return nil
+}
+
+// Roll up the status of the presubmits of a single pull request.
+type GetRefsStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Org  string `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	Repo string `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`
+	Pull int32  `protobuf:"varint,3,opt,name=pull,proto3" json:"pull,omitempty"`
+}
+
+func (x *GetRefsStatusRequest) Reset() {
+	*x = GetRefsStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRefsStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRefsStatusRequest) ProtoMessage() {}
+
+func (x *GetRefsStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRefsStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetRefsStatusRequest) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetRefsStatusRequest) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *GetRefsStatusRequest) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *GetRefsStatusRequest) GetPull() int32 {
+	if x != nil {
+		return x.Pull
+	}
+	return 0
+}
+
+type RefsStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether the pull request has presubmit executions and all of them are
+	// complete. Only the latest execution of each presubmit is looked at, so
+	// that retested presubmits count with their latest result.
+	AllComplete bool `protobuf:"varint,1,opt,name=all_complete,json=allComplete,proto3" json:"all_complete,omitempty"`
+	// Whether the latest execution of any presubmit failed or errored.
+	AnyFailed bool `protobuf:"varint,2,opt,name=any_failed,json=anyFailed,proto3" json:"any_failed,omitempty"`
+	// The latest executions of the presubmits grouped by status.
+	StatusCounts []*JobExecutionStatusCount `protobuf:"bytes,3,rep,name=status_counts,json=statusCounts,proto3" json:"status_counts,omitempty"`
+}
+
+func (x *RefsStatus) Reset() {
+	*x = RefsStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefsStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefsStatus) ProtoMessage() {}
+
+func (x *RefsStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefsStatus.ProtoReflect.Descriptor instead.
+func (*RefsStatus) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RefsStatus) GetAllComplete() bool {
+	if x != nil {
+		return x.AllComplete
+	}
+	return false
+}
+
+func (x *RefsStatus) GetAnyFailed() bool {
+	if x != nil {
+		return x.AnyFailed
+	}
+	return false
+}
+
+func (x *RefsStatus) GetStatusCounts() []*JobExecutionStatusCount {
+	if x != nil {
+		return x.StatusCounts
+	}
+	return nil
+}
+
+// Compute percentiles of the durations of the recent executions of a Prow Job.
+type GetJobDurationStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobName string `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	// How far back to look for completed executions. Defaults to 7 days and is
+	// capped at 30 days.
+	Lookback *durationpb.Duration `protobuf:"bytes,2,opt,name=lookback,proto3" json:"lookback,omitempty"`
+}
+
+func (x *GetJobDurationStatsRequest) Reset() {
+	*x = GetJobDurationStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobDurationStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobDurationStatsRequest) ProtoMessage() {}
+
+func (x *GetJobDurationStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobDurationStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetJobDurationStatsRequest) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetJobDurationStatsRequest) GetJobName() string {
+	if x != nil {
+		return x.JobName
+	}
+	return ""
+}
+
+func (x *GetJobDurationStatsRequest) GetLookback() *durationpb.Duration {
+	if x != nil {
+		return x.Lookback
+	}
+	return nil
+}
+
+type DurationStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobName string `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	// The number of completed executions the percentiles are computed from.
+	// Aborted executions are left out, as they did not run to the end.
+	Executions int32 `protobuf:"varint,2,opt,name=executions,proto3" json:"executions,omitempty"`
+	// The percentiles of the durations of the executions, i.e. the time from
+	// their start to their completion. Unset if there are no executions.
+	P50 *durationpb.Duration `protobuf:"bytes,3,opt,name=p50,proto3" json:"p50,omitempty"`
+	P90 *durationpb.Duration `protobuf:"bytes,4,opt,name=p90,proto3" json:"p90,omitempty"`
+	P99 *durationpb.Duration `protobuf:"bytes,5,opt,name=p99,proto3" json:"p99,omitempty"`
+}
+
+func (x *DurationStats) Reset() {
+	*x = DurationStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DurationStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DurationStats) ProtoMessage() {}
+
+func (x *DurationStats) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DurationStats.ProtoReflect.Descriptor instead.
+func (*DurationStats) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DurationStats) GetJobName() string {
+	if x != nil {
+		return x.JobName
+	}
+	return ""
+}
+
+func (x *DurationStats) GetExecutions() int32 {
+	if x != nil {
+		return x.Executions
+	}
+	return 0
+}
+
+func (x *DurationStats) GetP50() *durationpb.Duration {
+	if x != nil {
+		return x.P50
+	}
+	return nil
+}
+
+func (x *DurationStats) GetP90() *durationpb.Duration {
+	if x != nil {
+		return x.P90
+	}
+	return nil
+}
+
+func (x *DurationStats) GetP99() *durationpb.Duration {
+	if x != nil {
+		return x.P99
+	}
+	return nil
+}
+
+type JobExecutions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobExecution []*JobExecution `protobuf:"bytes,1,rep,name=job_execution,json=jobExecution,proto3" json:"job_execution,omitempty"`
+	// The requested ids no job execution was found for. Only set by
+	// GetJobExecutions.
+	MissingIds []string `protobuf:"bytes,2,rep,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"`
+	// The token to pass as page_token to get the next page, or empty if this
+	// is the last one. Only set by ListJobExecutions.
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *JobExecutions) Reset() {
+	*x = JobExecutions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobExecutions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobExecutions) ProtoMessage() {}
+
+func (x *JobExecutions) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobExecutions.ProtoReflect.Descriptor instead.
+func (*JobExecutions) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *JobExecutions) GetJobExecution() []*JobExecution {
+	if x != nil {
+		return x.JobExecution
+	}
+	return nil
+}
+
+func (x *JobExecutions) GetMissingIds() []string {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
+func (x *JobExecutions) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type JobExecution struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	JobName        string                 `protobuf:"bytes,2,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	JobType        JobExecutionType       `protobuf:"varint,3,opt,name=job_type,json=jobType,proto3,enum=JobExecutionType" json:"job_type,omitempty"`
+	JobStatus      JobExecutionStatus     `protobuf:"varint,4,opt,name=job_status,json=jobStatus,proto3,enum=JobExecutionStatus" json:"job_status,omitempty"`
+	Refs           *Refs                  `protobuf:"bytes,5,opt,name=refs,proto3" json:"refs,omitempty"`
+	PodSpecOptions *PodSpecOptions        `protobuf:"bytes,6,opt,name=pod_spec_options,json=podSpecOptions,proto3" json:"pod_spec_options,omitempty"`
+	GcsPath        string                 `protobuf:"bytes,7,opt,name=gcs_path,json=gcsPath,proto3" json:"gcs_path,omitempty"`
+	CreateTime     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	CompletionTime *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=completion_time,json=completionTime,proto3" json:"completion_time,omitempty"`
+	JobUrl         string                 `protobuf:"bytes,10,opt,name=job_url,json=jobUrl,proto3" json:"job_url,omitempty"`
+	// The URLs of all the repositories the job clones, starting with the base
+	// repository followed by any extra refs.
+	CloneUrls []string `protobuf:"bytes,11,rep,name=clone_urls,json=cloneUrls,proto3" json:"clone_urls,omitempty"`
+	// Whether the job errored because of a problem with the infrastructure
+	// running it (e.g. its pod could not be scheduled) rather than because of
+	// the job itself. Such jobs are usually worth retrying.
+	InfraFailure bool `protobuf:"varint,12,opt,name=infra_failure,json=infraFailure,proto3" json:"infra_failure,omitempty"`
+	// The link to the view of the job execution in Deck, if Deck is configured.
+	DeckUrl string `protobuf:"bytes,13,opt,name=deck_url,json=deckUrl,proto3" json:"deck_url,omitempty"`
+	// Whether the job runs with Prow decoration. Only decorated jobs upload
+	// their logs and artifacts.
+	Decorated bool `protobuf:"varint,14,opt,name=decorated,proto3" json:"decorated,omitempty"`
+	// Problems found while resolving the job that did not prevent it from
+	// being created, e.g. that the job name is ambiguous.
+	Warnings []string `protobuf:"bytes,15,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	// The command to comment on a pull request to rerun the job. Only set for
+	// presubmits.
+	RerunCommand string `protobuf:"bytes,16,opt,name=rerun_command,json=rerunCommand,proto3" json:"rerun_command,omitempty"`
+	// The environment of the main test container, including the environment
+	// variables requested in pod_spec_options. Variables that are not set to a
+	// literal value, e.g. taken from secrets, are left out.
+	Env map[string]string `protobuf:"bytes,17,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The UID of the Prow Job CR. Unlike the id, which is the name of the CR
+	// and could be reused once the CR got deleted, it is globally unique.
+	Uid string `protobuf:"bytes,18,opt,name=uid,proto3" json:"uid,omitempty"`
+	// The build cluster the job runs on. Empty if the job still has to be
+	// assigned to a cluster by the scheduler.
+	Cluster string `protobuf:"bytes,19,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	// The last time the status of the job execution changed. Falls back to
+	// the latest of the create, pending and completion times for executions
+	// that have not been updated by plank yet.
+	LastUpdateTime *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=last_update_time,json=lastUpdateTime,proto3" json:"last_update_time,omitempty"`
+	// Whether any of the pull requests the job runs against comes from a
+	// different repository than the one it is merged into, e.g. from a fork.
+	// Such jobs run code that is potentially untrusted.
+	CrossRepo bool `protobuf:"varint,21,opt,name=cross_repo,json=crossRepo,proto3" json:"cross_repo,omitempty"`
+	// The additional repositories the job clones besides the ones in refs, as
+	// resolved from the job configuration.
+	ExtraRefs []*Refs `protobuf:"bytes,22,rep,name=extra_refs,json=extraRefs,proto3" json:"extra_refs,omitempty"`
+	// The id of a job execution of the same job against the same refs that
+	// was not complete yet when this one got created. Only set in the response
+	// of CreateJobExecution.
+	DuplicateOf string `protobuf:"bytes,23,opt,name=duplicate_of,json=duplicateOf,proto3" json:"duplicate_of,omitempty"`
+	// The maximum number of executions of the job that may run at the same
+	// time. 0 means there is no limit.
+	MaxConcurrency int32 `protobuf:"varint,24,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	// The number of jobs of the job queue of the job that may run at the same
+	// time. -1 means there is no limit, either because the job belongs to no
+	// queue or because its queue is unlimited. 0 means the job is blocked from
+	// running.
+	JobQueueCapacity int32 `protobuf:"varint,25,opt,name=job_queue_capacity,json=jobQueueCapacity,proto3" json:"job_queue_capacity,omitempty"`
+	// The time the job execution was submitted, i.e. the time its ProwJob CR
+	// got created. Unlike create_time, which is the time the job started, it
+	// also covers the time the job spent waiting before it started, e.g.
+	// because of concurrency limits.
+	SubmitTime *timestamppb.Timestamp `protobuf:"bytes,26,opt,name=submit_time,json=submitTime,proto3" json:"submit_time,omitempty"`
+	// Whether the job is marked as deprecated in its config, with the
+	// prow.k8s.io/deprecated label or annotation. Only set in the response of
+	// CreateJobExecution.
+	Deprecated bool `protobuf:"varint,27,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	// The message of the prow.k8s.io/deprecation-message annotation of a
+	// deprecated job, e.g. which job to migrate to.
+	DeprecationMessage string `protobuf:"bytes,28,opt,name=deprecation_message,json=deprecationMessage,proto3" json:"deprecation_message,omitempty"`
+	// The storage prefix the artifacts of a decorated job get uploaded to,
+	// e.g. gs://bucket/pr-logs/pull/org_repo/1/job/1234. It is resolved the
+	// same way the pod utilities resolve it, so it is only known once the job
+	// got a build ID.
+	GcsPrefix string `protobuf:"bytes,29,opt,name=gcs_prefix,json=gcsPrefix,proto3" json:"gcs_prefix,omitempty"`
+	// The links to where the results of the job get reported, keyed by the
+	// report target, e.g. "github" for the commit the job reports its status
+	// to or "gerrit" for the change the job comments on. Targets the job is not
+	// reported to, and targets without a link like Slack, are left out.
+	ReportLinks map[string]string `protobuf:"bytes,30,rep,name=report_links,json=reportLinks,proto3" json:"report_links,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The command and the arguments of the main test container as configured
+	// for the job. Decorated jobs run them wrapped by the entrypoint, which
+	// these are not.
+	Command []string `protobuf:"bytes,31,rep,name=command,proto3" json:"command,omitempty"`
+	Args    []string `protobuf:"bytes,32,rep,name=args,proto3" json:"args,omitempty"`
+	// The statuses of the containers of the pod of the job execution, in the
+	// order of the pod spec, init containers first. Only set while the pod
+	// exists and if gangway has access to the build clusters.
+	ContainerStatuses []*ContainerStatus `protobuf:"bytes,33,rep,name=container_statuses,json=containerStatuses,proto3" json:"container_statuses,omitempty"`
+	// Whether the pod of the job gets privileged access to the node it runs
+	// on, i.e. it uses the host network, PID or IPC namespace or has
+	// privileged containers. privileged_access_reasons lists what grants the
+	// access, e.g. "hostNetwork" or "container test: privileged".
+	PrivilegedAccess        bool     `protobuf:"varint,34,opt,name=privileged_access,json=privilegedAccess,proto3" json:"privileged_access,omitempty"`
+	PrivilegedAccessReasons []string `protobuf:"bytes,35,rep,name=privileged_access_reasons,json=privilegedAccessReasons,proto3" json:"privileged_access_reasons,omitempty"`
+	// The number of triggered executions that share a concurrency limit with
+	// this one, i.e. the max_concurrency of the job or the job queue, and were
+	// created before it. Plank starts these first, so this is how many jobs are
+	// ahead of this one. Only set while the execution waits to be started.
+	AheadInQueue int32 `protobuf:"varint,36,opt,name=ahead_in_queue,json=aheadInQueue,proto3" json:"ahead_in_queue,omitempty"`
+	// A receipt of the creation of the job execution, only returned when
+	// creating it.
+	Audit *Audit `protobuf:"bytes,37,opt,name=audit,proto3" json:"audit,omitempty"`
+}
+
+func (x *JobExecution) Reset() {
+	*x = JobExecution{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobExecution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobExecution) ProtoMessage() {}
+
+func (x *JobExecution) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobExecution.ProtoReflect.Descriptor instead.
+func (*JobExecution) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *JobExecution) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *JobExecution) GetJobName() string {
+	if x != nil {
+		return x.JobName
+	}
+	return ""
+}
+
+func (x *JobExecution) GetJobType() JobExecutionType {
+	if x != nil {
+		return x.JobType
+	}
+	return JobExecutionType_JOB_EXECUTION_TYPE_UNSPECIFIED
+}
+
+func (x *JobExecution) GetJobStatus() JobExecutionStatus {
+	if x != nil {
+		return x.JobStatus
+	}
+	return JobExecutionStatus_JOB_EXECUTION_STATUS_UNSPECIFIED
+}
+
+func (x *JobExecution) GetRefs() *Refs {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+func (x *JobExecution) GetPodSpecOptions() *PodSpecOptions {
+	if x != nil {
+		return x.PodSpecOptions
+	}
+	return nil
+}
+
+func (x *JobExecution) GetGcsPath() string {
+	if x != nil {
+		return x.GcsPath
+	}
+	return ""
+}
+
+func (x *JobExecution) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *JobExecution) GetCompletionTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletionTime
+	}
+	return nil
+}
+
+func (x *JobExecution) GetJobUrl() string {
+	if x != nil {
+		return x.JobUrl
+	}
+	return ""
+}
+
+func (x *JobExecution) GetCloneUrls() []string {
+	if x != nil {
+		return x.CloneUrls
+	}
+	return nil
+}
+
+func (x *JobExecution) GetInfraFailure() bool {
+	if x != nil {
+		return x.InfraFailure
+	}
+	return false
+}
+
+func (x *JobExecution) GetDeckUrl() string {
+	if x != nil {
+		return x.DeckUrl
+	}
+	return ""
+}
+
+func (x *JobExecution) GetDecorated() bool {
+	if x != nil {
+		return x.Decorated
+	}
+	return false
+}
+
+func (x *JobExecution) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *JobExecution) GetRerunCommand() string {
+	if x != nil {
+		return x.RerunCommand
+	}
+	return ""
+}
+
+func (x *JobExecution) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *JobExecution) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *JobExecution) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+func (x *JobExecution) GetLastUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdateTime
+	}
+	return nil
+}
+
+func (x *JobExecution) GetCrossRepo() bool {
+	if x != nil {
+		return x.CrossRepo
+	}
+	return false
+}
+
+func (x *JobExecution) GetExtraRefs() []*Refs {
+	if x != nil {
+		return x.ExtraRefs
+	}
+	return nil
+}
+
+func (x *JobExecution) GetDuplicateOf() string {
+	if x != nil {
+		return x.DuplicateOf
+	}
+	return ""
+}
+
+func (x *JobExecution) GetMaxConcurrency() int32 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+func (x *JobExecution) GetJobQueueCapacity() int32 {
+	if x != nil {
+		return x.JobQueueCapacity
+	}
+	return 0
+}
+
+func (x *JobExecution) GetSubmitTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubmitTime
+	}
+	return nil
+}
+
+func (x *JobExecution) GetDeprecated() bool {
+	if x != nil {
+		return x.Deprecated
+	}
+	return false
+}
+
+func (x *JobExecution) GetDeprecationMessage() string {
+	if x != nil {
+		return x.DeprecationMessage
+	}
+	return ""
+}
+
+func (x *JobExecution) GetGcsPrefix() string {
+	if x != nil {
+		return x.GcsPrefix
+	}
+	return ""
+}
+
+func (x *JobExecution) GetReportLinks() map[string]string {
+	if x != nil {
+		return x.ReportLinks
+	}
+	return nil
+}
+
+func (x *JobExecution) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *JobExecution) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *JobExecution) GetContainerStatuses() []*ContainerStatus {
+	if x != nil {
+		return x.ContainerStatuses
+	}
+	return nil
+}
+
+func (x *JobExecution) GetPrivilegedAccess() bool {
+	if x != nil {
+		return x.PrivilegedAccess
+	}
+	return false
+}
+
+func (x *JobExecution) GetPrivilegedAccessReasons() []string {
+	if x != nil {
+		return x.PrivilegedAccessReasons
+	}
+	return nil
+}
+
+func (x *JobExecution) GetAheadInQueue() int32 {
+	if x != nil {
+		return x.AheadInQueue
+	}
+	return 0
+}
+
+func (x *JobExecution) GetAudit() *Audit {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+// Audit records who created a job execution, when and for which tenant.
+type Audit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The type and the ID of the API consumer that created the job execution,
+	// as passed in the x-endpoint-api-consumer-type and
+	// x-endpoint-api-consumer-number headers, e.g. "PROJECT" and the number of
+	// a GCP project.
+	ConsumerType string `protobuf:"bytes,1,opt,name=consumer_type,json=consumerType,proto3" json:"consumer_type,omitempty"`
+	ConsumerId   string `protobuf:"bytes,2,opt,name=consumer_id,json=consumerId,proto3" json:"consumer_id,omitempty"`
+	// The allowed API client the caller got identified as, e.g.
+	// "gcp-PROJECT-123456".
+	Client string `protobuf:"bytes,3,opt,name=client,proto3" json:"client,omitempty"`
+	// The tenant the job execution got created for.
+	TenantId string `protobuf:"bytes,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// When gangway created the job execution.
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+}
+
+func (x *Audit) Reset() {
+	*x = Audit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Audit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Audit) ProtoMessage() {}
+
+func (x *Audit) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Audit.ProtoReflect.Descriptor instead.
+func (*Audit) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Audit) GetConsumerType() string {
+	if x != nil {
+		return x.ConsumerType
+	}
+	return ""
+}
+
+func (x *Audit) GetConsumerId() string {
+	if x != nil {
+		return x.ConsumerId
+	}
+	return ""
+}
+
+func (x *Audit) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *Audit) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Audit) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+// ContainerStatus is the status of a container of the pod of a job execution.
+type ContainerStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// One of "waiting", "running", "terminated" or "unknown".
+	State        string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Ready        bool   `protobuf:"varint,3,opt,name=ready,proto3" json:"ready,omitempty"`
+	RestartCount int32  `protobuf:"varint,4,opt,name=restart_count,json=restartCount,proto3" json:"restart_count,omitempty"`
+}
+
+func (x *ContainerStatus) Reset() {
+	*x = ContainerStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContainerStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerStatus) ProtoMessage() {}
+
+func (x *ContainerStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerStatus.ProtoReflect.Descriptor instead.
+func (*ContainerStatus) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ContainerStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ContainerStatus) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ContainerStatus) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *ContainerStatus) GetRestartCount() int32 {
+	if x != nil {
+		return x.RestartCount
+	}
+	return 0
+}
+
+// Refs is a direct, 1:1 translation of the existing "Refs" struct defined in
+// prow/apis/prowjobs/v1/types.go.
+type Refs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Org                 string   `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	Repo                string   `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`
+	RepoLink            string   `protobuf:"bytes,3,opt,name=repo_link,json=repoLink,proto3" json:"repo_link,omitempty"`
+	BaseRef             string   `protobuf:"bytes,4,opt,name=base_ref,json=baseRef,proto3" json:"base_ref,omitempty"`
+	BaseSha             string   `protobuf:"bytes,5,opt,name=base_sha,json=baseSha,proto3" json:"base_sha,omitempty"`
+	BaseLink            string   `protobuf:"bytes,6,opt,name=base_link,json=baseLink,proto3" json:"base_link,omitempty"`
+	Pulls               []*Pull  `protobuf:"bytes,7,rep,name=pulls,proto3" json:"pulls,omitempty"`
+	PathAlias           string   `protobuf:"bytes,8,opt,name=path_alias,json=pathAlias,proto3" json:"path_alias,omitempty"`
+	WorkDir             bool     `protobuf:"varint,9,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	CloneUri            string   `protobuf:"bytes,10,opt,name=clone_uri,json=cloneUri,proto3" json:"clone_uri,omitempty"`
+	SkipSubmodules      bool     `protobuf:"varint,11,opt,name=skip_submodules,json=skipSubmodules,proto3" json:"skip_submodules,omitempty"`
+	CloneDepth          int32    `protobuf:"varint,12,opt,name=clone_depth,json=cloneDepth,proto3" json:"clone_depth,omitempty"`
+	SkipFetchHead       bool     `protobuf:"varint,13,opt,name=skip_fetch_head,json=skipFetchHead,proto3" json:"skip_fetch_head,omitempty"`
+	Auxiliary           bool     `protobuf:"varint,14,opt,name=auxiliary,proto3" json:"auxiliary,omitempty"`
+	BloblessFetch       *bool    `protobuf:"varint,15,opt,name=blobless_fetch,json=bloblessFetch,proto3,oneof" json:"blobless_fetch,omitempty"`
+	SparseCheckoutFiles []string `protobuf:"bytes,16,rep,name=sparse_checkout_files,json=sparseCheckoutFiles,proto3" json:"sparse_checkout_files,omitempty"`
+}
+
+func (x *Refs) Reset() {
+	*x = Refs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Refs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Refs) ProtoMessage() {}
+
+func (x *Refs) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Refs.ProtoReflect.Descriptor instead.
+func (*Refs) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Refs) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *Refs) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *Refs) GetRepoLink() string {
+	if x != nil {
+		return x.RepoLink
+	}
+	return ""
+}
+
+func (x *Refs) GetBaseRef() string {
+	if x != nil {
+		return x.BaseRef
+	}
+	return ""
+}
+
+func (x *Refs) GetBaseSha() string {
+	if x != nil {
+		return x.BaseSha
+	}
+	return ""
+}
+
+func (x *Refs) GetBaseLink() string {
+	if x != nil {
+		return x.BaseLink
+	}
+	return ""
+}
+
+func (x *Refs) GetPulls() []*Pull {
+	if x != nil {
+		return x.Pulls
+	}
+	return nil
+}
+
+func (x *Refs) GetPathAlias() string {
+	if x != nil {
+		return x.PathAlias
+	}
+	return ""
+}
+
+func (x *Refs) GetWorkDir() bool {
+	if x != nil {
+		return x.WorkDir
+	}
+	return false
+}
+
+func (x *Refs) GetCloneUri() string {
+	if x != nil {
+		return x.CloneUri
+	}
+	return ""
+}
+
+func (x *Refs) GetSkipSubmodules() bool {
+	if x != nil {
+		return x.SkipSubmodules
+	}
+	return false
+}
+
+func (x *Refs) GetCloneDepth() int32 {
+	if x != nil {
+		return x.CloneDepth
+	}
+	return 0
+}
+
+func (x *Refs) GetSkipFetchHead() bool {
+	if x != nil {
+		return x.SkipFetchHead
+	}
+	return false
+}
+
+func (x *Refs) GetAuxiliary() bool {
+	if x != nil {
+		return x.Auxiliary
+	}
+	return false
+}
+
+func (x *Refs) GetBloblessFetch() bool {
+	if x != nil && x.BloblessFetch != nil {
+		return *x.BloblessFetch
+	}
+	return false
+}
+
+func (x *Refs) GetSparseCheckoutFiles() []string {
+	if x != nil {
+		return x.SparseCheckoutFiles
+	}
+	return nil
+}
+
+// Pull is a direct, 1:1 translation of the existing "Pull" struct defined in
+// prow/apis/prowjobs/v1/types.go.
+type Pull struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number     int32  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Author     string `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Sha        string `protobuf:"bytes,3,opt,name=sha,proto3" json:"sha,omitempty"`
 	Title      string `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
 	Ref        string `protobuf:"bytes,5,opt,name=ref,proto3" json:"ref,omitempty"`
 	Link       string `protobuf:"bytes,6,opt,name=link,proto3" json:"link,omitempty"`
 	CommitLink string `protobuf:"bytes,7,opt,name=commit_link,json=commitLink,proto3" json:"commit_link,omitempty"`
 	AuthorLink string `protobuf:"bytes,8,opt,name=author_link,json=authorLink,proto3" json:"author_link,omitempty"`
+	// The full name (org/repo) of the repository the pull request comes from.
+	// It differs from the repository of the refs for pull requests from forks.
+	HeadRepo string `protobuf:"bytes,9,opt,name=head_repo,json=headRepo,proto3" json:"head_repo,omitempty"`
+	HeadRef  string `protobuf:"bytes,10,opt,name=head_ref,json=headRef,proto3" json:"head_ref,omitempty"`
 }
 
 func (x *Pull) Reset() {
 	*x = Pull{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gangway_proto_msgTypes[7]
+		mi := &file_gangway_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -728,7 +2264,7 @@ func (x *Pull) String() string {
 func (*Pull) ProtoMessage() {}
 
 func (x *Pull) ProtoReflect() protoreflect.Message {
-	mi := &file_gangway_proto_msgTypes[7]
+	mi := &file_gangway_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -741,7 +2277,7 @@ func (x *Pull) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Pull.ProtoReflect.Descriptor instead.
 func (*Pull) Descriptor() ([]byte, []int) {
-	return file_gangway_proto_rawDescGZIP(), []int{7}
+	return file_gangway_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *Pull) GetNumber() int32 {
@@ -800,6 +2336,20 @@ func (x *Pull) GetAuthorLink() string {
 	return ""
 }
 
+func (x *Pull) GetHeadRepo() string {
+	if x != nil {
+		return x.HeadRepo
+	}
+	return ""
+}
+
+func (x *Pull) GetHeadRef() string {
+	if x != nil {
+		return x.HeadRef
+	}
+	return ""
+}
+
 type BulkJobStatusChangeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -811,25 +2361,174 @@ type BulkJobStatusChangeRequest struct {
 	StartedAfter    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=started_after,json=startedAfter,proto3" json:"started_after,omitempty"`
 	JobType         JobExecutionType       `protobuf:"varint,5,opt,name=job_type,json=jobType,proto3,enum=JobExecutionType" json:"job_type,omitempty"`
 	Refs            *Refs                  `protobuf:"bytes,6,opt,name=refs,proto3" json:"refs,omitempty"`
+	// Optional correlation id under which the progress of the change can be
+	// looked up with GetBulkJobStatusChange.
+	Id string `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
 }
 
 func (x *BulkJobStatusChangeRequest) Reset() {
 	*x = BulkJobStatusChangeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gangway_proto_msgTypes[8]
+		mi := &file_gangway_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkJobStatusChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkJobStatusChangeRequest) ProtoMessage() {}
+
+func (x *BulkJobStatusChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkJobStatusChangeRequest.ProtoReflect.Descriptor instead.
+func (*BulkJobStatusChangeRequest) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BulkJobStatusChangeRequest) GetJobStatusChange() *JobStatusChange {
+	if x != nil {
+		return x.JobStatusChange
+	}
+	return nil
+}
+
+func (x *BulkJobStatusChangeRequest) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+func (x *BulkJobStatusChangeRequest) GetStartedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedBefore
+	}
+	return nil
+}
+
+func (x *BulkJobStatusChangeRequest) GetStartedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAfter
+	}
+	return nil
+}
+
+func (x *BulkJobStatusChangeRequest) GetJobType() JobExecutionType {
+	if x != nil {
+		return x.JobType
+	}
+	return JobExecutionType_JOB_EXECUTION_TYPE_UNSPECIFIED
+}
+
+func (x *BulkJobStatusChangeRequest) GetRefs() *Refs {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+func (x *BulkJobStatusChangeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetBulkJobStatusChangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetBulkJobStatusChangeRequest) Reset() {
+	*x = GetBulkJobStatusChangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBulkJobStatusChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBulkJobStatusChangeRequest) ProtoMessage() {}
+
+func (x *GetBulkJobStatusChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBulkJobStatusChangeRequest.ProtoReflect.Descriptor instead.
+func (*GetBulkJobStatusChangeRequest) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetBulkJobStatusChangeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// The progress of a BulkJobStatusChange.
+type BulkJobStatusChangeProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	State BulkJobStatusChangeState `protobuf:"varint,2,opt,name=state,proto3,enum=BulkJobStatusChangeState" json:"state,omitempty"`
+	// The number of jobs matching the request.
+	Matched int32 `protobuf:"varint,3,opt,name=matched,proto3" json:"matched,omitempty"`
+	// The number of matching jobs that were changed.
+	Updated int32 `protobuf:"varint,4,opt,name=updated,proto3" json:"updated,omitempty"`
+	// The number of matching jobs that the client is not authorized to change.
+	Skipped int32 `protobuf:"varint,5,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	// The number of matching jobs that failed to be changed.
+	Failed int32 `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+}
+
+func (x *BulkJobStatusChangeProgress) Reset() {
+	*x = BulkJobStatusChangeProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BulkJobStatusChangeRequest) String() string {
+func (x *BulkJobStatusChangeProgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BulkJobStatusChangeRequest) ProtoMessage() {}
+func (*BulkJobStatusChangeProgress) ProtoMessage() {}
 
-func (x *BulkJobStatusChangeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_gangway_proto_msgTypes[8]
+func (x *BulkJobStatusChangeProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -840,51 +2539,51 @@ func (x *BulkJobStatusChangeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BulkJobStatusChangeRequest.ProtoReflect.Descriptor instead.
-func (*BulkJobStatusChangeRequest) Descriptor() ([]byte, []int) {
-	return file_gangway_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use BulkJobStatusChangeProgress.ProtoReflect.Descriptor instead.
+func (*BulkJobStatusChangeProgress) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *BulkJobStatusChangeRequest) GetJobStatusChange() *JobStatusChange {
+func (x *BulkJobStatusChangeProgress) GetId() string {
 	if x != nil {
-		return x.JobStatusChange
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *BulkJobStatusChangeRequest) GetCluster() string {
+func (x *BulkJobStatusChangeProgress) GetState() BulkJobStatusChangeState {
 	if x != nil {
-		return x.Cluster
+		return x.State
 	}
-	return ""
+	return BulkJobStatusChangeState_BULK_JOB_STATUS_CHANGE_STATE_UNSPECIFIED
 }
 
-func (x *BulkJobStatusChangeRequest) GetStartedBefore() *timestamppb.Timestamp {
+func (x *BulkJobStatusChangeProgress) GetMatched() int32 {
 	if x != nil {
-		return x.StartedBefore
+		return x.Matched
 	}
-	return nil
+	return 0
 }
 
-func (x *BulkJobStatusChangeRequest) GetStartedAfter() *timestamppb.Timestamp {
+func (x *BulkJobStatusChangeProgress) GetUpdated() int32 {
 	if x != nil {
-		return x.StartedAfter
+		return x.Updated
 	}
-	return nil
+	return 0
 }
 
-func (x *BulkJobStatusChangeRequest) GetJobType() JobExecutionType {
+func (x *BulkJobStatusChangeProgress) GetSkipped() int32 {
 	if x != nil {
-		return x.JobType
+		return x.Skipped
 	}
-	return JobExecutionType_JOB_EXECUTION_TYPE_UNSPECIFIED
+	return 0
 }
 
-func (x *BulkJobStatusChangeRequest) GetRefs() *Refs {
+func (x *BulkJobStatusChangeProgress) GetFailed() int32 {
 	if x != nil {
-		return x.Refs
+		return x.Failed
 	}
-	return nil
+	return 0
 }
 
 type JobStatusChange struct {
@@ -899,7 +2598,7 @@ type JobStatusChange struct {
 func (x *JobStatusChange) Reset() {
 	*x = JobStatusChange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gangway_proto_msgTypes[9]
+		mi := &file_gangway_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -912,7 +2611,7 @@ func (x *JobStatusChange) String() string {
 func (*JobStatusChange) ProtoMessage() {}
 
 func (x *JobStatusChange) ProtoReflect() protoreflect.Message {
-	mi := &file_gangway_proto_msgTypes[9]
+	mi := &file_gangway_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -925,7 +2624,7 @@ func (x *JobStatusChange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobStatusChange.ProtoReflect.Descriptor instead.
 func (*JobStatusChange) Descriptor() ([]byte, []int) {
-	return file_gangway_proto_rawDescGZIP(), []int{9}
+	return file_gangway_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *JobStatusChange) GetCurrent() JobExecutionStatus {
@@ -947,11 +2646,13 @@ var File_gangway_proto protoreflect.FileDescriptor
 var file_gangway_proto_rawDesc = []byte{
 	0x0a, 0x0d, 0x67, 0x61, 0x6e, 0x67, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
 	0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f,
-	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67,
 	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
 	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
-	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcd, 0x01, 0x0a, 0x19,
+	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x97, 0x02, 0x0a, 0x19,
 	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
 	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6a, 0x6f, 0x62,
 	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6a, 0x6f, 0x62,
@@ -964,179 +2665,491 @@ var file_gangway_proto_rawDesc = []byte{
 	0x12, 0x39, 0x0a, 0x10, 0x70, 0x6f, 0x64, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x5f, 0x6f, 0x70, 0x74,
 	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x50, 0x6f, 0x64,
 	0x53, 0x70, 0x65, 0x63, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0e, 0x70, 0x6f, 0x64,
-	0x53, 0x70, 0x65, 0x63, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xec, 0x02, 0x0a, 0x0e,
-	0x50, 0x6f, 0x64, 0x53, 0x70, 0x65, 0x63, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d,
-	0x0a, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x50,
-	0x6f, 0x64, 0x53, 0x70, 0x65, 0x63, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e,
-	0x76, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x12, 0x33, 0x0a,
-	0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
-	0x50, 0x6f, 0x64, 0x53, 0x70, 0x65, 0x63, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4c,
-	0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65,
-	0x6c, 0x73, 0x12, 0x42, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x50, 0x6f, 0x64, 0x53, 0x70, 0x65,
-	0x63, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x45, 0x6e, 0x76, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
-	0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x41, 0x6e,
-	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x28, 0x0a, 0x16, 0x47, 0x65,
-	0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x02, 0x69, 0x64, 0x22, 0x62, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x45,
-	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x19, 0x0a, 0x08, 0x6a, 0x6f, 0x62, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6a, 0x6f, 0x62, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x4a, 0x6f,
-	0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x43, 0x0a, 0x0d, 0x4a, 0x6f, 0x62, 0x45,
-	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x32, 0x0a, 0x0d, 0x6a, 0x6f, 0x62,
-	0x5f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x0d, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x0c, 0x6a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xa7, 0x03,
-	0x0a, 0x0c, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19,
-	0x0a, 0x08, 0x6a, 0x6f, 0x62, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6a, 0x6f, 0x62, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x6a, 0x6f, 0x62,
-	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x4a, 0x6f,
-	0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07,
-	0x6a, 0x6f, 0x62, 0x54, 0x79, 0x70, 0x65, 0x12, 0x32, 0x0a, 0x0a, 0x6a, 0x6f, 0x62, 0x5f, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x4a, 0x6f,
+	0x53, 0x70, 0x65, 0x63, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6a,
+	0x6f, 0x62, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6a, 0x6f, 0x62, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x27, 0x0a, 0x0f,
+	0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x4b, 0x65, 0x79, 0x22, 0xec, 0x02, 0x0a, 0x0e, 0x50, 0x6f, 0x64, 0x53, 0x70, 0x65,
+	0x63, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x65, 0x6e, 0x76, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x50, 0x6f, 0x64, 0x53, 0x70, 0x65, 0x63,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e, 0x76, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x50, 0x6f, 0x64, 0x53, 0x70, 0x65,
+	0x63, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x42, 0x0a, 0x0b,
+	0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x50, 0x6f, 0x64, 0x53, 0x70, 0x65, 0x63, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x1a, 0x37, 0x0a, 0x09, 0x45, 0x6e, 0x76, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x28, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2b,
+	0x0a, 0x17, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x2e, 0x0a, 0x1c, 0x47,
+	0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2b, 0x0a, 0x19, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x6e, 0x0a, 0x11, 0x4a, 0x6f, 0x62, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e,
+	0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0xd8, 0x01, 0x0a, 0x18, 0x4c, 0x69, 0x73,
+	0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6a, 0x6f, 0x62, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6a, 0x6f, 0x62, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x13, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x65, 0x70, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x75, 0x6c, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70,
+	0x75, 0x6c, 0x6c, 0x22, 0x38, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x46, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6a, 0x6f, 0x62, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6a, 0x6f, 0x62, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xcf, 0x01,
+	0x0a, 0x11, 0x4a, 0x6f, 0x62, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x6a, 0x6f, 0x62, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6a, 0x6f, 0x62, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x31,
+	0x0a, 0x14, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x65, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x2b, 0x0a, 0x11, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x65, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x66, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3f,
+	0x0a, 0x0f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x4a, 0x6f, 0x62, 0x46, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52,
+	0x0e, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x22,
+	0x45, 0x0a, 0x15, 0x4a, 0x6f, 0x62, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x1f, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x54, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x49, 0x6e, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x6a, 0x0a, 0x13, 0x54, 0x65, 0x6e, 0x61, 0x6e,
+	0x74, 0x49, 0x6e, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3d, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x4a, 0x6f,
 	0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x09, 0x6a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x0a, 0x04, 0x72,
-	0x65, 0x66, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x52, 0x65, 0x66, 0x73,
-	0x52, 0x04, 0x72, 0x65, 0x66, 0x73, 0x12, 0x39, 0x0a, 0x10, 0x70, 0x6f, 0x64, 0x5f, 0x73, 0x70,
-	0x65, 0x63, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0f, 0x2e, 0x50, 0x6f, 0x64, 0x53, 0x70, 0x65, 0x63, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x52, 0x0e, 0x70, 0x6f, 0x64, 0x53, 0x70, 0x65, 0x63, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x63, 0x73, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x63, 0x73, 0x50, 0x61, 0x74, 0x68, 0x12, 0x3b, 0x0a, 0x0b,
-	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x43, 0x0a, 0x0f, 0x63, 0x6f, 0x6d,
-	0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e,
-	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x17,
-	0x0a, 0x07, 0x6a, 0x6f, 0x62, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x6a, 0x6f, 0x62, 0x55, 0x72, 0x6c, 0x22, 0x82, 0x03, 0x0a, 0x04, 0x52, 0x65, 0x66, 0x73,
-	0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f,
-	0x72, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x6c,
-	0x69, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x4c,
-	0x69, 0x6e, 0x6b, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x66, 0x12, 0x19,
-	0x0a, 0x08, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x73, 0x68, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x62, 0x61, 0x73, 0x65, 0x53, 0x68, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x61, 0x73,
-	0x65, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x61,
-	0x73, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x1b, 0x0a, 0x05, 0x70, 0x75, 0x6c, 0x6c, 0x73, 0x18,
-	0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x50, 0x75, 0x6c, 0x6c, 0x52, 0x05, 0x70, 0x75,
-	0x6c, 0x6c, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x61, 0x6c, 0x69, 0x61,
-	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x74, 0x68, 0x41, 0x6c, 0x69,
-	0x61, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x09,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x44, 0x69, 0x72, 0x12, 0x1b, 0x0a,
-	0x09, 0x63, 0x6c, 0x6f, 0x6e, 0x65, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x63, 0x6c, 0x6f, 0x6e, 0x65, 0x55, 0x72, 0x69, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x6b,
-	0x69, 0x70, 0x5f, 0x73, 0x75, 0x62, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x0b, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x6b, 0x69, 0x70, 0x53, 0x75, 0x62, 0x6d, 0x6f, 0x64, 0x75,
-	0x6c, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x6e, 0x65, 0x5f, 0x64, 0x65, 0x70,
-	0x74, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x63, 0x6c, 0x6f, 0x6e, 0x65, 0x44,
-	0x65, 0x70, 0x74, 0x68, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x66, 0x65, 0x74,
-	0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x73,
-	0x6b, 0x69, 0x70, 0x46, 0x65, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x22, 0xc6, 0x01, 0x0a,
-	0x04, 0x50, 0x75, 0x6c, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a,
-	0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61,
-	0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x68, 0x61, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x73, 0x68, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x10, 0x0a,
-	0x03, 0x72, 0x65, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12,
-	0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c,
-	0x69, 0x6e, 0x6b, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x6c, 0x69,
-	0x6e, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
-	0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x6c,
-	0x69, 0x6e, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x75, 0x74, 0x68, 0x6f,
-	0x72, 0x4c, 0x69, 0x6e, 0x6b, 0x22, 0xc1, 0x02, 0x0a, 0x1a, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f,
-	0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x11, 0x6a, 0x6f, 0x62, 0x5f, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x10, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x52, 0x0f, 0x6a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e,
-	0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x41, 0x0a, 0x0e,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12,
-	0x3f, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x22, 0x5c, 0x0a, 0x17, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2b,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x31, 0x0a, 0x14, 0x49, 0x73, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x69, 0x63, 0x44,
+	0x75, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6a, 0x6f, 0x62,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6a, 0x6f, 0x62,
+	0x4e, 0x61, 0x6d, 0x65, 0x22, 0x56, 0x0a, 0x0b, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x69, 0x63,
+	0x44, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x03, 0x64, 0x75, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x72, 0x75,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x07, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x75, 0x6e, 0x22, 0x50, 0x0a, 0x14,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x66, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x75,
+	0x6c, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x75, 0x6c, 0x6c, 0x22, 0x8d,
+	0x01, 0x0a, 0x0a, 0x52, 0x65, 0x66, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x61, 0x6c, 0x6c, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x6c, 0x6c, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x6e, 0x79, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x6e, 0x79, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12,
+	0x3d, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x22, 0x6e,
+	0x0a, 0x1a, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08,
+	0x6a, 0x6f, 0x62, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6a, 0x6f, 0x62, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x6c, 0x6f, 0x6f, 0x6b, 0x62,
+	0x61, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x6f, 0x6b, 0x62, 0x61, 0x63, 0x6b, 0x22, 0xd1,
+	0x01, 0x0a, 0x0d, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x19, 0x0a, 0x08, 0x6a, 0x6f, 0x62, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6a, 0x6f, 0x62, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x65,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x03, 0x70,
+	0x35, 0x30, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x03, 0x70, 0x35, 0x30, 0x12, 0x2b, 0x0a, 0x03, 0x70, 0x39, 0x30, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x03, 0x70, 0x39, 0x30, 0x12, 0x2b, 0x0a, 0x03, 0x70, 0x39, 0x39, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x70,
+	0x39, 0x39, 0x22, 0x8c, 0x01, 0x0a, 0x0d, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x32, 0x0a, 0x0d, 0x6a, 0x6f, 0x62, 0x5f, 0x65, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x4a, 0x6f,
+	0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x6a, 0x6f, 0x62, 0x45,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6e, 0x67, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x49, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78,
+	0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0xc4, 0x0c, 0x0a, 0x0c, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6a, 0x6f, 0x62, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6a, 0x6f, 0x62, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a,
+	0x08, 0x6a, 0x6f, 0x62, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x11, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x07, 0x6a, 0x6f, 0x62, 0x54, 0x79, 0x70, 0x65, 0x12, 0x32, 0x0a, 0x0a, 0x6a,
+	0x6f, 0x62, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x13, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x09, 0x6a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x19, 0x0a, 0x04, 0x72, 0x65, 0x66, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e,
+	0x52, 0x65, 0x66, 0x73, 0x52, 0x04, 0x72, 0x65, 0x66, 0x73, 0x12, 0x39, 0x0a, 0x10, 0x70, 0x6f,
+	0x64, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x50, 0x6f, 0x64, 0x53, 0x70, 0x65, 0x63, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0e, 0x70, 0x6f, 0x64, 0x53, 0x70, 0x65, 0x63, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x63, 0x73, 0x5f, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x63, 0x73, 0x50, 0x61, 0x74, 0x68,
+	0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x43, 0x0a,
+	0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72,
-	0x12, 0x2c, 0x0a, 0x08, 0x6a, 0x6f, 0x62, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x11, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
-	0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x6a, 0x6f, 0x62, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19,
-	0x0a, 0x04, 0x72, 0x65, 0x66, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x52,
-	0x65, 0x66, 0x73, 0x52, 0x04, 0x72, 0x65, 0x66, 0x73, 0x22, 0x6f, 0x0a, 0x0f, 0x4a, 0x6f, 0x62,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x07,
-	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e,
-	0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x07, 0x64,
-	0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x4a,
-	0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x07, 0x64, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x2a, 0x98, 0x01, 0x0a, 0x12, 0x4a,
-	0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x24, 0x0a, 0x20, 0x4a, 0x4f, 0x42, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x49,
-	0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
-	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x52, 0x49, 0x47, 0x47,
-	0x45, 0x52, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e,
-	0x47, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x03,
-	0x12, 0x0b, 0x0a, 0x07, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x10, 0x04, 0x12, 0x0b, 0x0a,
-	0x07, 0x41, 0x42, 0x4f, 0x52, 0x54, 0x45, 0x44, 0x10, 0x05, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52,
-	0x52, 0x4f, 0x52, 0x10, 0x06, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x43, 0x48, 0x45, 0x44, 0x55, 0x4c,
-	0x49, 0x4e, 0x47, 0x10, 0x07, 0x2a, 0x6e, 0x0a, 0x10, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63,
-	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x1e, 0x4a, 0x4f, 0x42,
-	0x5f, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
-	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a,
-	0x08, 0x50, 0x45, 0x52, 0x49, 0x4f, 0x44, 0x49, 0x43, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x50,
-	0x4f, 0x53, 0x54, 0x53, 0x55, 0x42, 0x4d, 0x49, 0x54, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x50,
-	0x52, 0x45, 0x53, 0x55, 0x42, 0x4d, 0x49, 0x54, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x41,
-	0x54, 0x43, 0x48, 0x10, 0x04, 0x32, 0x95, 0x03, 0x0a, 0x04, 0x50, 0x72, 0x6f, 0x77, 0x12, 0x62,
-	0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62,
-	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x0d, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22,
-	0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x3a, 0x01, 0x2a, 0x42, 0x16, 0x0a, 0x04, 0x50, 0x4f,
-	0x53, 0x54, 0x12, 0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x12, 0x56, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63,
-	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78,
-	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d,
-	0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x1b, 0x82,
-	0xd3, 0xe4, 0x93, 0x02, 0x15, 0x12, 0x13, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x56, 0x0a, 0x11, 0x4c, 0x69,
-	0x73, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
-	0x19, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x4a, 0x6f, 0x62,
-	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x10, 0x12, 0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x12, 0x79, 0x0a, 0x13, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1b, 0x2e, 0x42, 0x75, 0x6c, 0x6b,
+	0x6d, 0x70, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6a, 0x6f, 0x62, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6a, 0x6f, 0x62, 0x55, 0x72, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x6c, 0x6f, 0x6e, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x6c, 0x6f, 0x6e, 0x65, 0x55, 0x72, 0x6c, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e,
+	0x66, 0x72, 0x61, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0c, 0x69, 0x6e, 0x66, 0x72, 0x61, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x6b, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x64, 0x65, 0x63, 0x6b, 0x55, 0x72, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65,
+	0x63, 0x6f, 0x72, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x64,
+	0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e,
+	0x69, 0x6e, 0x67, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6e,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x72, 0x75, 0x6e, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x72,
+	0x75, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x28, 0x0a, 0x03, 0x65, 0x6e, 0x76,
+	0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03,
+	0x65, 0x6e, 0x76, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12,
+	0x44, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x5f, 0x72,
+	0x65, 0x70, 0x6f, 0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x72, 0x6f, 0x73, 0x73,
+	0x52, 0x65, 0x70, 0x6f, 0x12, 0x24, 0x0a, 0x0a, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x72, 0x65,
+	0x66, 0x73, 0x18, 0x16, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x52, 0x65, 0x66, 0x73, 0x52,
+	0x09, 0x65, 0x78, 0x74, 0x72, 0x61, 0x52, 0x65, 0x66, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x75,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x6f, 0x66, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x4f, 0x66, 0x12, 0x27, 0x0a,
+	0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x18, 0x18, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2c, 0x0a, 0x12, 0x6a, 0x6f, 0x62, 0x5f, 0x71, 0x75,
+	0x65, 0x75, 0x65, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x19, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x10, 0x6a, 0x6f, 0x62, 0x51, 0x75, 0x65, 0x75, 0x65, 0x43, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x1b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65,
+	0x64, 0x12, 0x2f, 0x0a, 0x13, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12,
+	0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x63, 0x73, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x18, 0x1d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x63, 0x73, 0x50, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x12, 0x41, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x6c, 0x69, 0x6e, 0x6b,
+	0x73, 0x18, 0x1e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x6e,
+	0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4c,
+	0x69, 0x6e, 0x6b, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18,
+	0x1f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x20, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72,
+	0x67, 0x73, 0x12, 0x3f, 0x0a, 0x12, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x21, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x70, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65,
+	0x64, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x22, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10,
+	0x70, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x64, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x12, 0x3a, 0x0a, 0x19, 0x70, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x64, 0x5f, 0x61,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x18, 0x23, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x17, 0x70, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x64, 0x41,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x0e,
+	0x61, 0x68, 0x65, 0x61, 0x64, 0x5f, 0x69, 0x6e, 0x5f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x18, 0x24,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x61, 0x68, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x51, 0x75, 0x65,
+	0x75, 0x65, 0x12, 0x1c, 0x0a, 0x05, 0x61, 0x75, 0x64, 0x69, 0x74, 0x18, 0x25, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x06, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x52, 0x05, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x1a, 0x36, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xbf, 0x01, 0x0a, 0x05, 0x41, 0x75, 0x64,
+	0x69, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x73, 0x75,
+	0x6d, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x75,
+	0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f,
+	0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x3b, 0x0a,
+	0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x76, 0x0a, 0x0f, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x12, 0x23, 0x0a,
+	0x0d, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x93, 0x04, 0x0a, 0x04, 0x52, 0x65, 0x66, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6f,
+	0x72, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70,
+	0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x19,
+	0x0a, 0x08, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x66, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x73,
+	0x65, 0x5f, 0x73, 0x68, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x73,
+	0x65, 0x53, 0x68, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x6c, 0x69, 0x6e,
+	0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x61, 0x73, 0x65, 0x4c, 0x69, 0x6e,
+	0x6b, 0x12, 0x1b, 0x0a, 0x05, 0x70, 0x75, 0x6c, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x05, 0x2e, 0x50, 0x75, 0x6c, 0x6c, 0x52, 0x05, 0x70, 0x75, 0x6c, 0x6c, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x74, 0x68, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x19, 0x0a,
+	0x08, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x77, 0x6f, 0x72, 0x6b, 0x44, 0x69, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x6f, 0x6e,
+	0x65, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x6f,
+	0x6e, 0x65, 0x55, 0x72, 0x69, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x73, 0x75,
+	0x62, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e,
+	0x73, 0x6b, 0x69, 0x70, 0x53, 0x75, 0x62, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1f,
+	0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x6e, 0x65, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0a, 0x63, 0x6c, 0x6f, 0x6e, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12,
+	0x26, 0x0a, 0x0f, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x68, 0x65,
+	0x61, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x46, 0x65,
+	0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x78, 0x69, 0x6c,
+	0x69, 0x61, 0x72, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x75, 0x78, 0x69,
+	0x6c, 0x69, 0x61, 0x72, 0x79, 0x12, 0x2a, 0x0a, 0x0e, 0x62, 0x6c, 0x6f, 0x62, 0x6c, 0x65, 0x73,
+	0x73, 0x5f, 0x66, 0x65, 0x74, 0x63, 0x68, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52,
+	0x0d, 0x62, 0x6c, 0x6f, 0x62, 0x6c, 0x65, 0x73, 0x73, 0x46, 0x65, 0x74, 0x63, 0x68, 0x88, 0x01,
+	0x01, 0x12, 0x32, 0x0a, 0x15, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x5f, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x6f, 0x75, 0x74, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x13, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74,
+	0x46, 0x69, 0x6c, 0x65, 0x73, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x62, 0x6c, 0x6f, 0x62, 0x6c, 0x65,
+	0x73, 0x73, 0x5f, 0x66, 0x65, 0x74, 0x63, 0x68, 0x22, 0xfe, 0x01, 0x0a, 0x04, 0x50, 0x75, 0x6c,
+	0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x68, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x73, 0x68, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x66,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6c,
+	0x69, 0x6e, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x12,
+	0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x4c, 0x69, 0x6e, 0x6b,
+	0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x4c, 0x69, 0x6e,
+	0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x64, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x19,
+	0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x52, 0x65, 0x66, 0x22, 0xd1, 0x02, 0x0a, 0x1a, 0x42, 0x75,
+	0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x11, 0x6a, 0x6f, 0x62, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0f, 0x6a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x12, 0x41, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x65, 0x66, 0x6f,
+	0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x42, 0x65, 0x66,
+	0x6f, 0x72, 0x65, 0x12, 0x3f, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x66, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41,
+	0x66, 0x74, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x08, 0x6a, 0x6f, 0x62, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x6a, 0x6f, 0x62, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x19, 0x0a, 0x04, 0x72, 0x65, 0x66, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x05, 0x2e, 0x52, 0x65, 0x66, 0x73, 0x52, 0x04, 0x72, 0x65, 0x66, 0x73, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2f, 0x0a,
+	0x1d, 0x47, 0x65, 0x74, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xc4,
+	0x01, 0x0a, 0x1b, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2f,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e,
+	0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x66,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x22, 0x6f, 0x0a, 0x0f, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x4a, 0x6f, 0x62, 0x45,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x07,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x07, 0x64, 0x65, 0x73, 0x69, 0x72,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x07, 0x64,
+	0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x2a, 0x98, 0x01, 0x0a, 0x12, 0x4a, 0x6f, 0x62, 0x45, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x24, 0x0a,
+	0x20, 0x4a, 0x4f, 0x42, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x52, 0x49, 0x47, 0x47, 0x45, 0x52, 0x45, 0x44,
+	0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12,
+	0x0b, 0x0a, 0x07, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07,
+	0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x10, 0x04, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x42, 0x4f,
+	0x52, 0x54, 0x45, 0x44, 0x10, 0x05, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10,
+	0x06, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x43, 0x48, 0x45, 0x44, 0x55, 0x4c, 0x49, 0x4e, 0x47, 0x10,
+	0x07, 0x2a, 0x6e, 0x0a, 0x10, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x1e, 0x4a, 0x4f, 0x42, 0x5f, 0x45, 0x58, 0x45,
+	0x43, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x45, 0x52,
+	0x49, 0x4f, 0x44, 0x49, 0x43, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x4f, 0x53, 0x54, 0x53,
+	0x55, 0x42, 0x4d, 0x49, 0x54, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x52, 0x45, 0x53, 0x55,
+	0x42, 0x4d, 0x49, 0x54, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x41, 0x54, 0x43, 0x48, 0x10,
+	0x04, 0x2a, 0xb5, 0x01, 0x0a, 0x18, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2c,
+	0x0a, 0x28, 0x42, 0x55, 0x4c, 0x4b, 0x5f, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x22, 0x0a, 0x1e,
+	0x42, 0x55, 0x4c, 0x4b, 0x5f, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x01,
+	0x12, 0x24, 0x0a, 0x20, 0x42, 0x55, 0x4c, 0x4b, 0x5f, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x55, 0x53, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x53, 0x55, 0x43, 0x43, 0x45,
+	0x45, 0x44, 0x45, 0x44, 0x10, 0x02, 0x12, 0x21, 0x0a, 0x1d, 0x42, 0x55, 0x4c, 0x4b, 0x5f, 0x4a,
+	0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45,
+	0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x32, 0xbf, 0x0b, 0x0a, 0x04, 0x50, 0x72,
+	0x6f, 0x77, 0x12, 0x62, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x45,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x3a, 0x01, 0x2a, 0x42, 0x16,
+	0x0a, 0x04, 0x50, 0x4f, 0x53, 0x54, 0x12, 0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x56, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62,
+	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x4a,
+	0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x12, 0x13, 0x2f, 0x76, 0x31, 0x2f, 0x65,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x5d,
+	0x0a, 0x10, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x18, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x4a,
+	0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x1f, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x3a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x12, 0x6e, 0x0a,
+	0x15, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x1c, 0x12, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x68, 0x0a,
+	0x12, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x45,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x22, 0x1a, 0x2f, 0x76, 0x31,
+	0x2f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d,
+	0x3a, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x56, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x4a,
+	0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x12,
+	0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x60, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62,
+	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x0d, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x3a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x30,
+	0x01, 0x12, 0x79, 0x0a, 0x13, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1b, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x4a,
+	0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x2d, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x27, 0x3a, 0x01, 0x2a, 0x42, 0x22, 0x0a, 0x04, 0x50, 0x4f, 0x53, 0x54,
+	0x12, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x75, 0x6c, 0x6b, 0x2d, 0x6a, 0x6f, 0x62, 0x2d, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x2d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x7f, 0x0a, 0x16,
+	0x47, 0x65, 0x74, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1e, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x6c, 0x6b,
 	0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x2d,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x3a, 0x01, 0x2a, 0x42, 0x22, 0x0a, 0x04, 0x50, 0x4f, 0x53,
-	0x54, 0x12, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x75, 0x6c, 0x6b, 0x2d, 0x6a, 0x6f, 0x62, 0x2d,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x1e, 0x5a,
-	0x1c, 0x73, 0x69, 0x67, 0x73, 0x2e, 0x6b, 0x38, 0x73, 0x2e, 0x69, 0x6f, 0x2f, 0x70, 0x72, 0x6f,
-	0x77, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x61, 0x6e, 0x67, 0x77, 0x61, 0x79, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21, 0x12, 0x1f, 0x2f, 0x76,
+	0x31, 0x2f, 0x62, 0x75, 0x6c, 0x6b, 0x2d, 0x6a, 0x6f, 0x62, 0x2d, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x2d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x70, 0x0a,
+	0x14, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x53, 0x75,
+	0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1c, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x46, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x4a, 0x6f, 0x62, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x22, 0x26, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x20, 0x12,
+	0x1e, 0x2f, 0x76, 0x31, 0x2f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x2d, 0x73, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x2f, 0x7b, 0x6a, 0x6f, 0x62, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x12,
+	0x6b, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x6e, 0x46, 0x6c,
+	0x69, 0x67, 0x68, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x2e, 0x47, 0x65, 0x74, 0x54,
+	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x6e, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x54, 0x65, 0x6e, 0x61,
+	0x6e, 0x74, 0x49, 0x6e, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x12, 0x13, 0x2f, 0x76, 0x31, 0x2f, 0x69, 0x6e, 0x2d,
+	0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2d, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x5a, 0x0a, 0x0d,
+	0x49, 0x73, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x69, 0x63, 0x44, 0x75, 0x65, 0x12, 0x15, 0x2e,
+	0x49, 0x73, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x69, 0x63, 0x44, 0x75, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x69, 0x63, 0x44,
+	0x75, 0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x12, 0x1c, 0x2f, 0x76, 0x31, 0x2f,
+	0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x69, 0x63, 0x73, 0x2f, 0x7b, 0x6a, 0x6f, 0x62, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x7d, 0x2f, 0x64, 0x75, 0x65, 0x12, 0x66, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x66, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x15, 0x2e, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x66, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0b, 0x2e, 0x52, 0x65, 0x66, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x31, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x2b, 0x12, 0x29, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x66, 0x73, 0x2f,
+	0x7b, 0x6f, 0x72, 0x67, 0x7d, 0x2f, 0x7b, 0x72, 0x65, 0x70, 0x6f, 0x7d, 0x2f, 0x70, 0x75, 0x6c,
+	0x6c, 0x73, 0x2f, 0x7b, 0x70, 0x75, 0x6c, 0x6c, 0x7d, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x69, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1b, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x12, 0x1d, 0x2f, 0x76,
+	0x31, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2d, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x2f, 0x7b, 0x6a, 0x6f, 0x62, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x42, 0x1e, 0x5a, 0x1c, 0x73,
+	0x69, 0x67, 0x73, 0x2e, 0x6b, 0x38, 0x73, 0x2e, 0x69, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x77, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x67, 0x61, 0x6e, 0x67, 0x77, 0x61, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1151,63 +3164,126 @@ func file_gangway_proto_rawDescGZIP() []byte {
 	return file_gangway_proto_rawDescData
 }
 
-var file_gangway_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_gangway_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_gangway_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_gangway_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_gangway_proto_goTypes = []interface{}{
-	(JobExecutionStatus)(0),            // 0: JobExecutionStatus
-	(JobExecutionType)(0),              // 1: JobExecutionType
-	(*CreateJobExecutionRequest)(nil),  // 2: CreateJobExecutionRequest
-	(*PodSpecOptions)(nil),             // 3: PodSpecOptions
-	(*GetJobExecutionRequest)(nil),     // 4: GetJobExecutionRequest
-	(*ListJobExecutionsRequest)(nil),   // 5: ListJobExecutionsRequest
-	(*JobExecutions)(nil),              // 6: JobExecutions
-	(*JobExecution)(nil),               // 7: JobExecution
-	(*Refs)(nil),                       // 8: Refs
-	(*Pull)(nil),                       // 9: Pull
-	(*BulkJobStatusChangeRequest)(nil), // 10: BulkJobStatusChangeRequest
-	(*JobStatusChange)(nil),            // 11: JobStatusChange
-	nil,                                // 12: PodSpecOptions.EnvsEntry
-	nil,                                // 13: PodSpecOptions.LabelsEntry
-	nil,                                // 14: PodSpecOptions.AnnotationsEntry
-	(*timestamppb.Timestamp)(nil),      // 15: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),              // 16: google.protobuf.Empty
+	(JobExecutionStatus)(0),               // 0: JobExecutionStatus
+	(JobExecutionType)(0),                 // 1: JobExecutionType
+	(BulkJobStatusChangeState)(0),         // 2: BulkJobStatusChangeState
+	(*CreateJobExecutionRequest)(nil),     // 3: CreateJobExecutionRequest
+	(*PodSpecOptions)(nil),                // 4: PodSpecOptions
+	(*GetJobExecutionRequest)(nil),        // 5: GetJobExecutionRequest
+	(*GetJobExecutionsRequest)(nil),       // 6: GetJobExecutionsRequest
+	(*GetJobExecutionStatusRequest)(nil),  // 7: GetJobExecutionStatusRequest
+	(*CancelJobExecutionRequest)(nil),     // 8: CancelJobExecutionRequest
+	(*JobStatusResponse)(nil),             // 9: JobStatusResponse
+	(*ListJobExecutionsRequest)(nil),      // 10: ListJobExecutionsRequest
+	(*GetJobFailureSummaryRequest)(nil),   // 11: GetJobFailureSummaryRequest
+	(*JobFailureSummary)(nil),             // 12: JobFailureSummary
+	(*JobFailureReasonCount)(nil),         // 13: JobFailureReasonCount
+	(*GetTenantInFlightCountRequest)(nil), // 14: GetTenantInFlightCountRequest
+	(*TenantInFlightCount)(nil),           // 15: TenantInFlightCount
+	(*JobExecutionStatusCount)(nil),       // 16: JobExecutionStatusCount
+	(*IsPeriodicDueRequest)(nil),          // 17: IsPeriodicDueRequest
+	(*PeriodicDue)(nil),                   // 18: PeriodicDue
+	(*GetRefsStatusRequest)(nil),          // 19: GetRefsStatusRequest
+	(*RefsStatus)(nil),                    // 20: RefsStatus
+	(*GetJobDurationStatsRequest)(nil),    // 21: GetJobDurationStatsRequest
+	(*DurationStats)(nil),                 // 22: DurationStats
+	(*JobExecutions)(nil),                 // 23: JobExecutions
+	(*JobExecution)(nil),                  // 24: JobExecution
+	(*Audit)(nil),                         // 25: Audit
+	(*ContainerStatus)(nil),               // 26: ContainerStatus
+	(*Refs)(nil),                          // 27: Refs
+	(*Pull)(nil),                          // 28: Pull
+	(*BulkJobStatusChangeRequest)(nil),    // 29: BulkJobStatusChangeRequest
+	(*GetBulkJobStatusChangeRequest)(nil), // 30: GetBulkJobStatusChangeRequest
+	(*BulkJobStatusChangeProgress)(nil),   // 31: BulkJobStatusChangeProgress
+	(*JobStatusChange)(nil),               // 32: JobStatusChange
+	nil,                                   // 33: PodSpecOptions.EnvsEntry
+	nil,                                   // 34: PodSpecOptions.LabelsEntry
+	nil,                                   // 35: PodSpecOptions.AnnotationsEntry
+	nil,                                   // 36: JobExecution.EnvEntry
+	nil,                                   // 37: JobExecution.ReportLinksEntry
+	(*timestamppb.Timestamp)(nil),         // 38: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),           // 39: google.protobuf.Duration
+	(*emptypb.Empty)(nil),                 // 40: google.protobuf.Empty
 }
 var file_gangway_proto_depIdxs = []int32{
 	1,  // 0: CreateJobExecutionRequest.job_execution_type:type_name -> JobExecutionType
-	8,  // 1: CreateJobExecutionRequest.refs:type_name -> Refs
-	3,  // 2: CreateJobExecutionRequest.pod_spec_options:type_name -> PodSpecOptions
-	12, // 3: PodSpecOptions.envs:type_name -> PodSpecOptions.EnvsEntry
-	13, // 4: PodSpecOptions.labels:type_name -> PodSpecOptions.LabelsEntry
-	14, // 5: PodSpecOptions.annotations:type_name -> PodSpecOptions.AnnotationsEntry
-	0,  // 6: ListJobExecutionsRequest.status:type_name -> JobExecutionStatus
-	7,  // 7: JobExecutions.job_execution:type_name -> JobExecution
-	1,  // 8: JobExecution.job_type:type_name -> JobExecutionType
-	0,  // 9: JobExecution.job_status:type_name -> JobExecutionStatus
-	8,  // 10: JobExecution.refs:type_name -> Refs
-	3,  // 11: JobExecution.pod_spec_options:type_name -> PodSpecOptions
-	15, // 12: JobExecution.create_time:type_name -> google.protobuf.Timestamp
-	15, // 13: JobExecution.completion_time:type_name -> google.protobuf.Timestamp
-	9,  // 14: Refs.pulls:type_name -> Pull
-	11, // 15: BulkJobStatusChangeRequest.job_status_change:type_name -> JobStatusChange
-	15, // 16: BulkJobStatusChangeRequest.started_before:type_name -> google.protobuf.Timestamp
-	15, // 17: BulkJobStatusChangeRequest.started_after:type_name -> google.protobuf.Timestamp
-	1,  // 18: BulkJobStatusChangeRequest.job_type:type_name -> JobExecutionType
-	8,  // 19: BulkJobStatusChangeRequest.refs:type_name -> Refs
-	0,  // 20: JobStatusChange.current:type_name -> JobExecutionStatus
-	0,  // 21: JobStatusChange.desired:type_name -> JobExecutionStatus
-	2,  // 22: Prow.CreateJobExecution:input_type -> CreateJobExecutionRequest
-	4,  // 23: Prow.GetJobExecution:input_type -> GetJobExecutionRequest
-	5,  // 24: Prow.ListJobExecutions:input_type -> ListJobExecutionsRequest
-	10, // 25: Prow.BulkJobStatusChange:input_type -> BulkJobStatusChangeRequest
-	7,  // 26: Prow.CreateJobExecution:output_type -> JobExecution
-	7,  // 27: Prow.GetJobExecution:output_type -> JobExecution
-	6,  // 28: Prow.ListJobExecutions:output_type -> JobExecutions
-	16, // 29: Prow.BulkJobStatusChange:output_type -> google.protobuf.Empty
-	26, // [26:30] is the sub-list for method output_type
-	22, // [22:26] is the sub-list for method input_type
-	22, // [22:22] is the sub-list for extension type_name
-	22, // [22:22] is the sub-list for extension extendee
-	0,  // [0:22] is the sub-list for field type_name
+	27, // 1: CreateJobExecutionRequest.refs:type_name -> Refs
+	4,  // 2: CreateJobExecutionRequest.pod_spec_options:type_name -> PodSpecOptions
+	33, // 3: PodSpecOptions.envs:type_name -> PodSpecOptions.EnvsEntry
+	34, // 4: PodSpecOptions.labels:type_name -> PodSpecOptions.LabelsEntry
+	35, // 5: PodSpecOptions.annotations:type_name -> PodSpecOptions.AnnotationsEntry
+	0,  // 6: JobStatusResponse.status:type_name -> JobExecutionStatus
+	0,  // 7: ListJobExecutionsRequest.status:type_name -> JobExecutionStatus
+	13, // 8: JobFailureSummary.failure_reasons:type_name -> JobFailureReasonCount
+	16, // 9: TenantInFlightCount.status_counts:type_name -> JobExecutionStatusCount
+	0,  // 10: JobExecutionStatusCount.status:type_name -> JobExecutionStatus
+	38, // 11: PeriodicDue.next_run:type_name -> google.protobuf.Timestamp
+	16, // 12: RefsStatus.status_counts:type_name -> JobExecutionStatusCount
+	39, // 13: GetJobDurationStatsRequest.lookback:type_name -> google.protobuf.Duration
+	39, // 14: DurationStats.p50:type_name -> google.protobuf.Duration
+	39, // 15: DurationStats.p90:type_name -> google.protobuf.Duration
+	39, // 16: DurationStats.p99:type_name -> google.protobuf.Duration
+	24, // 17: JobExecutions.job_execution:type_name -> JobExecution
+	1,  // 18: JobExecution.job_type:type_name -> JobExecutionType
+	0,  // 19: JobExecution.job_status:type_name -> JobExecutionStatus
+	27, // 20: JobExecution.refs:type_name -> Refs
+	4,  // 21: JobExecution.pod_spec_options:type_name -> PodSpecOptions
+	38, // 22: JobExecution.create_time:type_name -> google.protobuf.Timestamp
+	38, // 23: JobExecution.completion_time:type_name -> google.protobuf.Timestamp
+	36, // 24: JobExecution.env:type_name -> JobExecution.EnvEntry
+	38, // 25: JobExecution.last_update_time:type_name -> google.protobuf.Timestamp
+	27, // 26: JobExecution.extra_refs:type_name -> Refs
+	38, // 27: JobExecution.submit_time:type_name -> google.protobuf.Timestamp
+	37, // 28: JobExecution.report_links:type_name -> JobExecution.ReportLinksEntry
+	26, // 29: JobExecution.container_statuses:type_name -> ContainerStatus
+	25, // 30: JobExecution.audit:type_name -> Audit
+	38, // 31: Audit.create_time:type_name -> google.protobuf.Timestamp
+	28, // 32: Refs.pulls:type_name -> Pull
+	32, // 33: BulkJobStatusChangeRequest.job_status_change:type_name -> JobStatusChange
+	38, // 34: BulkJobStatusChangeRequest.started_before:type_name -> google.protobuf.Timestamp
+	38, // 35: BulkJobStatusChangeRequest.started_after:type_name -> google.protobuf.Timestamp
+	1,  // 36: BulkJobStatusChangeRequest.job_type:type_name -> JobExecutionType
+	27, // 37: BulkJobStatusChangeRequest.refs:type_name -> Refs
+	2,  // 38: BulkJobStatusChangeProgress.state:type_name -> BulkJobStatusChangeState
+	0,  // 39: JobStatusChange.current:type_name -> JobExecutionStatus
+	0,  // 40: JobStatusChange.desired:type_name -> JobExecutionStatus
+	3,  // 41: Prow.CreateJobExecution:input_type -> CreateJobExecutionRequest
+	5,  // 42: Prow.GetJobExecution:input_type -> GetJobExecutionRequest
+	6,  // 43: Prow.GetJobExecutions:input_type -> GetJobExecutionsRequest
+	7,  // 44: Prow.GetJobExecutionStatus:input_type -> GetJobExecutionStatusRequest
+	8,  // 45: Prow.CancelJobExecution:input_type -> CancelJobExecutionRequest
+	10, // 46: Prow.ListJobExecutions:input_type -> ListJobExecutionsRequest
+	10, // 47: Prow.StreamJobExecutions:input_type -> ListJobExecutionsRequest
+	29, // 48: Prow.BulkJobStatusChange:input_type -> BulkJobStatusChangeRequest
+	30, // 49: Prow.GetBulkJobStatusChange:input_type -> GetBulkJobStatusChangeRequest
+	11, // 50: Prow.GetJobFailureSummary:input_type -> GetJobFailureSummaryRequest
+	14, // 51: Prow.GetTenantInFlightCount:input_type -> GetTenantInFlightCountRequest
+	17, // 52: Prow.IsPeriodicDue:input_type -> IsPeriodicDueRequest
+	19, // 53: Prow.GetRefsStatus:input_type -> GetRefsStatusRequest
+	21, // 54: Prow.GetJobDurationStats:input_type -> GetJobDurationStatsRequest
+	24, // 55: Prow.CreateJobExecution:output_type -> JobExecution
+	24, // 56: Prow.GetJobExecution:output_type -> JobExecution
+	23, // 57: Prow.GetJobExecutions:output_type -> JobExecutions
+	9,  // 58: Prow.GetJobExecutionStatus:output_type -> JobStatusResponse
+	9,  // 59: Prow.CancelJobExecution:output_type -> JobStatusResponse
+	23, // 60: Prow.ListJobExecutions:output_type -> JobExecutions
+	24, // 61: Prow.StreamJobExecutions:output_type -> JobExecution
+	40, // 62: Prow.BulkJobStatusChange:output_type -> google.protobuf.Empty
+	31, // 63: Prow.GetBulkJobStatusChange:output_type -> BulkJobStatusChangeProgress
+	12, // 64: Prow.GetJobFailureSummary:output_type -> JobFailureSummary
+	15, // 65: Prow.GetTenantInFlightCount:output_type -> TenantInFlightCount
+	18, // 66: Prow.IsPeriodicDue:output_type -> PeriodicDue
+	20, // 67: Prow.GetRefsStatus:output_type -> RefsStatus
+	22, // 68: Prow.GetJobDurationStats:output_type -> DurationStats
+	55, // [55:69] is the sub-list for method output_type
+	41, // [41:55] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
 }
 
 func init() { file_gangway_proto_init() }
@@ -1253,7 +3329,7 @@ func file_gangway_proto_init() {
 			}
 		}
 		file_gangway_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListJobExecutionsRequest); i {
+			switch v := v.(*GetJobExecutionsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1265,7 +3341,7 @@ func file_gangway_proto_init() {
 			}
 		}
 		file_gangway_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobExecutions); i {
+			switch v := v.(*GetJobExecutionStatusRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1277,7 +3353,7 @@ func file_gangway_proto_init() {
 			}
 		}
 		file_gangway_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobExecution); i {
+			switch v := v.(*CancelJobExecutionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1289,7 +3365,7 @@ func file_gangway_proto_init() {
 			}
 		}
 		file_gangway_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Refs); i {
+			switch v := v.(*JobStatusResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1301,7 +3377,7 @@ func file_gangway_proto_init() {
 			}
 		}
 		file_gangway_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Pull); i {
+			switch v := v.(*ListJobExecutionsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1313,7 +3389,7 @@ func file_gangway_proto_init() {
 			}
 		}
 		file_gangway_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BulkJobStatusChangeRequest); i {
+			switch v := v.(*GetJobFailureSummaryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1325,6 +3401,246 @@ func file_gangway_proto_init() {
 			}
 		}
 		file_gangway_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobFailureSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobFailureReasonCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTenantInFlightCountRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TenantInFlightCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobExecutionStatusCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IsPeriodicDueRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeriodicDue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRefsStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefsStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobDurationStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DurationStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobExecutions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobExecution); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Audit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContainerStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Refs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pull); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkJobStatusChangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBulkJobStatusChangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkJobStatusChangeProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*JobStatusChange); i {
 			case 0:
 				return &v.state
@@ -1337,13 +3653,14 @@ func file_gangway_proto_init() {
 			}
 		}
 	}
+	file_gangway_proto_msgTypes[24].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_gangway_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   13,
+			NumEnums:      3,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
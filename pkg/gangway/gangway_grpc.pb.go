@@ -20,10 +20,20 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Prow_CreateJobExecution_FullMethodName  = "/Prow/CreateJobExecution"
-	Prow_GetJobExecution_FullMethodName     = "/Prow/GetJobExecution"
-	Prow_ListJobExecutions_FullMethodName   = "/Prow/ListJobExecutions"
-	Prow_BulkJobStatusChange_FullMethodName = "/Prow/BulkJobStatusChange"
+	Prow_CreateJobExecution_FullMethodName     = "/Prow/CreateJobExecution"
+	Prow_GetJobExecution_FullMethodName        = "/Prow/GetJobExecution"
+	Prow_GetJobExecutions_FullMethodName       = "/Prow/GetJobExecutions"
+	Prow_GetJobExecutionStatus_FullMethodName  = "/Prow/GetJobExecutionStatus"
+	Prow_CancelJobExecution_FullMethodName     = "/Prow/CancelJobExecution"
+	Prow_ListJobExecutions_FullMethodName      = "/Prow/ListJobExecutions"
+	Prow_StreamJobExecutions_FullMethodName    = "/Prow/StreamJobExecutions"
+	Prow_BulkJobStatusChange_FullMethodName    = "/Prow/BulkJobStatusChange"
+	Prow_GetBulkJobStatusChange_FullMethodName = "/Prow/GetBulkJobStatusChange"
+	Prow_GetJobFailureSummary_FullMethodName   = "/Prow/GetJobFailureSummary"
+	Prow_GetTenantInFlightCount_FullMethodName = "/Prow/GetTenantInFlightCount"
+	Prow_IsPeriodicDue_FullMethodName          = "/Prow/IsPeriodicDue"
+	Prow_GetRefsStatus_FullMethodName          = "/Prow/GetRefsStatus"
+	Prow_GetJobDurationStats_FullMethodName    = "/Prow/GetJobDurationStats"
 )
 
 // ProwClient is the client API for Prow service.
@@ -37,8 +47,26 @@ type ProwClient interface {
 	// https://docs.google.com/document/d/1v77jp1Nb5C2C2-PdV02SGViO9CyZ9SvNxCPOHyIUQeo/edit#bookmark=id.q68srxklvpt4.
 	CreateJobExecution(ctx context.Context, in *CreateJobExecutionRequest, opts ...grpc.CallOption) (*JobExecution, error)
 	GetJobExecution(ctx context.Context, in *GetJobExecutionRequest, opts ...grpc.CallOption) (*JobExecution, error)
+	GetJobExecutions(ctx context.Context, in *GetJobExecutionsRequest, opts ...grpc.CallOption) (*JobExecutions, error)
+	GetJobExecutionStatus(ctx context.Context, in *GetJobExecutionStatusRequest, opts ...grpc.CallOption) (*JobStatusResponse, error)
+	CancelJobExecution(ctx context.Context, in *CancelJobExecutionRequest, opts ...grpc.CallOption) (*JobStatusResponse, error)
 	ListJobExecutions(ctx context.Context, in *ListJobExecutionsRequest, opts ...grpc.CallOption) (*JobExecutions, error)
+	// StreamJobExecutions is like ListJobExecutions, but sends the job
+	// executions one by one while they are listed instead of all at once.
+	StreamJobExecutions(ctx context.Context, in *ListJobExecutionsRequest, opts ...grpc.CallOption) (Prow_StreamJobExecutionsClient, error)
 	BulkJobStatusChange(ctx context.Context, in *BulkJobStatusChangeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetBulkJobStatusChange returns the progress of a BulkJobStatusChange
+	// request with a correlation id. The progress is only kept in memory, for
+	// an hour after the change finished and for at most 1000 changes. Once
+	// gangway has 1000 changes, the ones that finished first are forgotten to
+	// make room for new ones, and new changes with a correlation id are
+	// rejected while all 1000 are running.
+	GetBulkJobStatusChange(ctx context.Context, in *GetBulkJobStatusChangeRequest, opts ...grpc.CallOption) (*BulkJobStatusChangeProgress, error)
+	GetJobFailureSummary(ctx context.Context, in *GetJobFailureSummaryRequest, opts ...grpc.CallOption) (*JobFailureSummary, error)
+	GetTenantInFlightCount(ctx context.Context, in *GetTenantInFlightCountRequest, opts ...grpc.CallOption) (*TenantInFlightCount, error)
+	IsPeriodicDue(ctx context.Context, in *IsPeriodicDueRequest, opts ...grpc.CallOption) (*PeriodicDue, error)
+	GetRefsStatus(ctx context.Context, in *GetRefsStatusRequest, opts ...grpc.CallOption) (*RefsStatus, error)
+	GetJobDurationStats(ctx context.Context, in *GetJobDurationStatsRequest, opts ...grpc.CallOption) (*DurationStats, error)
 }
 
 type prowClient struct {
@@ -67,6 +95,33 @@ func (c *prowClient) GetJobExecution(ctx context.Context, in *GetJobExecutionReq
 	return out, nil
 }
 
+func (c *prowClient) GetJobExecutions(ctx context.Context, in *GetJobExecutionsRequest, opts ...grpc.CallOption) (*JobExecutions, error) {
+	out := new(JobExecutions)
+	err := c.cc.Invoke(ctx, Prow_GetJobExecutions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prowClient) GetJobExecutionStatus(ctx context.Context, in *GetJobExecutionStatusRequest, opts ...grpc.CallOption) (*JobStatusResponse, error) {
+	out := new(JobStatusResponse)
+	err := c.cc.Invoke(ctx, Prow_GetJobExecutionStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prowClient) CancelJobExecution(ctx context.Context, in *CancelJobExecutionRequest, opts ...grpc.CallOption) (*JobStatusResponse, error) {
+	out := new(JobStatusResponse)
+	err := c.cc.Invoke(ctx, Prow_CancelJobExecution_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *prowClient) ListJobExecutions(ctx context.Context, in *ListJobExecutionsRequest, opts ...grpc.CallOption) (*JobExecutions, error) {
 	out := new(JobExecutions)
 	err := c.cc.Invoke(ctx, Prow_ListJobExecutions_FullMethodName, in, out, opts...)
@@ -76,6 +131,38 @@ func (c *prowClient) ListJobExecutions(ctx context.Context, in *ListJobExecution
 	return out, nil
 }
 
+func (c *prowClient) StreamJobExecutions(ctx context.Context, in *ListJobExecutionsRequest, opts ...grpc.CallOption) (Prow_StreamJobExecutionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Prow_ServiceDesc.Streams[0], Prow_StreamJobExecutions_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &prowStreamJobExecutionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Prow_StreamJobExecutionsClient interface {
+	Recv() (*JobExecution, error)
+	grpc.ClientStream
+}
+
+type prowStreamJobExecutionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *prowStreamJobExecutionsClient) Recv() (*JobExecution, error) {
+	m := new(JobExecution)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *prowClient) BulkJobStatusChange(ctx context.Context, in *BulkJobStatusChangeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, Prow_BulkJobStatusChange_FullMethodName, in, out, opts...)
@@ -85,6 +172,60 @@ func (c *prowClient) BulkJobStatusChange(ctx context.Context, in *BulkJobStatusC
 	return out, nil
 }
 
+func (c *prowClient) GetBulkJobStatusChange(ctx context.Context, in *GetBulkJobStatusChangeRequest, opts ...grpc.CallOption) (*BulkJobStatusChangeProgress, error) {
+	out := new(BulkJobStatusChangeProgress)
+	err := c.cc.Invoke(ctx, Prow_GetBulkJobStatusChange_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prowClient) GetJobFailureSummary(ctx context.Context, in *GetJobFailureSummaryRequest, opts ...grpc.CallOption) (*JobFailureSummary, error) {
+	out := new(JobFailureSummary)
+	err := c.cc.Invoke(ctx, Prow_GetJobFailureSummary_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prowClient) GetTenantInFlightCount(ctx context.Context, in *GetTenantInFlightCountRequest, opts ...grpc.CallOption) (*TenantInFlightCount, error) {
+	out := new(TenantInFlightCount)
+	err := c.cc.Invoke(ctx, Prow_GetTenantInFlightCount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prowClient) IsPeriodicDue(ctx context.Context, in *IsPeriodicDueRequest, opts ...grpc.CallOption) (*PeriodicDue, error) {
+	out := new(PeriodicDue)
+	err := c.cc.Invoke(ctx, Prow_IsPeriodicDue_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prowClient) GetRefsStatus(ctx context.Context, in *GetRefsStatusRequest, opts ...grpc.CallOption) (*RefsStatus, error) {
+	out := new(RefsStatus)
+	err := c.cc.Invoke(ctx, Prow_GetRefsStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prowClient) GetJobDurationStats(ctx context.Context, in *GetJobDurationStatsRequest, opts ...grpc.CallOption) (*DurationStats, error) {
+	out := new(DurationStats)
+	err := c.cc.Invoke(ctx, Prow_GetJobDurationStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProwServer is the server API for Prow service.
 // All implementations must embed UnimplementedProwServer
 // for forward compatibility
@@ -96,8 +237,26 @@ type ProwServer interface {
 	// https://docs.google.com/document/d/1v77jp1Nb5C2C2-PdV02SGViO9CyZ9SvNxCPOHyIUQeo/edit#bookmark=id.q68srxklvpt4.
 	CreateJobExecution(context.Context, *CreateJobExecutionRequest) (*JobExecution, error)
 	GetJobExecution(context.Context, *GetJobExecutionRequest) (*JobExecution, error)
+	GetJobExecutions(context.Context, *GetJobExecutionsRequest) (*JobExecutions, error)
+	GetJobExecutionStatus(context.Context, *GetJobExecutionStatusRequest) (*JobStatusResponse, error)
+	CancelJobExecution(context.Context, *CancelJobExecutionRequest) (*JobStatusResponse, error)
 	ListJobExecutions(context.Context, *ListJobExecutionsRequest) (*JobExecutions, error)
+	// StreamJobExecutions is like ListJobExecutions, but sends the job
+	// executions one by one while they are listed instead of all at once.
+	StreamJobExecutions(*ListJobExecutionsRequest, Prow_StreamJobExecutionsServer) error
 	BulkJobStatusChange(context.Context, *BulkJobStatusChangeRequest) (*emptypb.Empty, error)
+	// GetBulkJobStatusChange returns the progress of a BulkJobStatusChange
+	// request with a correlation id. The progress is only kept in memory, for
+	// an hour after the change finished and for at most 1000 changes. Once
+	// gangway has 1000 changes, the ones that finished first are forgotten to
+	// make room for new ones, and new changes with a correlation id are
+	// rejected while all 1000 are running.
+	GetBulkJobStatusChange(context.Context, *GetBulkJobStatusChangeRequest) (*BulkJobStatusChangeProgress, error)
+	GetJobFailureSummary(context.Context, *GetJobFailureSummaryRequest) (*JobFailureSummary, error)
+	GetTenantInFlightCount(context.Context, *GetTenantInFlightCountRequest) (*TenantInFlightCount, error)
+	IsPeriodicDue(context.Context, *IsPeriodicDueRequest) (*PeriodicDue, error)
+	GetRefsStatus(context.Context, *GetRefsStatusRequest) (*RefsStatus, error)
+	GetJobDurationStats(context.Context, *GetJobDurationStatsRequest) (*DurationStats, error)
 	mustEmbedUnimplementedProwServer()
 }
 
@@ -111,12 +270,42 @@ func (UnimplementedProwServer) CreateJobExecution(context.Context, *CreateJobExe
 func (UnimplementedProwServer) GetJobExecution(context.Context, *GetJobExecutionRequest) (*JobExecution, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetJobExecution not implemented")
 }
+func (UnimplementedProwServer) GetJobExecutions(context.Context, *GetJobExecutionsRequest) (*JobExecutions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobExecutions not implemented")
+}
+func (UnimplementedProwServer) GetJobExecutionStatus(context.Context, *GetJobExecutionStatusRequest) (*JobStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobExecutionStatus not implemented")
+}
+func (UnimplementedProwServer) CancelJobExecution(context.Context, *CancelJobExecutionRequest) (*JobStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelJobExecution not implemented")
+}
 func (UnimplementedProwServer) ListJobExecutions(context.Context, *ListJobExecutionsRequest) (*JobExecutions, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListJobExecutions not implemented")
 }
+func (UnimplementedProwServer) StreamJobExecutions(*ListJobExecutionsRequest, Prow_StreamJobExecutionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamJobExecutions not implemented")
+}
 func (UnimplementedProwServer) BulkJobStatusChange(context.Context, *BulkJobStatusChangeRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BulkJobStatusChange not implemented")
 }
+func (UnimplementedProwServer) GetBulkJobStatusChange(context.Context, *GetBulkJobStatusChangeRequest) (*BulkJobStatusChangeProgress, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBulkJobStatusChange not implemented")
+}
+func (UnimplementedProwServer) GetJobFailureSummary(context.Context, *GetJobFailureSummaryRequest) (*JobFailureSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobFailureSummary not implemented")
+}
+func (UnimplementedProwServer) GetTenantInFlightCount(context.Context, *GetTenantInFlightCountRequest) (*TenantInFlightCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTenantInFlightCount not implemented")
+}
+func (UnimplementedProwServer) IsPeriodicDue(context.Context, *IsPeriodicDueRequest) (*PeriodicDue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsPeriodicDue not implemented")
+}
+func (UnimplementedProwServer) GetRefsStatus(context.Context, *GetRefsStatusRequest) (*RefsStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRefsStatus not implemented")
+}
+func (UnimplementedProwServer) GetJobDurationStats(context.Context, *GetJobDurationStatsRequest) (*DurationStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobDurationStats not implemented")
+}
 func (UnimplementedProwServer) mustEmbedUnimplementedProwServer() {}
 
 // UnsafeProwServer may be embedded to opt out of forward compatibility for this service.
@@ -166,6 +355,60 @@ func _Prow_GetJobExecution_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Prow_GetJobExecutions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobExecutionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProwServer).GetJobExecutions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Prow_GetJobExecutions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProwServer).GetJobExecutions(ctx, req.(*GetJobExecutionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Prow_GetJobExecutionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobExecutionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProwServer).GetJobExecutionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Prow_GetJobExecutionStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProwServer).GetJobExecutionStatus(ctx, req.(*GetJobExecutionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Prow_CancelJobExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelJobExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProwServer).CancelJobExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Prow_CancelJobExecution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProwServer).CancelJobExecution(ctx, req.(*CancelJobExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Prow_ListJobExecutions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListJobExecutionsRequest)
 	if err := dec(in); err != nil {
@@ -184,6 +427,27 @@ func _Prow_ListJobExecutions_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Prow_StreamJobExecutions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListJobExecutionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProwServer).StreamJobExecutions(m, &prowStreamJobExecutionsServer{stream})
+}
+
+type Prow_StreamJobExecutionsServer interface {
+	Send(*JobExecution) error
+	grpc.ServerStream
+}
+
+type prowStreamJobExecutionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *prowStreamJobExecutionsServer) Send(m *JobExecution) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Prow_BulkJobStatusChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BulkJobStatusChangeRequest)
 	if err := dec(in); err != nil {
@@ -202,6 +466,114 @@ func _Prow_BulkJobStatusChange_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Prow_GetBulkJobStatusChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBulkJobStatusChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProwServer).GetBulkJobStatusChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Prow_GetBulkJobStatusChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProwServer).GetBulkJobStatusChange(ctx, req.(*GetBulkJobStatusChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Prow_GetJobFailureSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobFailureSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProwServer).GetJobFailureSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Prow_GetJobFailureSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProwServer).GetJobFailureSummary(ctx, req.(*GetJobFailureSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Prow_GetTenantInFlightCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTenantInFlightCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProwServer).GetTenantInFlightCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Prow_GetTenantInFlightCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProwServer).GetTenantInFlightCount(ctx, req.(*GetTenantInFlightCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Prow_IsPeriodicDue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsPeriodicDueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProwServer).IsPeriodicDue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Prow_IsPeriodicDue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProwServer).IsPeriodicDue(ctx, req.(*IsPeriodicDueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Prow_GetRefsStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRefsStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProwServer).GetRefsStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Prow_GetRefsStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProwServer).GetRefsStatus(ctx, req.(*GetRefsStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Prow_GetJobDurationStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobDurationStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProwServer).GetJobDurationStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Prow_GetJobDurationStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProwServer).GetJobDurationStats(ctx, req.(*GetJobDurationStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Prow_ServiceDesc is the grpc.ServiceDesc for Prow service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -217,6 +589,18 @@ var Prow_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetJobExecution",
 			Handler:    _Prow_GetJobExecution_Handler,
 		},
+		{
+			MethodName: "GetJobExecutions",
+			Handler:    _Prow_GetJobExecutions_Handler,
+		},
+		{
+			MethodName: "GetJobExecutionStatus",
+			Handler:    _Prow_GetJobExecutionStatus_Handler,
+		},
+		{
+			MethodName: "CancelJobExecution",
+			Handler:    _Prow_CancelJobExecution_Handler,
+		},
 		{
 			MethodName: "ListJobExecutions",
 			Handler:    _Prow_ListJobExecutions_Handler,
@@ -225,7 +609,37 @@ var Prow_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BulkJobStatusChange",
 			Handler:    _Prow_BulkJobStatusChange_Handler,
 		},
+		{
+			MethodName: "GetBulkJobStatusChange",
+			Handler:    _Prow_GetBulkJobStatusChange_Handler,
+		},
+		{
+			MethodName: "GetJobFailureSummary",
+			Handler:    _Prow_GetJobFailureSummary_Handler,
+		},
+		{
+			MethodName: "GetTenantInFlightCount",
+			Handler:    _Prow_GetTenantInFlightCount_Handler,
+		},
+		{
+			MethodName: "IsPeriodicDue",
+			Handler:    _Prow_IsPeriodicDue_Handler,
+		},
+		{
+			MethodName: "GetRefsStatus",
+			Handler:    _Prow_GetRefsStatus_Handler,
+		},
+		{
+			MethodName: "GetJobDurationStats",
+			Handler:    _Prow_GetJobDurationStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamJobExecutions",
+			Handler:       _Prow_StreamJobExecutions_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "gangway.proto",
 }
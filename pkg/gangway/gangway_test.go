@@ -0,0 +1,3207 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gangway
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+
+	prowcrd "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io/fakeopener"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+func TestHandleProwJobCloneUrls(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		extraRefs []prowcrd.Refs
+		expected  []string
+	}{
+		{
+			name:     "no refs",
+			expected: nil,
+		},
+		{
+			name: "default clone URL",
+			extraRefs: []prowcrd.Refs{
+				{Org: "org", Repo: "repo", BaseRef: "main"},
+			},
+			expected: []string{"https://github.com/org/repo.git"},
+		},
+		{
+			name: "custom clone URI",
+			extraRefs: []prowcrd.Refs{
+				{Org: "org", Repo: "repo", BaseRef: "main", CloneURI: "https://git.example.com/org/repo.git"},
+			},
+			expected: []string{"https://git.example.com/org/repo.git"},
+		},
+		{
+			name: "multiple refs keep their order",
+			extraRefs: []prowcrd.Refs{
+				{Org: "org", Repo: "repo", BaseRef: "main", CloneURI: "https://git.example.com/org/repo.git"},
+				{Org: "other", Repo: "dep", BaseRef: "main"},
+			},
+			expected: []string{"https://git.example.com/org/repo.git", "https://github.com/other/dep.git"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{
+				JobConfig: config.JobConfig{
+					Periodics: []config.Periodic{
+						{
+							JobBase: config.JobBase{
+								Name: "periodic-job",
+								UtilityConfig: config.UtilityConfig{
+									ExtraRefs: tc.extraRefs,
+								},
+							},
+						},
+					},
+				},
+			}
+			cjer := &CreateJobExecutionRequest{
+				JobName:          "periodic-job",
+				JobExecutionType: JobExecutionType_PERIODIC,
+			}
+			pjc := fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs")
+
+			jobExec, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, pjc, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, jobExec.GetCloneUrls()); diff != "" {
+				t.Errorf("unexpected clone URLs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJobExecutionExtraRefs(t *testing.T) {
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			Periodics: []config.Periodic{
+				{
+					JobBase: config.JobBase{
+						Name: "periodic-job",
+						UtilityConfig: config.UtilityConfig{
+							ExtraRefs: []prowcrd.Refs{
+								{Org: "org", Repo: "repo", BaseRef: "main", PathAlias: "example.com/repo"},
+								{Org: "other", Repo: "dep", BaseRef: "release", WorkDir: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	ca := &config.Agent{}
+	ca.Set(cfg)
+	gw := &Gangway{
+		ConfigAgent:   ca,
+		ProwJobClient: fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs"),
+	}
+	cjer := &CreateJobExecutionRequest{
+		JobName:          "periodic-job",
+		JobExecutionType: JobExecutionType_PERIODIC,
+	}
+	expected := []*Refs{
+		{Org: "org", Repo: "repo", BaseRef: "main", PathAlias: "example.com/repo"},
+		{Org: "other", Repo: "dep", BaseRef: "release", WorkDir: true},
+	}
+
+	created, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, gw.ProwJobClient, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(expected, created.GetExtraRefs(), protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected extra refs of the created job execution (-want +got):\n%s", diff)
+	}
+
+	fetched, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(expected, fetched.GetExtraRefs(), protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected extra refs of the fetched job execution (-want +got):\n%s", diff)
+	}
+}
+
+// newProwJob returns a ProwJob of the given job, labeled with the name of the
+// job, in the given state. The tenant and type are only set if given. Tests set
+// further fields on the returned ProwJob as they need.
+func newProwJob(name, job, tenantID string, jobType prowcrd.ProwJobType, state prowcrd.ProwJobState, complete bool) *prowcrd.ProwJob {
+	pj := &prowcrd.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "prowjobs",
+			Labels:    map[string]string{kube.ProwJobAnnotation: job},
+		},
+		Spec: prowcrd.ProwJobSpec{
+			Job:  job,
+			Type: jobType,
+		},
+		Status: prowcrd.ProwJobStatus{
+			State: state,
+		},
+	}
+	if tenantID != "" {
+		pj.Spec.ProwJobDefault = &prowcrd.ProwJobDefault{TenantID: tenantID}
+	}
+	if complete {
+		pj.SetComplete()
+	}
+	return pj
+}
+
+func TestGetJobFailureSummary(t *testing.T) {
+	withDescription := func(pj *prowcrd.ProwJob, description string) runtime.Object {
+		pj.Status.Description = description
+		return pj
+	}
+
+	prowJobs := []runtime.Object{
+		withDescription(newProwJob("oom-1", "flaky-job", "tenant", "", prowcrd.ErrorState, true), "Job pod was OOM killed by the cluster."),
+		withDescription(newProwJob("oom-2", "flaky-job", "tenant", "", prowcrd.ErrorState, true), "Job pod was OOM killed by the cluster."),
+		withDescription(newProwJob("timeout", "flaky-job", "tenant", "", prowcrd.ErrorState, true), "Pod pending timeout."),
+		withDescription(newProwJob("failed", "flaky-job", "tenant", "", prowcrd.FailureState, true), ""),
+		withDescription(newProwJob("success", "flaky-job", "tenant", "", prowcrd.SuccessState, true), "Job succeeded."),
+		withDescription(newProwJob("running", "flaky-job", "tenant", "", prowcrd.PendingState, false), "Job triggered."),
+		withDescription(newProwJob("other-tenant", "flaky-job", "other-tenant", "", prowcrd.ErrorState, true), "Pod pending timeout."),
+		withDescription(newProwJob("other-job", "other-job", "tenant", "", prowcrd.ErrorState, true), "Pod pending timeout."),
+	}
+
+	ca := &config.Agent{}
+	ca.Set(&config.Config{
+		ProwConfig: config.ProwConfig{
+			Gangway: config.Gangway{
+				AllowedApiClients: []config.AllowedApiClient{
+					{
+						GCP: &config.ApiClientGcp{
+							EndpointApiConsumerType:   "PROJECT",
+							EndpointApiConsumerNumber: "123",
+						},
+						AllowedJobsFilters: []config.AllowedJobsFilter{{TenantID: "tenant"}},
+					},
+				},
+			},
+		},
+	})
+	gw := &Gangway{
+		ConfigAgent:   ca,
+		ProwJobClient: fake.NewSimpleClientset(prowJobs...).ProwV1().ProwJobs("prowjobs"),
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		HEADER_API_CONSUMER_TYPE: "PROJECT",
+		HEADER_API_CONSUMER_ID:   "123",
+	}))
+
+	summary, err := gw.GetJobFailureSummary(ctx, &GetJobFailureSummaryRequest{JobName: "flaky-job"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &JobFailureSummary{
+		JobName:             "flaky-job",
+		CompletedExecutions: 5,
+		FailedExecutions:    4,
+		FailureReasons: []*JobFailureReasonCount{
+			{Reason: "Job pod was OOM killed by the cluster.", Count: 2},
+			{Reason: "Pod pending timeout.", Count: 1},
+			{Reason: "failure", Count: 1},
+		},
+	}
+	if diff := cmp.Diff(expected, summary, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected failure summary (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetJobDurationStats(t *testing.T) {
+	now := time.Now()
+	finished := func(pj *prowcrd.ProwJob, completedAgo, duration time.Duration) runtime.Object {
+		completion := metav1.NewTime(now.Add(-completedAgo))
+		pj.Status.StartTime = metav1.NewTime(completion.Add(-duration))
+		pj.Status.CompletionTime = &completion
+		return pj
+	}
+
+	var prowJobs []runtime.Object
+	for i := 1; i <= 10; i++ {
+		state := prowcrd.SuccessState
+		if i%3 == 0 {
+			state = prowcrd.FailureState
+		}
+		prowJobs = append(prowJobs, finished(newProwJob(fmt.Sprintf("run-%d", i), "some-job", "tenant", "", state, true), time.Duration(i)*time.Hour, time.Duration(i)*time.Minute))
+	}
+	prowJobs = append(prowJobs,
+		finished(newProwJob("aborted", "some-job", "tenant", "", prowcrd.AbortedState, true), time.Hour, 100*time.Minute),
+		finished(newProwJob("other-tenant", "some-job", "other-tenant", "", prowcrd.SuccessState, true), time.Hour, 200*time.Minute),
+		finished(newProwJob("ten-days-ago", "some-job", "tenant", "", prowcrd.SuccessState, true), 10*24*time.Hour, 300*time.Minute),
+		finished(newProwJob("forty-days-ago", "some-job", "tenant", "", prowcrd.SuccessState, true), 40*24*time.Hour, 400*time.Minute),
+		&prowcrd.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "prowjobs", Labels: map[string]string{"prow.k8s.io/job": "some-job"}},
+			Spec:       prowcrd.ProwJobSpec{Job: "some-job", ProwJobDefault: &prowcrd.ProwJobDefault{TenantID: "tenant"}},
+			Status:     prowcrd.ProwJobStatus{State: prowcrd.PendingState, StartTime: metav1.NewTime(now.Add(-time.Hour))},
+		},
+	)
+
+	ca := &config.Agent{}
+	ca.Set(&config.Config{
+		ProwConfig: config.ProwConfig{
+			Gangway: config.Gangway{
+				AllowedApiClients: []config.AllowedApiClient{
+					{
+						GCP: &config.ApiClientGcp{
+							EndpointApiConsumerType:   "PROJECT",
+							EndpointApiConsumerNumber: "123",
+						},
+						AllowedJobsFilters: []config.AllowedJobsFilter{{TenantID: "tenant"}},
+					},
+				},
+			},
+		},
+	})
+	gw := &Gangway{
+		ConfigAgent:   ca,
+		ProwJobClient: fake.NewSimpleClientset(prowJobs...).ProwV1().ProwJobs("prowjobs"),
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		HEADER_API_CONSUMER_TYPE: "PROJECT",
+		HEADER_API_CONSUMER_ID:   "123",
+	}))
+
+	testCases := []struct {
+		name        string
+		lookback    *durationpb.Duration
+		expected    *DurationStats
+		expectedErr bool
+	}{
+		{
+			name: "default lookback",
+			expected: &DurationStats{
+				JobName:    "some-job",
+				Executions: 10,
+				P50:        durationpb.New(5 * time.Minute),
+				P90:        durationpb.New(9 * time.Minute),
+				P99:        durationpb.New(10 * time.Minute),
+			},
+		},
+		{
+			name:     "longer lookback",
+			lookback: durationpb.New(11 * 24 * time.Hour),
+			expected: &DurationStats{
+				JobName:    "some-job",
+				Executions: 11,
+				P50:        durationpb.New(6 * time.Minute),
+				P90:        durationpb.New(10 * time.Minute),
+				P99:        durationpb.New(300 * time.Minute),
+			},
+		},
+		{
+			name:     "lookback is capped",
+			lookback: durationpb.New(60 * 24 * time.Hour),
+			expected: &DurationStats{
+				JobName:    "some-job",
+				Executions: 11,
+				P50:        durationpb.New(6 * time.Minute),
+				P90:        durationpb.New(10 * time.Minute),
+				P99:        durationpb.New(300 * time.Minute),
+			},
+		},
+		{
+			name:     "no executions within lookback",
+			lookback: durationpb.New(time.Minute),
+			expected: &DurationStats{JobName: "some-job"},
+		},
+		{
+			name:        "negative lookback",
+			lookback:    durationpb.New(-time.Hour),
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stats, err := gw.GetJobDurationStats(ctx, &GetJobDurationStatsRequest{JobName: "some-job", Lookback: tc.lookback})
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if diff := cmp.Diff(tc.expected, stats, protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected duration stats (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetTenantInFlightCount(t *testing.T) {
+
+	prowJobs := []runtime.Object{
+		newProwJob("scheduling", "scheduling", "tenant", "", prowcrd.SchedulingState, false),
+		newProwJob("triggered-1", "triggered-1", "tenant", "", prowcrd.TriggeredState, false),
+		newProwJob("triggered-2", "triggered-2", "tenant", "", prowcrd.TriggeredState, false),
+		newProwJob("pending-1", "pending-1", "tenant", "", prowcrd.PendingState, false),
+		newProwJob("pending-2", "pending-2", "tenant", "", prowcrd.PendingState, false),
+		newProwJob("pending-3", "pending-3", "tenant", "", prowcrd.PendingState, false),
+		newProwJob("success", "success", "tenant", "", prowcrd.SuccessState, true),
+		newProwJob("failure", "failure", "tenant", "", prowcrd.FailureState, true),
+		newProwJob("other-tenant", "other-tenant", "other-tenant", "", prowcrd.PendingState, false),
+	}
+
+	ca := &config.Agent{}
+	ca.Set(&config.Config{
+		ProwConfig: config.ProwConfig{
+			Gangway: config.Gangway{
+				AllowedApiClients: []config.AllowedApiClient{
+					{
+						GCP: &config.ApiClientGcp{
+							EndpointApiConsumerType:   "PROJECT",
+							EndpointApiConsumerNumber: "123",
+						},
+						AllowedJobsFilters: []config.AllowedJobsFilter{{TenantID: "tenant"}},
+					},
+				},
+			},
+		},
+	})
+	gw := &Gangway{
+		ConfigAgent:   ca,
+		ProwJobClient: fake.NewSimpleClientset(prowJobs...).ProwV1().ProwJobs("prowjobs"),
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		HEADER_API_CONSUMER_TYPE: "PROJECT",
+		HEADER_API_CONSUMER_ID:   "123",
+	}))
+
+	inFlight, err := gw.GetTenantInFlightCount(ctx, &GetTenantInFlightCountRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &TenantInFlightCount{
+		Count: 6,
+		StatusCounts: []*JobExecutionStatusCount{
+			{Status: JobExecutionStatus_TRIGGERED, Count: 2},
+			{Status: JobExecutionStatus_PENDING, Count: 3},
+			{Status: JobExecutionStatus_SCHEDULING, Count: 1},
+		},
+	}
+	if diff := cmp.Diff(expected, inFlight, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected in-flight count (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetRefsStatus(t *testing.T) {
+	now := time.Now()
+	forPull := func(pj *prowcrd.ProwJob, pull int, age time.Duration) runtime.Object {
+		pj.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		pj.Labels[kube.ProwJobTypeLabel] = string(prowcrd.PresubmitJob)
+		pj.Labels[kube.OrgLabel] = "org"
+		pj.Labels[kube.RepoLabel] = "repo"
+		pj.Labels[kube.PullLabel] = strconv.Itoa(pull)
+		return pj
+	}
+
+	prowJobs := []runtime.Object{
+		forPull(newProwJob("unit-retested", "unit", "tenant", prowcrd.PresubmitJob, prowcrd.FailureState, true), 1, time.Hour),
+		forPull(newProwJob("unit", "unit", "tenant", prowcrd.PresubmitJob, prowcrd.SuccessState, true), 1, time.Minute),
+		forPull(newProwJob("lint", "lint", "tenant", prowcrd.PresubmitJob, prowcrd.FailureState, true), 1, time.Minute),
+		forPull(newProwJob("e2e", "e2e", "tenant", prowcrd.PresubmitJob, prowcrd.PendingState, false), 1, time.Minute),
+		forPull(newProwJob("other-tenant", "other", "other-tenant", prowcrd.PresubmitJob, prowcrd.ErrorState, true), 1, time.Minute),
+		forPull(newProwJob("green-unit-retested", "unit", "tenant", prowcrd.PresubmitJob, prowcrd.ErrorState, true), 2, time.Hour),
+		forPull(newProwJob("green-unit", "unit", "tenant", prowcrd.PresubmitJob, prowcrd.SuccessState, true), 2, time.Minute),
+		forPull(newProwJob("green-lint", "lint", "tenant", prowcrd.PresubmitJob, prowcrd.SuccessState, true), 2, time.Minute),
+	}
+
+	ca := &config.Agent{}
+	ca.Set(&config.Config{
+		ProwConfig: config.ProwConfig{
+			Gangway: config.Gangway{
+				AllowedApiClients: []config.AllowedApiClient{
+					{
+						GCP: &config.ApiClientGcp{
+							EndpointApiConsumerType:   "PROJECT",
+							EndpointApiConsumerNumber: "123",
+						},
+						AllowedJobsFilters: []config.AllowedJobsFilter{{TenantID: "tenant"}},
+					},
+				},
+			},
+		},
+	})
+	gw := &Gangway{
+		ConfigAgent:   ca,
+		ProwJobClient: fake.NewSimpleClientset(prowJobs...).ProwV1().ProwJobs("prowjobs"),
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		HEADER_API_CONSUMER_TYPE: "PROJECT",
+		HEADER_API_CONSUMER_ID:   "123",
+	}))
+
+	testCases := []struct {
+		name     string
+		pull     int32
+		expected *RefsStatus
+	}{
+		{
+			name: "pull request with failing and pending presubmits",
+			pull: 1,
+			expected: &RefsStatus{
+				AnyFailed: true,
+				StatusCounts: []*JobExecutionStatusCount{
+					{Status: JobExecutionStatus_PENDING, Count: 1},
+					{Status: JobExecutionStatus_SUCCESS, Count: 1},
+					{Status: JobExecutionStatus_FAILURE, Count: 1},
+				},
+			},
+		},
+		{
+			name: "pull request whose presubmits are green after a retest",
+			pull: 2,
+			expected: &RefsStatus{
+				AllComplete: true,
+				StatusCounts: []*JobExecutionStatusCount{
+					{Status: JobExecutionStatus_SUCCESS, Count: 2},
+				},
+			},
+		},
+		{
+			name:     "pull request without presubmits",
+			pull:     3,
+			expected: &RefsStatus{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			refsStatus, err := gw.GetRefsStatus(ctx, &GetRefsStatusRequest{Org: "org", Repo: "repo", Pull: tc.pull})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, refsStatus, protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected refs status (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleProwJobEchoesResolvedRefs(t *testing.T) {
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			PresubmitsStatic: map[string][]config.Presubmit{
+				"org/repo": {
+					{
+						JobBase: config.JobBase{
+							Name: "presubmit-job",
+							UtilityConfig: config.UtilityConfig{
+								PathAlias: "example.com/org/repo",
+								CloneURI:  "https://git.example.com/org/repo.git",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	cjer := &CreateJobExecutionRequest{
+		JobName:          "presubmit-job",
+		JobExecutionType: JobExecutionType_PRESUBMIT,
+		Refs: &Refs{
+			Org:     "org",
+			Repo:    "repo",
+			BaseRef: "main",
+			BaseSha: "a2f7c5b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0",
+			Pulls: []*Pull{
+				{Number: 1, Sha: "b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5"},
+			},
+		},
+	}
+	pjc := fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs")
+
+	jobExec, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, pjc, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &Refs{
+		Org:       "org",
+		Repo:      "repo",
+		BaseRef:   "main",
+		BaseSha:   "a2f7c5b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0",
+		PathAlias: "example.com/org/repo",
+		CloneUri:  "https://git.example.com/org/repo.git",
+		Pulls: []*Pull{
+			{Number: 1, Sha: "b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5"},
+		},
+	}
+	if diff := cmp.Diff(expected, jobExec.GetRefs(), protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected refs (-want +got):\n%s", diff)
+	}
+}
+
+type fakeInRepoConfigGetter struct {
+	prowYAML *config.ProwYAML
+	err      error
+}
+
+func (f *fakeInRepoConfigGetter) GetInRepoConfig(identifier, baseBranch string, baseSHAGetter config.RefGetter, headSHAGetters ...config.RefGetter) (*config.ProwYAML, error) {
+	return f.prowYAML, f.err
+}
+
+func (f *fakeInRepoConfigGetter) GetPresubmits(identifier, baseBranch string, baseSHAGetter config.RefGetter, headSHAGetters ...config.RefGetter) ([]config.Presubmit, error) {
+	return f.prowYAML.Presubmits, f.err
+}
+
+func (f *fakeInRepoConfigGetter) GetPostsubmits(identifier, baseBranch string, baseSHAGetter config.RefGetter, headSHAGetters ...config.RefGetter) ([]config.Postsubmit, error) {
+	return f.prowYAML.Postsubmits, f.err
+}
+
+func TestHandleProwJobInRepoConfigFailure(t *testing.T) {
+	inRepoConfig := &config.ProwYAML{
+		Presubmits:  []config.Presubmit{{JobBase: config.JobBase{Name: "inrepo-job"}}},
+		Postsubmits: []config.Postsubmit{{JobBase: config.JobBase{Name: "inrepo-job"}}},
+	}
+	for _, tc := range []struct {
+		name         string
+		jobType      JobExecutionType
+		jobName      string
+		ircg         config.InRepoConfigGetter
+		expectedCode codes.Code
+	}{
+		{
+			name:         "presubmit from inrepoconfig",
+			jobType:      JobExecutionType_PRESUBMIT,
+			jobName:      "inrepo-job",
+			ircg:         &fakeInRepoConfigGetter{prowYAML: inRepoConfig},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "presubmit missing from inrepoconfig",
+			jobType:      JobExecutionType_PRESUBMIT,
+			jobName:      "missing-job",
+			ircg:         &fakeInRepoConfigGetter{prowYAML: inRepoConfig},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "presubmit while getting the inrepoconfig fails",
+			jobType:      JobExecutionType_PRESUBMIT,
+			jobName:      "inrepo-job",
+			ircg:         &fakeInRepoConfigGetter{err: errors.New("injected error")},
+			expectedCode: codes.Unavailable,
+		},
+		{
+			name:         "postsubmit missing from inrepoconfig",
+			jobType:      JobExecutionType_POSTSUBMIT,
+			jobName:      "missing-job",
+			ircg:         &fakeInRepoConfigGetter{prowYAML: inRepoConfig},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "postsubmit while getting the inrepoconfig fails",
+			jobType:      JobExecutionType_POSTSUBMIT,
+			jobName:      "inrepo-job",
+			ircg:         &fakeInRepoConfigGetter{err: errors.New("injected error")},
+			expectedCode: codes.Unavailable,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cjer := &CreateJobExecutionRequest{
+				JobName:          tc.jobName,
+				JobExecutionType: tc.jobType,
+				Refs: &Refs{
+					Org:     "org",
+					Repo:    "repo",
+					BaseRef: "main",
+					BaseSha: "a2f7c5b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0",
+				},
+			}
+			if tc.jobType == JobExecutionType_PRESUBMIT {
+				cjer.Refs.Pulls = []*Pull{{Number: 1, Sha: "b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5"}}
+			}
+			pjc := fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs")
+
+			_, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, pjc, &ProwCfgAdapter{Config: &config.Config{}}, tc.ircg, nil, false, []string{"*"})
+			if code := status.Code(err); code != tc.expectedCode {
+				t.Errorf("expected code %v, got %v (error: %v)", tc.expectedCode, code, err)
+			}
+		})
+	}
+}
+
+func TestRefsRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		refs *Refs
+	}{
+		{
+			name: "minimal refs",
+			refs: &Refs{
+				Org:  "org",
+				Repo: "repo",
+			},
+		},
+		{
+			name: "all fields set",
+			refs: &Refs{
+				Org:                 "org",
+				Repo:                "repo",
+				RepoLink:            "https://github.com/org/repo",
+				BaseRef:             "main",
+				BaseSha:             "a2f7c5b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0",
+				BaseLink:            "https://github.com/org/repo/commit/a2f7c5b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0",
+				PathAlias:           "example.com/org/repo",
+				WorkDir:             true,
+				CloneUri:            "https://git.example.com/org/repo.git",
+				SkipSubmodules:      true,
+				CloneDepth:          3,
+				SkipFetchHead:       true,
+				Auxiliary:           true,
+				BloblessFetch:       ptr.To(true),
+				SparseCheckoutFiles: []string{"docs/", "README.md"},
+				Pulls: []*Pull{
+					{
+						Number:     1,
+						Author:     "alice",
+						Sha:        "b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5",
+						Title:      "Fix the thing",
+						Ref:        "refs/pull/1/head",
+						Link:       "https://github.com/org/repo/pull/1",
+						CommitLink: "https://github.com/org/repo/pull/1/commits/b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5",
+						AuthorLink: "https://github.com/alice",
+						HeadRepo:   "alice/repo",
+						HeadRef:    "fix-the-thing",
+					},
+					{
+						Number: 2,
+						Sha:    "c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5b3e9d4",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			crdRefs, err := ToCrdRefs(tc.refs)
+			if err != nil {
+				t.Fatalf("ToCrdRefs: unexpected error: %v", err)
+			}
+			refs, err := FromCrdRefs(crdRefs)
+			if err != nil {
+				t.Fatalf("FromCrdRefs: unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.refs, refs, protocmp.Transform()); diff != "" {
+				t.Errorf("refs changed in the roundtrip (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCrdRefsRoundTrip(t *testing.T) {
+	crdRefs := &prowcrd.Refs{
+		Org:                 "org",
+		Repo:                "repo",
+		RepoLink:            "https://github.com/org/repo",
+		BaseRef:             "main",
+		BaseSHA:             "a2f7c5b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0",
+		BaseLink:            "https://github.com/org/repo/commit/a2f7c5b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0",
+		PathAlias:           "example.com/org/repo",
+		WorkDir:             true,
+		Auxiliary:           true,
+		CloneURI:            "https://git.example.com/org/repo.git",
+		SkipSubmodules:      true,
+		CloneDepth:          3,
+		SkipFetchHead:       true,
+		BloblessFetch:       ptr.To(false),
+		SparseCheckoutFiles: []string{"docs/"},
+		Pulls: []prowcrd.Pull{{
+			Number:     1,
+			Author:     "alice",
+			SHA:        "b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5",
+			Title:      "Fix the thing",
+			Ref:        "refs/pull/1/head",
+			HeadRef:    "fix-the-thing",
+			HeadRepo:   "alice/repo",
+			Link:       "https://github.com/org/repo/pull/1",
+			CommitLink: "https://github.com/org/repo/pull/1/commits/b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5",
+			AuthorLink: "https://github.com/alice",
+		}},
+	}
+
+	refs, err := FromCrdRefs(crdRefs)
+	if err != nil {
+		t.Fatalf("FromCrdRefs: unexpected error: %v", err)
+	}
+	got, err := ToCrdRefs(refs)
+	if err != nil {
+		t.Fatalf("ToCrdRefs: unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(crdRefs, got); diff != "" {
+		t.Errorf("refs changed in the roundtrip (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetJobExecutionInfraFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		state       prowcrd.ProwJobState
+		errorReason string
+		expected    bool
+	}{
+		{
+			name:        "errored because of the infrastructure",
+			state:       prowcrd.ErrorState,
+			errorReason: kube.ErrorReasonPodPendingTimeout,
+			expected:    true,
+		},
+		{
+			name:        "errored because of the job",
+			state:       prowcrd.ErrorState,
+			errorReason: kube.ErrorReasonOOMKilled,
+		},
+		{
+			name:  "errored without a reason",
+			state: prowcrd.ErrorState,
+		},
+		{
+			name:  "test failed",
+			state: prowcrd.FailureState,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &prowcrd.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job-execution",
+					Namespace: "prowjobs",
+				},
+				Spec: prowcrd.ProwJobSpec{
+					Job:  "job",
+					Type: prowcrd.PeriodicJob,
+				},
+				Status: prowcrd.ProwJobStatus{
+					State: tc.state,
+				},
+			}
+			if tc.errorReason != "" {
+				pj.Annotations = map[string]string{kube.ErrorReasonAnnotation: tc.errorReason}
+			}
+			ca := &config.Agent{}
+			ca.Set(&config.Config{})
+			gw := &Gangway{
+				ConfigAgent:   ca,
+				ProwJobClient: fake.NewSimpleClientset(pj).ProwV1().ProwJobs("prowjobs"),
+			}
+
+			jobExec, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: "job-execution"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if jobExec.GetInfraFailure() != tc.expected {
+				t.Errorf("expected InfraFailure to be %t, got %t", tc.expected, jobExec.GetInfraFailure())
+			}
+		})
+	}
+}
+
+func TestDeckUrl(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		deckURL  string
+		expected string
+	}{
+		{
+			name:     "no deck configured",
+			expected: "",
+		},
+		{
+			name:     "deck configured",
+			deckURL:  "https://prow.example.com",
+			expected: "https://prow.example.com/prowjob?prowjob=",
+		},
+		{
+			name:     "deck configured with trailing slash",
+			deckURL:  "https://prow.example.com/",
+			expected: "https://prow.example.com/prowjob?prowjob=",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{
+				JobConfig: config.JobConfig{
+					Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "periodic-job"}}},
+				},
+				ProwConfig: config.ProwConfig{
+					Gangway: config.Gangway{DeckURL: tc.deckURL},
+				},
+			}
+			ca := &config.Agent{}
+			ca.Set(cfg)
+			gw := &Gangway{
+				ConfigAgent:   ca,
+				ProwJobClient: fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs"),
+			}
+			cjer := &CreateJobExecutionRequest{
+				JobName:          "periodic-job",
+				JobExecutionType: JobExecutionType_PERIODIC,
+			}
+
+			created, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, gw.ProwJobClient, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			expected := ""
+			if tc.expected != "" {
+				expected = tc.expected + created.GetId()
+			}
+			if created.GetDeckUrl() != expected {
+				t.Errorf("expected created job execution to link to %q, got %q", expected, created.GetDeckUrl())
+			}
+
+			fetched, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: created.GetId()})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fetched.GetDeckUrl() != expected {
+				t.Errorf("expected fetched job execution to link to %q, got %q", expected, fetched.GetDeckUrl())
+			}
+		})
+	}
+}
+
+func TestJobExecutionDecorated(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		decorationConfig *prowcrd.DecorationConfig
+		expected         bool
+	}{
+		{
+			name:             "decorated job",
+			decorationConfig: &prowcrd.DecorationConfig{GCSConfiguration: &prowcrd.GCSConfiguration{Bucket: "bucket"}},
+			expected:         true,
+		},
+		{
+			name:     "undecorated job",
+			expected: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{
+				JobConfig: config.JobConfig{
+					Periodics: []config.Periodic{
+						{
+							JobBase: config.JobBase{
+								Name: "periodic-job",
+								UtilityConfig: config.UtilityConfig{
+									DecorationConfig: tc.decorationConfig,
+								},
+							},
+						},
+					},
+				},
+			}
+			ca := &config.Agent{}
+			ca.Set(cfg)
+			gw := &Gangway{
+				ConfigAgent:   ca,
+				ProwJobClient: fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs"),
+			}
+			cjer := &CreateJobExecutionRequest{
+				JobName:          "periodic-job",
+				JobExecutionType: JobExecutionType_PERIODIC,
+			}
+
+			created, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, gw.ProwJobClient, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if created.GetDecorated() != tc.expected {
+				t.Errorf("expected created job execution to have Decorated %t, got %t", tc.expected, created.GetDecorated())
+			}
+
+			fetched, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: created.GetId()})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fetched.GetDecorated() != tc.expected {
+				t.Errorf("expected fetched job execution to have Decorated %t, got %t", tc.expected, fetched.GetDecorated())
+			}
+		})
+	}
+}
+
+func TestHandleProwJobAmbiguousJobName(t *testing.T) {
+	ambiguousPresubmit := func(context string) config.Presubmit {
+		return config.Presubmit{
+			JobBase:  config.JobBase{Name: "ambiguous-job"},
+			Reporter: config.Reporter{Context: context},
+		}
+	}
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			PresubmitsStatic: map[string][]config.Presubmit{
+				"org/repo": {ambiguousPresubmit("unit"), ambiguousPresubmit("e2e")},
+			},
+		},
+	}
+	refs := &Refs{
+		Org:     "org",
+		Repo:    "repo",
+		BaseRef: "main",
+		BaseSha: "a2f7c5b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0",
+		Pulls: []*Pull{
+			{Number: 1, Sha: "b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5"},
+		},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		jobContext      string
+		expectedErr     string
+		expectedContext string
+		expectWarning   bool
+	}{
+		{
+			name:        "no hint",
+			expectedErr: `ambiguous-job matches multiple prow jobs from orgRepo "org/repo"`,
+		},
+		{
+			name:        "hint matching no job",
+			jobContext:  "integration",
+			expectedErr: `ambiguous-job matches multiple prow jobs from orgRepo "org/repo"`,
+		},
+		{
+			name:            "hint resolving the ambiguity",
+			jobContext:      "e2e",
+			expectedContext: "e2e",
+			expectWarning:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cjer := &CreateJobExecutionRequest{
+				JobName:          "ambiguous-job",
+				JobExecutionType: JobExecutionType_PRESUBMIT,
+				Refs:             refs,
+				JobContext:       tc.jobContext,
+			}
+			fakeClient := fake.NewSimpleClientset()
+			pjc := fakeClient.ProwV1().ProwJobs("prowjobs")
+
+			jobExec, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, pjc, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+			if tc.expectedErr != "" {
+				if err == nil || err.Error() != tc.expectedErr {
+					t.Fatalf("expected error %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tc.expectWarning != (len(jobExec.GetWarnings()) > 0) {
+				t.Errorf("expected warnings: %t, got %v", tc.expectWarning, jobExec.GetWarnings())
+			}
+			created, err := pjc.Get(context.Background(), jobExec.GetId(), metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get created prowjob: %v", err)
+			}
+			if created.Spec.Context != tc.expectedContext {
+				t.Errorf("expected job with context %q to be created, got %q", tc.expectedContext, created.Spec.Context)
+			}
+		})
+	}
+}
+
+func TestHandleProwJobRerunCommand(t *testing.T) {
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			PresubmitsStatic: map[string][]config.Presubmit{
+				"org/repo": {
+					{
+						JobBase:      config.JobBase{Name: "presubmit-job"},
+						RerunCommand: "/test unit",
+						Trigger:      `(?m)^/test (?:.*? )?unit(?: .*?)?$`,
+					},
+				},
+			},
+			Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "periodic-job"}}},
+		},
+	}
+	for _, tc := range []struct {
+		name     string
+		cjer     *CreateJobExecutionRequest
+		expected string
+	}{
+		{
+			name: "presubmit",
+			cjer: &CreateJobExecutionRequest{
+				JobName:          "presubmit-job",
+				JobExecutionType: JobExecutionType_PRESUBMIT,
+				Refs: &Refs{
+					Org:     "org",
+					Repo:    "repo",
+					BaseRef: "main",
+					BaseSha: "a2f7c5b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0",
+					Pulls: []*Pull{
+						{Number: 1, Sha: "b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5"},
+					},
+				},
+			},
+			expected: "/test unit",
+		},
+		{
+			name: "periodic",
+			cjer: &CreateJobExecutionRequest{
+				JobName:          "periodic-job",
+				JobExecutionType: JobExecutionType_PERIODIC,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ca := &config.Agent{}
+			ca.Set(cfg)
+			gw := &Gangway{
+				ConfigAgent:   ca,
+				ProwJobClient: fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs"),
+			}
+
+			created, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, tc.cjer, gw.ProwJobClient, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if created.GetRerunCommand() != tc.expected {
+				t.Errorf("expected created job execution to have rerun command %q, got %q", tc.expected, created.GetRerunCommand())
+			}
+
+			fetched, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: created.GetId()})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fetched.GetRerunCommand() != tc.expected {
+				t.Errorf("expected fetched job execution to have rerun command %q, got %q", tc.expected, fetched.GetRerunCommand())
+			}
+		})
+	}
+}
+
+func TestHandleProwJobCrossRepo(t *testing.T) {
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			PresubmitsStatic: map[string][]config.Presubmit{
+				"org/repo": {{JobBase: config.JobBase{Name: "presubmit-job"}}},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		name     string
+		pull     *Pull
+		expected bool
+	}{
+		{
+			name:     "pull from the same repository",
+			pull:     &Pull{Number: 1, Sha: "b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5", HeadRepo: "org/repo"},
+			expected: false,
+		},
+		{
+			name:     "pull from a fork",
+			pull:     &Pull{Number: 1, Sha: "b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5", HeadRepo: "contributor/repo"},
+			expected: true,
+		},
+		{
+			name:     "pull without head repository",
+			pull:     &Pull{Number: 1, Sha: "b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5"},
+			expected: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ca := &config.Agent{}
+			ca.Set(cfg)
+			gw := &Gangway{
+				ConfigAgent:   ca,
+				ProwJobClient: fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs"),
+			}
+			cjer := &CreateJobExecutionRequest{
+				JobName:          "presubmit-job",
+				JobExecutionType: JobExecutionType_PRESUBMIT,
+				Refs: &Refs{
+					Org:     "org",
+					Repo:    "repo",
+					BaseRef: "main",
+					BaseSha: "a2f7c5b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0",
+					Pulls:   []*Pull{tc.pull},
+				},
+			}
+
+			created, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, gw.ProwJobClient, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if created.GetCrossRepo() != tc.expected {
+				t.Errorf("expected created job execution to have cross repo %t, got %t", tc.expected, created.GetCrossRepo())
+			}
+
+			fetched, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: created.GetId()})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fetched.GetCrossRepo() != tc.expected {
+				t.Errorf("expected fetched job execution to have cross repo %t, got %t", tc.expected, fetched.GetCrossRepo())
+			}
+		})
+	}
+}
+
+func TestHandleProwJobDuplicateOf(t *testing.T) {
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			PresubmitsStatic: map[string][]config.Presubmit{
+				"org/repo": {{JobBase: config.JobBase{Name: "presubmit-job"}}},
+			},
+		},
+	}
+	request := func(sha string) *CreateJobExecutionRequest {
+		return &CreateJobExecutionRequest{
+			JobName:          "presubmit-job",
+			JobExecutionType: JobExecutionType_PRESUBMIT,
+			Refs: &Refs{
+				Org:     "org",
+				Repo:    "repo",
+				BaseRef: "main",
+				BaseSha: "a2f7c5b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0",
+				Pulls:   []*Pull{{Number: 1, Sha: sha}},
+			},
+		}
+	}
+	for _, tc := range []struct {
+		name             string
+		firstComplete    bool
+		secondSha        string
+		expectDuplicated bool
+	}{
+		{
+			name:             "running job against the same refs",
+			secondSha:        "b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5",
+			expectDuplicated: true,
+		},
+		{
+			name:          "complete job against the same refs",
+			firstComplete: true,
+			secondSha:     "b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5",
+		},
+		{
+			name:      "running job against different refs",
+			secondSha: "c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5b3e9d4",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pjc := fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs")
+
+			first, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, request("b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0a2f7c5"), pjc, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if first.GetDuplicateOf() != "" {
+				t.Errorf("expected first job execution not to be a duplicate, got duplicate of %q", first.GetDuplicateOf())
+			}
+			if tc.firstComplete {
+				pj, err := pjc.Get(context.Background(), first.GetId(), metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("failed to get ProwJob: %v", err)
+				}
+				pj.SetComplete()
+				if _, err := pjc.Update(context.Background(), pj, metav1.UpdateOptions{}); err != nil {
+					t.Fatalf("failed to update ProwJob: %v", err)
+				}
+			}
+
+			second, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, request(tc.secondSha), pjc, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var expected string
+			if tc.expectDuplicated {
+				expected = first.GetId()
+			}
+			if second.GetDuplicateOf() != expected {
+				t.Errorf("expected second job execution to be a duplicate of %q, got %q", expected, second.GetDuplicateOf())
+			}
+		})
+	}
+}
+
+func TestHandleProwJobEnv(t *testing.T) {
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			Periodics: []config.Periodic{
+				{
+					JobBase: config.JobBase{
+						Name: "periodic-job",
+						Spec: &v1.PodSpec{
+							Containers: []v1.Container{
+								{
+									Name: "test",
+									Env: []v1.EnvVar{
+										{Name: "STATIC", Value: "static"},
+										{Name: "OVERRIDDEN", Value: "static"},
+										{Name: "SECRET", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{Key: "token"}}},
+									},
+								},
+								{
+									Name: "helper",
+									Env:  []v1.EnvVar{{Name: "HELPER", Value: "helper"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	cjer := &CreateJobExecutionRequest{
+		JobName:          "periodic-job",
+		JobExecutionType: JobExecutionType_PERIODIC,
+		PodSpecOptions: &PodSpecOptions{
+			Envs: map[string]string{
+				"REQUESTED":  "requested",
+				"OVERRIDDEN": "requested",
+			},
+		},
+	}
+
+	jobExec, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs"), &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"STATIC":     "static",
+		"REQUESTED":  "requested",
+		"OVERRIDDEN": "requested",
+	}
+	if diff := cmp.Diff(expected, jobExec.GetEnv()); diff != "" {
+		t.Errorf("unexpected env (-want +got):\n%s", diff)
+	}
+}
+
+func TestHandleProwJobCommand(t *testing.T) {
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			Periodics: []config.Periodic{
+				{
+					JobBase: config.JobBase{
+						Name: "periodic-job",
+						Spec: &v1.PodSpec{
+							Containers: []v1.Container{
+								{
+									Name:    "test",
+									Command: []string{"runner.sh"},
+									Args:    []string{"make", "test"},
+								},
+								{
+									Name:    "helper",
+									Command: []string{"helper.sh"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	cjer := &CreateJobExecutionRequest{
+		JobName:          "periodic-job",
+		JobExecutionType: JobExecutionType_PERIODIC,
+	}
+
+	pjc := fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs")
+	created, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, pjc, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ca := &config.Agent{}
+	ca.Set(cfg)
+	gw := &Gangway{ConfigAgent: ca, ProwJobClient: pjc}
+	got, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, jobExec := range []*JobExecution{created, got} {
+		if diff := cmp.Diff([]string{"runner.sh"}, jobExec.GetCommand()); diff != "" {
+			t.Errorf("unexpected command (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{"make", "test"}, jobExec.GetArgs()); diff != "" {
+			t.Errorf("unexpected args (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestHandleProwJobPrivilegedAccess(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		spec            *v1.PodSpec
+		expected        bool
+		expectedReasons []string
+	}{
+		{
+			name: "unprivileged container",
+			spec: &v1.PodSpec{
+				Containers: []v1.Container{{
+					Name:            "test",
+					SecurityContext: &v1.SecurityContext{Privileged: ptr.To(false)},
+				}},
+			},
+		},
+		{
+			name: "privileged container on the host network",
+			spec: &v1.PodSpec{
+				HostNetwork: true,
+				Containers: []v1.Container{
+					{Name: "test"},
+					{Name: "dind", SecurityContext: &v1.SecurityContext{Privileged: ptr.To(true)}},
+				},
+			},
+			expected:        true,
+			expectedReasons: []string{"hostNetwork", "container dind: privileged"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{
+				JobConfig: config.JobConfig{
+					Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "periodic-job", Spec: tc.spec}}},
+				},
+			}
+			cjer := &CreateJobExecutionRequest{
+				JobName:          "periodic-job",
+				JobExecutionType: JobExecutionType_PERIODIC,
+			}
+
+			pjc := fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs")
+			created, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, pjc, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ca := &config.Agent{}
+			ca.Set(cfg)
+			gw := &Gangway{ConfigAgent: ca, ProwJobClient: pjc}
+			got, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: created.GetId()})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, jobExec := range []*JobExecution{created, got} {
+				if jobExec.GetPrivilegedAccess() != tc.expected {
+					t.Errorf("expected privileged access to be %t, got %t", tc.expected, jobExec.GetPrivilegedAccess())
+				}
+				if diff := cmp.Diff(tc.expectedReasons, jobExec.GetPrivilegedAccessReasons()); diff != "" {
+					t.Errorf("unexpected privileged access reasons (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestGetJobExecutionAheadInQueue(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	newPJ := func(name, job, queue string, state prowcrd.ProwJobState, age time.Duration) runtime.Object {
+		pj := newProwJob(name, job, "", "", state, false)
+		pj.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		pj.Spec.MaxConcurrency = 1
+		if queue != "" {
+			pj.Labels[kube.JobQueueLabel] = queue
+			pj.Spec.JobQueueName = queue
+		}
+		return pj
+	}
+	pjs := []runtime.Object{
+		newPJ("oldest-in-queue", "other-job", "queue", prowcrd.TriggeredState, 5*time.Minute),
+		newPJ("older-same-job", "job", "", prowcrd.TriggeredState, 4*time.Minute),
+		newPJ("pending-in-queue", "other-job", "queue", prowcrd.PendingState, 4*time.Minute),
+		newPJ("other-queue", "other-job", "other-queue", prowcrd.TriggeredState, 3*time.Minute),
+		newPJ("older-in-queue", "other-job", "queue", prowcrd.TriggeredState, 2*time.Minute),
+		newPJ("mid-queue", "job", "queue", prowcrd.TriggeredState, time.Minute),
+		newPJ("newer-in-queue", "other-job", "queue", prowcrd.TriggeredState, 0),
+	}
+	clientset := fake.NewSimpleClientset(pjs...)
+	var unfilteredLists int
+	clientset.PrependReactor("list", "prowjobs", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if action.(clienttesting.ListActionImpl).GetListRestrictions().Labels.Empty() {
+			unfilteredLists++
+		}
+		return false, nil, nil
+	})
+	ca := &config.Agent{}
+	ca.Set(&config.Config{})
+	gw := &Gangway{
+		ConfigAgent:   ca,
+		ProwJobClient: clientset.ProwV1().ProwJobs("prowjobs"),
+	}
+
+	for id, expected := range map[string]int32{
+		"mid-queue":        3,
+		"oldest-in-queue":  0,
+		"pending-in-queue": 0,
+	} {
+		jobExec, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: id})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if jobExec.GetAheadInQueue() != expected {
+			t.Errorf("expected %d jobs ahead of %s, got %d", expected, id, jobExec.GetAheadInQueue())
+		}
+	}
+	if unfilteredLists != 0 {
+		t.Errorf("expected ProwJobs to only be listed by label, got %d unfiltered lists", unfilteredLists)
+	}
+}
+
+func TestCreateJobExecutionAudit(t *testing.T) {
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			Periodics: []config.Periodic{{JobBase: config.JobBase{
+				Name:           "periodic-job",
+				ProwJobDefault: &prowcrd.ProwJobDefault{TenantID: "tenant"},
+			}}},
+		},
+		ProwConfig: config.ProwConfig{
+			Gangway: config.Gangway{
+				AllowedApiClients: []config.AllowedApiClient{
+					{
+						GCP: &config.ApiClientGcp{
+							EndpointApiConsumerType:   "PROJECT",
+							EndpointApiConsumerNumber: "123",
+						},
+						AllowedJobsFilters: []config.AllowedJobsFilter{{TenantID: "tenant"}},
+					},
+				},
+			},
+		},
+	}
+	ca := &config.Agent{}
+	ca.Set(cfg)
+	gw := &Gangway{
+		ConfigAgent:   ca,
+		ProwJobClient: fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs"),
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		HEADER_API_CONSUMER_TYPE: "PROJECT",
+		HEADER_API_CONSUMER_ID:   "123",
+	}))
+
+	before := time.Now()
+	jobExec, err := gw.CreateJobExecution(ctx, &CreateJobExecutionRequest{
+		JobName:          "periodic-job",
+		JobExecutionType: JobExecutionType_PERIODIC,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &Audit{
+		ConsumerType: "PROJECT",
+		ConsumerId:   "123",
+		Client:       "gcp-PROJECT-123",
+		TenantId:     "tenant",
+	}
+	if diff := cmp.Diff(expected, jobExec.GetAudit(), protocmp.Transform(), protocmp.IgnoreFields(&Audit{}, "create_time")); diff != "" {
+		t.Errorf("unexpected audit (-want +got):\n%s", diff)
+	}
+	if createTime := jobExec.GetAudit().GetCreateTime().AsTime(); createTime.Before(before) || createTime.After(time.Now()) {
+		t.Errorf("expected the create time to be the time of the call, got %v", createTime)
+	}
+}
+
+func TestCreateJobExecutionAllowedClusters(t *testing.T) {
+	periodic := func(name, cluster string) config.Periodic {
+		return config.Periodic{JobBase: config.JobBase{
+			Name:           name,
+			Cluster:        cluster,
+			ProwJobDefault: &prowcrd.ProwJobDefault{TenantID: "tenant"},
+		}}
+	}
+	testCases := []struct {
+		name            string
+		allowedClusters []string
+		jobName         string
+		expectedCode    codes.Code
+	}{
+		{
+			name:            "job on an allowed cluster",
+			allowedClusters: []string{"build-a"},
+			jobName:         "job-on-build-a",
+			expectedCode:    codes.OK,
+		},
+		{
+			name:            "job on another cluster",
+			allowedClusters: []string{"build-a"},
+			jobName:         "job-on-build-b",
+			expectedCode:    codes.PermissionDenied,
+		},
+		{
+			name:            "all clusters allowed explicitly",
+			allowedClusters: []string{"*"},
+			jobName:         "job-on-build-b",
+			expectedCode:    codes.OK,
+		},
+		{
+			name:         "all clusters allowed by default",
+			jobName:      "job-on-build-b",
+			expectedCode: codes.OK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{
+				JobConfig: config.JobConfig{
+					Periodics: []config.Periodic{
+						periodic("job-on-build-a", "build-a"),
+						periodic("job-on-build-b", "build-b"),
+					},
+				},
+				ProwConfig: config.ProwConfig{
+					Gangway: config.Gangway{
+						AllowedApiClients: []config.AllowedApiClient{
+							{
+								GCP: &config.ApiClientGcp{
+									EndpointApiConsumerType:   "PROJECT",
+									EndpointApiConsumerNumber: "123",
+								},
+								AllowedJobsFilters: []config.AllowedJobsFilter{{TenantID: "tenant"}},
+								AllowedClusters:    tc.allowedClusters,
+							},
+						},
+					},
+				},
+			}
+			ca := &config.Agent{}
+			ca.Set(cfg)
+			gw := &Gangway{
+				ConfigAgent:   ca,
+				ProwJobClient: fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs"),
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+				HEADER_API_CONSUMER_TYPE: "PROJECT",
+				HEADER_API_CONSUMER_ID:   "123",
+			}))
+
+			_, err := gw.CreateJobExecution(ctx, &CreateJobExecutionRequest{
+				JobName:          tc.jobName,
+				JobExecutionType: JobExecutionType_PERIODIC,
+			})
+			if code := status.Code(err); code != tc.expectedCode {
+				t.Errorf("expected code %v, got %v (error: %v)", tc.expectedCode, code, err)
+			}
+		})
+	}
+}
+
+func TestCreateJobExecutionRateLimit(t *testing.T) {
+	allowedApiClient := func(consumerNumber string) config.AllowedApiClient {
+		return config.AllowedApiClient{
+			GCP: &config.ApiClientGcp{
+				EndpointApiConsumerType:   "PROJECT",
+				EndpointApiConsumerNumber: consumerNumber,
+			},
+			AllowedJobsFilters: []config.AllowedJobsFilter{{TenantID: "tenant"}},
+			// Low enough to not refill any tokens during the test.
+			QPS:   0.001,
+			Burst: 2,
+		}
+	}
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			Periodics: []config.Periodic{{JobBase: config.JobBase{
+				Name:           "periodic-job",
+				ProwJobDefault: &prowcrd.ProwJobDefault{TenantID: "tenant"},
+			}}},
+		},
+		ProwConfig: config.ProwConfig{
+			Gangway: config.Gangway{
+				AllowedApiClients: []config.AllowedApiClient{allowedApiClient("123"), allowedApiClient("456")},
+			},
+		},
+	}
+	ca := &config.Agent{}
+	ca.Set(cfg)
+	pjc := fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs")
+	gw := &Gangway{
+		ConfigAgent:   ca,
+		ProwJobClient: pjc,
+	}
+	createJobExecution := func(consumerNumber string) error {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+			HEADER_API_CONSUMER_TYPE: "PROJECT",
+			HEADER_API_CONSUMER_ID:   consumerNumber,
+		}))
+		_, err := gw.CreateJobExecution(ctx, &CreateJobExecutionRequest{
+			JobName:          "periodic-job",
+			JobExecutionType: JobExecutionType_PERIODIC,
+		})
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := createJobExecution("123"); err != nil {
+			t.Fatalf("request %d within the burst: unexpected error: %v", i, err)
+		}
+	}
+	if err := createJobExecution("123"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected %s error past the burst, got %v", codes.ResourceExhausted, err)
+	}
+	if err := createJobExecution("456"); err != nil {
+		t.Errorf("other client: unexpected error: %v", err)
+	}
+
+	prowJobs, err := pjc.List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list ProwJobs: %v", err)
+	}
+	if len(prowJobs.Items) != 3 {
+		t.Errorf("expected 3 ProwJobs, got %d", len(prowJobs.Items))
+	}
+}
+
+func TestCreateJobExecutionIdempotencyKey(t *testing.T) {
+	allowedApiClient := func(consumerNumber string) config.AllowedApiClient {
+		return config.AllowedApiClient{
+			GCP: &config.ApiClientGcp{
+				EndpointApiConsumerType:   "PROJECT",
+				EndpointApiConsumerNumber: consumerNumber,
+			},
+			AllowedJobsFilters: []config.AllowedJobsFilter{{TenantID: "tenant"}},
+		}
+	}
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			Periodics: []config.Periodic{{JobBase: config.JobBase{
+				Name:           "periodic-job",
+				ProwJobDefault: &prowcrd.ProwJobDefault{TenantID: "tenant"},
+			}}},
+		},
+		ProwConfig: config.ProwConfig{
+			Gangway: config.Gangway{
+				AllowedApiClients: []config.AllowedApiClient{allowedApiClient("123"), allowedApiClient("456")},
+			},
+		},
+	}
+	ca := &config.Agent{}
+	ca.Set(cfg)
+	pjc := fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs")
+	gw := &Gangway{
+		ConfigAgent:   ca,
+		ProwJobClient: pjc,
+	}
+	createJobExecution := func(consumerNumber, idempotencyKey string) string {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+			HEADER_API_CONSUMER_TYPE: "PROJECT",
+			HEADER_API_CONSUMER_ID:   consumerNumber,
+		}))
+		jobExec, err := gw.CreateJobExecution(ctx, &CreateJobExecutionRequest{
+			JobName:          "periodic-job",
+			JobExecutionType: JobExecutionType_PERIODIC,
+			IdempotencyKey:   idempotencyKey,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return jobExec.GetId()
+	}
+
+	first := createJobExecution("123", "some-key")
+	if retried := createJobExecution("123", "some-key"); retried != first {
+		t.Errorf("expected the retried request to return job execution %q, got %q", first, retried)
+	}
+	if other := createJobExecution("123", "other-key"); other == first {
+		t.Errorf("expected a request with another key to create a new job execution, got %q", other)
+	}
+	if otherClient := createJobExecution("456", "some-key"); otherClient == first {
+		t.Errorf("expected a request of another client with the same key to create a new job execution, got %q", otherClient)
+	}
+
+	prowJobs, err := pjc.List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list ProwJobs: %v", err)
+	}
+	if len(prowJobs.Items) != 3 {
+		t.Errorf("expected 3 ProwJobs, got %d", len(prowJobs.Items))
+	}
+}
+
+func TestBulkJobStatusChangeProgress(t *testing.T) {
+	prowJob := func(name, tenantID string, state prowcrd.ProwJobState) runtime.Object {
+		return &prowcrd.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prowjobs"},
+			Spec:       prowcrd.ProwJobSpec{ProwJobDefault: &prowcrd.ProwJobDefault{TenantID: tenantID}},
+			Status:     prowcrd.ProwJobStatus{State: state},
+		}
+	}
+	cfg := &config.Config{
+		ProwConfig: config.ProwConfig{
+			Gangway: config.Gangway{
+				AllowedApiClients: []config.AllowedApiClient{
+					{
+						GCP: &config.ApiClientGcp{
+							EndpointApiConsumerType:   "PROJECT",
+							EndpointApiConsumerNumber: "123",
+						},
+						AllowedJobsFilters: []config.AllowedJobsFilter{{TenantID: "tenant"}},
+					},
+				},
+			},
+		},
+	}
+	ca := &config.Agent{}
+	ca.Set(cfg)
+	gw := &Gangway{
+		ConfigAgent: ca,
+		ProwJobClient: fake.NewSimpleClientset(
+			prowJob("pending-1", "tenant", prowcrd.PendingState),
+			prowJob("pending-2", "tenant", prowcrd.PendingState),
+			prowJob("pending-3", "tenant", prowcrd.PendingState),
+			prowJob("pending-of-other-tenant", "other-tenant", prowcrd.PendingState),
+			prowJob("triggered", "tenant", prowcrd.TriggeredState),
+		).ProwV1().ProwJobs("prowjobs"),
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		HEADER_API_CONSUMER_TYPE: "PROJECT",
+		HEADER_API_CONSUMER_ID:   "123",
+	}))
+
+	if _, err := gw.GetBulkJobStatusChange(ctx, &GetBulkJobStatusChangeRequest{Id: "change"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound before the change, got %v", err)
+	}
+
+	request := &BulkJobStatusChangeRequest{
+		Id:              "change",
+		JobStatusChange: &JobStatusChange{Current: JobExecutionStatus_PENDING, Desired: JobExecutionStatus_ABORTED},
+	}
+	if _, err := gw.BulkJobStatusChange(ctx, request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := gw.BulkJobStatusChange(ctx, request); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists when reusing the id, got %v", err)
+	}
+
+	var progress *BulkJobStatusChangeProgress
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 10*time.Second, true, func(ctx context.Context) (bool, error) {
+		var err error
+		progress, err = gw.GetBulkJobStatusChange(ctx, &GetBulkJobStatusChangeRequest{Id: "change"})
+		if err != nil {
+			return false, err
+		}
+		return progress.GetState() != BulkJobStatusChangeState_BULK_JOB_STATUS_CHANGE_RUNNING, nil
+	}); err != nil {
+		t.Fatalf("failed waiting for the change to finish: %v", err)
+	}
+
+	expected := &BulkJobStatusChangeProgress{
+		Id:      "change",
+		State:   BulkJobStatusChangeState_BULK_JOB_STATUS_CHANGE_SUCCEEDED,
+		Matched: 4,
+		Updated: 3,
+		Skipped: 1,
+	}
+	if diff := cmp.Diff(expected, progress, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected progress (-want +got):\n%s", diff)
+	}
+}
+
+func TestBulkJobStatusChangeRetention(t *testing.T) {
+	gw := &Gangway{}
+	ctx := context.Background()
+	finished := func(id string, age time.Duration) *bulkJobStatusChange {
+		return &bulkJobStatusChange{
+			progress: &BulkJobStatusChangeProgress{Id: id, State: BulkJobStatusChangeState_BULK_JOB_STATUS_CHANGE_SUCCEEDED},
+			finished: time.Now().Add(-age),
+		}
+	}
+
+	gw.bulkJobStatusChanges = map[string]*bulkJobStatusChange{
+		"recent":  finished("recent", time.Minute),
+		"expired": finished("expired", 2*bulkJobStatusChangeRetention),
+	}
+	if _, err := gw.GetBulkJobStatusChange(ctx, &GetBulkJobStatusChangeRequest{Id: "recent"}); err != nil {
+		t.Errorf("expected a recently finished change to be kept, got %v", err)
+	}
+	if _, err := gw.GetBulkJobStatusChange(ctx, &GetBulkJobStatusChangeRequest{Id: "expired"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for a change that finished too long ago, got %v", err)
+	}
+	if _, exists := gw.bulkJobStatusChanges["expired"]; exists {
+		t.Error("expected the change that finished too long ago to be forgotten")
+	}
+
+	gw.bulkJobStatusChanges = map[string]*bulkJobStatusChange{}
+	for i := range maxBulkJobStatusChanges {
+		if err := gw.startBulkJobStatusChange(fmt.Sprintf("running-%d", i)); err != nil {
+			t.Fatalf("unexpected error starting change %d: %v", i, err)
+		}
+	}
+	if err := gw.startBulkJobStatusChange("one-too-many"); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted while all tracked changes are running, got %v", err)
+	}
+	gw.finishBulkJobStatusChange("running-1", BulkJobStatusChangeState_BULK_JOB_STATUS_CHANGE_SUCCEEDED)
+	gw.finishBulkJobStatusChange("running-0", BulkJobStatusChangeState_BULK_JOB_STATUS_CHANGE_SUCCEEDED)
+	gw.bulkJobStatusChanges["running-1"].finished = time.Now().Add(-2 * time.Minute)
+	gw.bulkJobStatusChanges["running-0"].finished = time.Now().Add(-time.Minute)
+	if err := gw.startBulkJobStatusChange("one-too-many"); err != nil {
+		t.Fatalf("expected a finished change to make room, got %v", err)
+	}
+	if _, exists := gw.bulkJobStatusChanges["running-1"]; exists {
+		t.Error("expected the change that finished first to be forgotten")
+	}
+	if _, exists := gw.bulkJobStatusChanges["running-0"]; !exists {
+		t.Error("expected the change that finished last to be kept")
+	}
+	if got := len(gw.bulkJobStatusChanges); got != maxBulkJobStatusChanges {
+		t.Errorf("expected %d tracked changes, got %d", maxBulkJobStatusChanges, got)
+	}
+}
+
+func TestCreateJobExecutionRequestValidatePodSpecOptions(t *testing.T) {
+	testCases := []struct {
+		name           string
+		podSpecOptions *PodSpecOptions
+		expectedErr    string
+	}{
+		{
+			name: "custom labels and annotations",
+			podSpecOptions: &PodSpecOptions{
+				Labels:      map[string]string{"example.com/team": "infra"},
+				Annotations: map[string]string{"example.com/owner": "someone"},
+			},
+		},
+		{
+			name:           "label under prow.k8s.io/",
+			podSpecOptions: &PodSpecOptions{Labels: map[string]string{"prow.k8s.io/job": "other-job"}},
+			expectedErr:    `invalid label: key "prow.k8s.io/job" uses the prefix "prow.k8s.io/" reserved for Prow`,
+		},
+		{
+			name:           "created-by-prow label",
+			podSpecOptions: &PodSpecOptions{Labels: map[string]string{"created-by-prow": "false"}},
+			expectedErr:    `invalid label: key "created-by-prow" uses the prefix "created-by-prow" reserved for Prow`,
+		},
+		{
+			name:           "annotation under prow.k8s.io/",
+			podSpecOptions: &PodSpecOptions{Annotations: map[string]string{"prow.k8s.io/context": "other-context"}},
+			expectedErr:    `invalid annotation: key "prow.k8s.io/context" uses the prefix "prow.k8s.io/" reserved for Prow`,
+		},
+		{
+			name: "oversized annotations",
+			podSpecOptions: &PodSpecOptions{Annotations: map[string]string{
+				"example.com/first":  strings.Repeat("a", 128*1024),
+				"example.com/second": strings.Repeat("b", 128*1024),
+			}},
+			expectedErr: "invalid annotations: annotations size 262179 is larger than limit 262144",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cjer := &CreateJobExecutionRequest{
+				JobName:          "periodic-job",
+				JobExecutionType: JobExecutionType_PERIODIC,
+				PodSpecOptions:   tc.podSpecOptions,
+			}
+			var errMsg string
+			if err := cjer.Validate(); err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, errMsg)
+			}
+		})
+	}
+}
+
+func TestHandleProwJobTimeOrderedIds(t *testing.T) {
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "periodic-job"}}},
+		},
+	}
+	pjc := fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs")
+	cjer := &CreateJobExecutionRequest{
+		JobName:          "periodic-job",
+		JobExecutionType: JobExecutionType_PERIODIC,
+	}
+
+	var previousId string
+	for i := 0; i < 20; i++ {
+		jobExec, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, pjc, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if jobExec.GetId() <= previousId {
+			t.Errorf("expected id %q to sort after the previous id %q", jobExec.GetId(), previousId)
+		}
+		previousId = jobExec.GetId()
+	}
+}
+
+func TestJobExecutionUid(t *testing.T) {
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "periodic-job"}}},
+		},
+	}
+	ca := &config.Agent{}
+	ca.Set(cfg)
+	clientset := fake.NewSimpleClientset()
+	// The fake clientset doesn't assign UIDs like the API server does.
+	clientset.PrependReactor("create", "prowjobs", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		pj := action.(clienttesting.CreateAction).GetObject().(*prowcrd.ProwJob)
+		pj.UID = types.UID("uid-" + pj.Name)
+		return false, nil, nil
+	})
+	gw := &Gangway{
+		ConfigAgent:   ca,
+		ProwJobClient: clientset.ProwV1().ProwJobs("prowjobs"),
+	}
+	cjer := &CreateJobExecutionRequest{
+		JobName:          "periodic-job",
+		JobExecutionType: JobExecutionType_PERIODIC,
+	}
+
+	created, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, gw.ProwJobClient, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pj, err := gw.ProwJobClient.Get(context.Background(), created.GetId(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get created prowjob: %v", err)
+	}
+	if pj.UID == "" {
+		t.Fatal("expected created prowjob to have a UID")
+	}
+	if created.GetUid() != string(pj.UID) {
+		t.Errorf("expected created job execution to have UID %q, got %q", pj.UID, created.GetUid())
+	}
+
+	fetched, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fetched.GetUid() != string(pj.UID) {
+		t.Errorf("expected fetched job execution to have UID %q, got %q", pj.UID, fetched.GetUid())
+	}
+}
+
+func TestIsPeriodicDue(t *testing.T) {
+	now := time.Now()
+	startedAt := func(pj *prowcrd.ProwJob, start time.Time) runtime.Object {
+		pj.Status.StartTime = metav1.NewTime(start)
+		return pj
+	}
+
+	for _, tc := range []struct {
+		name            string
+		periodic        config.Periodic
+		prowJobs        []runtime.Object
+		expectedDue     bool
+		expectedNextRun time.Time
+	}{
+		{
+			name:            "interval periodic that never ran is due",
+			periodic:        config.Periodic{JobBase: config.JobBase{Name: "periodic-job"}, Interval: "1h"},
+			expectedDue:     true,
+			expectedNextRun: now,
+		},
+		{
+			name:     "interval periodic is due once the interval passed",
+			periodic: config.Periodic{JobBase: config.JobBase{Name: "periodic-job"}, Interval: "1h"},
+			prowJobs: []runtime.Object{
+				startedAt(newProwJob("older", "periodic-job", "", prowcrd.PeriodicJob, prowcrd.SuccessState, true), now.Add(-3*time.Hour)),
+				startedAt(newProwJob("latest", "periodic-job", "", prowcrd.PeriodicJob, prowcrd.SuccessState, true), now.Add(-2*time.Hour)),
+			},
+			expectedDue:     true,
+			expectedNextRun: now.Add(-time.Hour),
+		},
+		{
+			name:     "interval periodic is not due before the interval passed",
+			periodic: config.Periodic{JobBase: config.JobBase{Name: "periodic-job"}, Interval: "1h"},
+			prowJobs: []runtime.Object{
+				startedAt(newProwJob("latest", "periodic-job", "", prowcrd.PeriodicJob, prowcrd.SuccessState, true), now.Add(-10*time.Minute)),
+				startedAt(newProwJob("other-job", "other-job", "", prowcrd.PeriodicJob, prowcrd.SuccessState, true), now.Add(-2*time.Hour)),
+			},
+			expectedDue:     false,
+			expectedNextRun: now.Add(50 * time.Minute),
+		},
+		{
+			name:     "interval periodic is not due while its latest run is still running",
+			periodic: config.Periodic{JobBase: config.JobBase{Name: "periodic-job"}, Interval: "1h"},
+			prowJobs: []runtime.Object{
+				startedAt(newProwJob("latest", "periodic-job", "", prowcrd.PeriodicJob, prowcrd.PendingState, false), now.Add(-2*time.Hour)),
+			},
+			expectedDue:     false,
+			expectedNextRun: now.Add(-time.Hour),
+		},
+		{
+			name:     "cron periodic is due once a scheduled time passed",
+			periodic: config.Periodic{JobBase: config.JobBase{Name: "periodic-job"}, Cron: "0 * * * *"},
+			prowJobs: []runtime.Object{
+				startedAt(newProwJob("latest", "periodic-job", "", prowcrd.PeriodicJob, prowcrd.SuccessState, true), now.Add(-2*time.Hour)),
+			},
+			expectedDue:     true,
+			expectedNextRun: now.Add(-2 * time.Hour).UTC().Truncate(time.Hour).Add(time.Hour),
+		},
+		{
+			name:     "cron periodic is not due before the next scheduled time",
+			periodic: config.Periodic{JobBase: config.JobBase{Name: "periodic-job"}, Cron: "0 * * * *"},
+			prowJobs: []runtime.Object{
+				startedAt(newProwJob("latest", "periodic-job", "", prowcrd.PeriodicJob, prowcrd.SuccessState, true), now),
+			},
+			expectedDue:     false,
+			expectedNextRun: now.UTC().Truncate(time.Hour).Add(time.Hour),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.periodic.Interval != "" {
+				interval, err := time.ParseDuration(tc.periodic.Interval)
+				if err != nil {
+					t.Fatalf("failed to parse interval: %v", err)
+				}
+				tc.periodic.SetInterval(interval)
+			}
+			ca := &config.Agent{}
+			ca.Set(&config.Config{
+				JobConfig: config.JobConfig{
+					Periodics: []config.Periodic{tc.periodic},
+				},
+			})
+			gw := &Gangway{
+				ConfigAgent:   ca,
+				ProwJobClient: fake.NewSimpleClientset(tc.prowJobs...).ProwV1().ProwJobs("prowjobs"),
+			}
+
+			due, err := gw.IsPeriodicDue(context.Background(), &IsPeriodicDueRequest{JobName: "periodic-job"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if due.GetDue() != tc.expectedDue {
+				t.Errorf("expected due to be %t, got %t", tc.expectedDue, due.GetDue())
+			}
+			if nextRun := due.GetNextRun().AsTime(); nextRun.Sub(tc.expectedNextRun).Abs() > time.Minute {
+				t.Errorf("expected next run at %v, got %v", tc.expectedNextRun, nextRun)
+			}
+		})
+	}
+}
+
+func TestJobExecutionCluster(t *testing.T) {
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "periodic-job", Cluster: "build-cluster"}}},
+		},
+	}
+	ca := &config.Agent{}
+	ca.Set(cfg)
+	gw := &Gangway{
+		ConfigAgent:   ca,
+		ProwJobClient: fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs"),
+	}
+	cjer := &CreateJobExecutionRequest{
+		JobName:          "periodic-job",
+		JobExecutionType: JobExecutionType_PERIODIC,
+	}
+
+	created, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, gw.ProwJobClient, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.GetCluster() != "build-cluster" {
+		t.Errorf("expected created job execution to run on cluster %q, got %q", "build-cluster", created.GetCluster())
+	}
+
+	fetched, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fetched.GetCluster() != "build-cluster" {
+		t.Errorf("expected fetched job execution to run on cluster %q, got %q", "build-cluster", fetched.GetCluster())
+	}
+}
+
+func TestGetJobExecutionLastUpdateTime(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	pending := metav1.NewTime(start.Add(time.Minute))
+	reconciled := metav1.NewTime(start.Add(5 * time.Minute))
+
+	testCases := []struct {
+		name     string
+		status   prowcrd.ProwJobStatus
+		expected *timestamppb.Timestamp
+	}{
+		{
+			name:     "reconciled job uses the last reconcile time",
+			status:   prowcrd.ProwJobStatus{State: prowcrd.PendingState, StartTime: metav1.NewTime(start), PendingTime: &pending, LastReconcileTime: &reconciled},
+			expected: timestamppb.New(reconciled.Time),
+		},
+		{
+			name:     "job not reconciled yet falls back to the latest status timestamp",
+			status:   prowcrd.ProwJobStatus{State: prowcrd.PendingState, StartTime: metav1.NewTime(start), PendingTime: &pending},
+			expected: timestamppb.New(pending.Time),
+		},
+		{
+			name:     "job without timestamps has no last update time",
+			status:   prowcrd.ProwJobStatus{State: prowcrd.TriggeredState},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &prowcrd.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-job", Namespace: "prowjobs"},
+				Status:     tc.status,
+			}
+			ca := &config.Agent{}
+			ca.Set(&config.Config{})
+			gw := &Gangway{
+				ConfigAgent:   ca,
+				ProwJobClient: fake.NewSimpleClientset(pj).ProwV1().ProwJobs("prowjobs"),
+			}
+
+			jobExec, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: "some-job"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, jobExec.GetLastUpdateTime(), protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected last update time (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetJobExecutionSubmitTime(t *testing.T) {
+	submitted := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	started := submitted.Add(3 * time.Minute)
+	pj := &prowcrd.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-job", Namespace: "prowjobs", CreationTimestamp: metav1.NewTime(submitted)},
+		Status:     prowcrd.ProwJobStatus{State: prowcrd.TriggeredState, StartTime: metav1.NewTime(started)},
+	}
+	ca := &config.Agent{}
+	ca.Set(&config.Config{})
+	gw := &Gangway{
+		ConfigAgent:   ca,
+		ProwJobClient: fake.NewSimpleClientset(pj).ProwV1().ProwJobs("prowjobs"),
+	}
+
+	jobExec, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: "some-job"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(timestamppb.New(submitted), jobExec.GetSubmitTime(), protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected submit time (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(timestamppb.New(started), jobExec.GetCreateTime(), protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected create time (-want +got):\n%s", diff)
+	}
+}
+
+type fakeArtifactLookup struct {
+	jobExecs map[string]*JobExecution
+	err      error
+}
+
+func (f *fakeArtifactLookup) GetFinishedJob(_ context.Context, id string) (*JobExecution, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.jobExecs[id], nil
+}
+
+func TestGetJobExecutionArtifactFallback(t *testing.T) {
+	pj := &prowcrd.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "live-job", Namespace: "prowjobs"},
+		Spec:       prowcrd.ProwJobSpec{Job: "live"},
+		Status:     prowcrd.ProwJobStatus{State: prowcrd.PendingState},
+	}
+	lookup := &fakeArtifactLookup{jobExecs: map[string]*JobExecution{
+		"live-job": {Id: "live-job", JobName: "stale", JobStatus: JobExecutionStatus_SUCCESS},
+		"gone-job": {Id: "gone-job", JobName: "gone", JobStatus: JobExecutionStatus_FAILURE},
+	}}
+
+	testCases := []struct {
+		name           string
+		id             string
+		artifactLookup ArtifactLookup
+		expected       *JobExecution
+		expectNotFound bool
+	}{
+		{
+			name:           "ProwJob is preferred over its artifacts",
+			id:             "live-job",
+			artifactLookup: lookup,
+			expected:       &JobExecution{Id: "live-job", JobName: "live", JobStatus: JobExecutionStatus_PENDING},
+		},
+		{
+			name:           "artifacts of a job whose ProwJob is gone",
+			id:             "gone-job",
+			artifactLookup: lookup,
+			expected:       &JobExecution{Id: "gone-job", JobName: "gone", JobStatus: JobExecutionStatus_FAILURE},
+		},
+		{
+			name:           "no artifacts either",
+			id:             "unknown-job",
+			artifactLookup: lookup,
+			expectNotFound: true,
+		},
+		{
+			name:           "failure to look up the artifacts",
+			id:             "gone-job",
+			artifactLookup: &fakeArtifactLookup{err: fmt.Errorf("injected error")},
+			expectNotFound: true,
+		},
+		{
+			name:           "no artifact lookup",
+			id:             "gone-job",
+			expectNotFound: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ca := &config.Agent{}
+			ca.Set(&config.Config{})
+			gw := &Gangway{
+				ConfigAgent:    ca,
+				ProwJobClient:  fake.NewSimpleClientset(pj.DeepCopy()).ProwV1().ProwJobs("prowjobs"),
+				ArtifactLookup: tc.artifactLookup,
+			}
+
+			jobExec, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: tc.id})
+			if tc.expectNotFound {
+				if !kerrors.IsNotFound(err) {
+					t.Fatalf("expected a NotFound error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := &JobExecution{Id: jobExec.GetId(), JobName: jobExec.GetJobName(), JobStatus: jobExec.GetJobStatus()}
+			if diff := cmp.Diff(tc.expected, got, protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected job execution (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGCSArtifactLookup(t *testing.T) {
+	opener := &fakeopener.FakeOpener{Buffer: map[string]*bytes.Buffer{
+		"gs://index/finished-job":                      bytes.NewBufferString("gs://bucket/logs/some-job/1234\n"),
+		"gs://bucket/logs/some-job/1234/started.json":  bytes.NewBufferString(`{"timestamp": 1700000000}`),
+		"gs://bucket/logs/some-job/1234/finished.json": bytes.NewBufferString(`{"timestamp": 1700000600, "passed": false, "result": "ABORTED"}`),
+		"gs://index/running-job":                       bytes.NewBufferString("gs://bucket/logs/some-job/5678"),
+		"gs://bucket/logs/some-job/5678/started.json":  bytes.NewBufferString(`{"timestamp": 1700000000}`),
+		"gs://index/broken-job":                        bytes.NewBufferString("gs://bucket/logs/some-job/9012"),
+		"gs://bucket/logs/some-job/9012/started.json":  bytes.NewBufferString(`{"timestamp": 1700000000}`),
+		"gs://bucket/logs/some-job/9012/finished.json": bytes.NewBufferString(`not json`),
+	}}
+
+	testCases := []struct {
+		name        string
+		id          string
+		expected    *JobExecution
+		expectedErr bool
+	}{
+		{
+			name: "finished job",
+			id:   "finished-job",
+			expected: &JobExecution{
+				Id:             "finished-job",
+				JobName:        "some-job",
+				JobStatus:      JobExecutionStatus_ABORTED,
+				GcsPrefix:      "gs://bucket/logs/some-job/1234",
+				CreateTime:     timestamppb.New(time.Unix(1700000000, 0)),
+				CompletionTime: timestamppb.New(time.Unix(1700000600, 0)),
+			},
+		},
+		{
+			name: "job that did not finish",
+			id:   "running-job",
+		},
+		{
+			name: "job missing from the index",
+			id:   "unknown-job",
+		},
+		{
+			name:        "malformed finished.json",
+			id:          "broken-job",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lookup := &GCSArtifactLookup{Opener: opener, IndexDir: "gs://index/"}
+			jobExec, err := lookup.GetFinishedJob(context.Background(), tc.id)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, jobExec, protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected job execution (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetJobExecutionGcsPrefix(t *testing.T) {
+	gcsConfig := &prowcrd.GCSConfiguration{Bucket: "some-bucket", PathStrategy: prowcrd.PathStrategyExplicit}
+	testCases := []struct {
+		name     string
+		spec     prowcrd.ProwJobSpec
+		buildID  string
+		expected string
+	}{
+		{
+			name: "presubmit uses the pulls layout",
+			spec: prowcrd.ProwJobSpec{
+				Type:             prowcrd.PresubmitJob,
+				Job:              "pull-job",
+				Refs:             &prowcrd.Refs{Org: "org", Repo: "repo", Pulls: []prowcrd.Pull{{Number: 42}}},
+				DecorationConfig: &prowcrd.DecorationConfig{GCSConfiguration: gcsConfig},
+			},
+			buildID:  "1234",
+			expected: "gs://some-bucket/pr-logs/pull/org_repo/42/pull-job/1234",
+		},
+		{
+			name: "periodic uses the logs layout",
+			spec: prowcrd.ProwJobSpec{
+				Type:             prowcrd.PeriodicJob,
+				Job:              "periodic-job",
+				DecorationConfig: &prowcrd.DecorationConfig{GCSConfiguration: &prowcrd.GCSConfiguration{Bucket: "s3://other-bucket", PathPrefix: "prefix"}},
+			},
+			buildID:  "5678",
+			expected: "s3://other-bucket/prefix/logs/periodic-job/5678",
+		},
+		{
+			name: "job without build ID has no prefix yet",
+			spec: prowcrd.ProwJobSpec{
+				Type:             prowcrd.PeriodicJob,
+				Job:              "periodic-job",
+				DecorationConfig: &prowcrd.DecorationConfig{GCSConfiguration: gcsConfig},
+			},
+		},
+		{
+			name: "undecorated job has no prefix",
+			spec: prowcrd.ProwJobSpec{
+				Type: prowcrd.PeriodicJob,
+				Job:  "periodic-job",
+			},
+			buildID: "5678",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &prowcrd.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-job", Namespace: "prowjobs"},
+				Spec:       tc.spec,
+				Status:     prowcrd.ProwJobStatus{State: prowcrd.PendingState, BuildID: tc.buildID},
+			}
+			ca := &config.Agent{}
+			ca.Set(&config.Config{})
+			gw := &Gangway{
+				ConfigAgent:   ca,
+				ProwJobClient: fake.NewSimpleClientset(pj).ProwV1().ProwJobs("prowjobs"),
+			}
+
+			jobExec, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: "some-job"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, jobExec.GetGcsPrefix()); diff != "" {
+				t.Errorf("unexpected GCS prefix (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetJobExecutionReportLinks(t *testing.T) {
+	testCases := []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		spec        prowcrd.ProwJobSpec
+		expected    map[string]string
+	}{
+		{
+			name: "presubmit reports its status to the pull request commit on GitHub",
+			spec: prowcrd.ProwJobSpec{
+				Type:   prowcrd.PresubmitJob,
+				Report: true,
+				Refs: &prowcrd.Refs{
+					Org:      "org",
+					Repo:     "repo",
+					RepoLink: "https://github.com/org/repo",
+					Pulls:    []prowcrd.Pull{{Number: 42, SHA: "abcdef", CommitLink: "https://github.com/org/repo/pull/42/commits/abcdef"}},
+				},
+			},
+			expected: map[string]string{"github": "https://github.com/org/repo/pull/42/commits/abcdef"},
+		},
+		{
+			name: "postsubmit without base link falls back to the repo link",
+			spec: prowcrd.ProwJobSpec{
+				Type:   prowcrd.PostsubmitJob,
+				Report: true,
+				Refs:   &prowcrd.Refs{Org: "org", Repo: "repo", RepoLink: "https://github.com/org/repo", BaseSHA: "abcdef"},
+			},
+			expected: map[string]string{"github": "https://github.com/org/repo/commit/abcdef"},
+		},
+		{
+			name:        "gerrit job reports to the change",
+			labels:      map[string]string{kube.GerritRevision: "abcdef", kube.GerritReportLabel: "Verified"},
+			annotations: map[string]string{kube.GerritInstance: "https://review.example.com"},
+			spec: prowcrd.ProwJobSpec{
+				Type:   prowcrd.PresubmitJob,
+				Report: true,
+				Refs: &prowcrd.Refs{
+					Org:   "https://review.example.com",
+					Repo:  "project",
+					Pulls: []prowcrd.Pull{{Number: 42, SHA: "abcdef", Link: "https://review.example.com/c/project/+/42"}},
+				},
+			},
+			expected: map[string]string{"gerrit": "https://review.example.com/c/project/+/42"},
+		},
+		{
+			name: "job that does not report has no links",
+			spec: prowcrd.ProwJobSpec{
+				Type: prowcrd.PostsubmitJob,
+				Refs: &prowcrd.Refs{Org: "org", Repo: "repo", BaseLink: "https://github.com/org/repo/commit/abcdef"},
+			},
+		},
+		{
+			name: "periodic has no links",
+			spec: prowcrd.ProwJobSpec{
+				Type:   prowcrd.PeriodicJob,
+				Report: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &prowcrd.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-job", Namespace: "prowjobs", Labels: tc.labels, Annotations: tc.annotations},
+				Spec:       tc.spec,
+				Status:     prowcrd.ProwJobStatus{State: prowcrd.PendingState},
+			}
+			ca := &config.Agent{}
+			ca.Set(&config.Config{})
+			gw := &Gangway{
+				ConfigAgent:   ca,
+				ProwJobClient: fake.NewSimpleClientset(pj).ProwV1().ProwJobs("prowjobs"),
+			}
+
+			jobExec, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: "some-job"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, jobExec.GetReportLinks()); diff != "" {
+				t.Errorf("unexpected report links (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetJobExecutionContainerStatuses(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-pod", Namespace: "pods"},
+		Status: v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{
+				{Name: "clonerefs", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{}}},
+			},
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "test", Ready: true, RestartCount: 1, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+				{Name: "sidecar", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+				{Name: "helper"},
+			},
+		},
+	}
+	testCases := []struct {
+		name     string
+		cluster  string
+		podName  string
+		expected []*ContainerStatus
+	}{
+		{
+			name:    "statuses of all containers of the pod",
+			podName: "some-pod",
+			expected: []*ContainerStatus{
+				{Name: "clonerefs", State: "terminated"},
+				{Name: "test", State: "running", Ready: true, RestartCount: 1},
+				{Name: "sidecar", State: "waiting"},
+				{Name: "helper", State: "unknown"},
+			},
+		},
+		{
+			name: "job without pod has no statuses",
+		},
+		{
+			name:    "job whose pod is gone has no statuses",
+			podName: "other-pod",
+		},
+		{
+			name:    "job on a cluster without pod client has no statuses",
+			cluster: "other-cluster",
+			podName: "some-pod",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &prowcrd.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-job", Namespace: "prowjobs"},
+				Spec:       prowcrd.ProwJobSpec{Type: prowcrd.PeriodicJob, Job: "periodic-job", Cluster: tc.cluster},
+				Status:     prowcrd.ProwJobStatus{State: prowcrd.PendingState, PodName: tc.podName},
+			}
+			ca := &config.Agent{}
+			ca.Set(&config.Config{})
+			gw := &Gangway{
+				ConfigAgent:   ca,
+				ProwJobClient: fake.NewSimpleClientset(pj).ProwV1().ProwJobs("prowjobs"),
+				PodClients: map[string]PodClient{
+					prowcrd.DefaultClusterAlias: k8sfake.NewSimpleClientset(pod).CoreV1().Pods("pods"),
+				},
+			}
+
+			jobExec, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: "some-job"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, jobExec.GetContainerStatuses(), protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected container statuses (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetJobExecutionStatus(t *testing.T) {
+	completionTime := metav1.Now()
+	pj := &prowcrd.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-job", Namespace: "prowjobs"},
+		Spec: prowcrd.ProwJobSpec{
+			Job:  "job-name",
+			Type: prowcrd.PostsubmitJob,
+			Refs: &prowcrd.Refs{Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "a2f7c5b3e9d4c6a1b8e0f2d4c6a8b0e2d4f6a8c0"},
+		},
+		Status: prowcrd.ProwJobStatus{
+			State:          prowcrd.FailureState,
+			StartTime:      metav1.Now(),
+			CompletionTime: &completionTime,
+			URL:            "https://prow.example.com/view/some-job",
+		},
+	}
+	ca := &config.Agent{}
+	ca.Set(&config.Config{})
+	gw := &Gangway{
+		ConfigAgent:   ca,
+		ProwJobClient: fake.NewSimpleClientset(pj).ProwV1().ProwJobs("prowjobs"),
+	}
+
+	full, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: "some-job"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lightweight, err := gw.GetJobExecutionStatus(context.Background(), &GetJobExecutionStatusRequest{Id: "some-job"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &JobStatusResponse{
+		Status:   full.GetJobStatus(),
+		Complete: full.GetCompletionTime() != nil,
+		Url:      full.GetJobUrl(),
+	}
+	if diff := cmp.Diff(expected, lightweight, protocmp.Transform()); diff != "" {
+		t.Errorf("lightweight response does not match the full job execution (-want +got):\n%s", diff)
+	}
+	if fields := lightweight.ProtoReflect().Descriptor().Fields(); fields.Len() != 3 {
+		t.Errorf("expected the lightweight response to only have the status fields, got %d fields", fields.Len())
+	}
+
+	_, err = gw.GetJobExecutionStatus(context.Background(), &GetJobExecutionStatusRequest{Id: "missing-job"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected code %s for a missing job execution, got %v", codes.NotFound, err)
+	}
+}
+
+func TestJobExecutionConcurrencyLimits(t *testing.T) {
+	for _, tc := range []struct {
+		name                     string
+		maxConcurrency           int
+		jobQueueName             string
+		expectedMaxConcurrency   int32
+		expectedJobQueueCapacity int32
+	}{
+		{
+			name:                     "no limits",
+			expectedJobQueueCapacity: -1,
+		},
+		{
+			name:                     "limited job in a limited queue",
+			maxConcurrency:           2,
+			jobQueueName:             "gpu",
+			expectedMaxConcurrency:   2,
+			expectedJobQueueCapacity: 3,
+		},
+		{
+			name:                     "job in an unlimited queue",
+			jobQueueName:             "unlimited",
+			expectedJobQueueCapacity: -1,
+		},
+		{
+			name:                     "job in a blocked queue",
+			jobQueueName:             "blocked",
+			expectedJobQueueCapacity: 0,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{
+				JobConfig: config.JobConfig{
+					Periodics: []config.Periodic{{JobBase: config.JobBase{
+						Name:           "periodic-job",
+						MaxConcurrency: tc.maxConcurrency,
+						JobQueueName:   tc.jobQueueName,
+					}}},
+				},
+				ProwConfig: config.ProwConfig{
+					Plank: config.Plank{
+						JobQueueCapacities: map[string]int{"gpu": 3, "unlimited": -1, "blocked": 0},
+					},
+				},
+			}
+			ca := &config.Agent{}
+			ca.Set(cfg)
+			gw := &Gangway{
+				ConfigAgent:   ca,
+				ProwJobClient: fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs"),
+			}
+			cjer := &CreateJobExecutionRequest{
+				JobName:          "periodic-job",
+				JobExecutionType: JobExecutionType_PERIODIC,
+			}
+
+			created, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, gw.ProwJobClient, &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			fetched, err := gw.GetJobExecution(context.Background(), &GetJobExecutionRequest{Id: created.GetId()})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for name, jobExec := range map[string]*JobExecution{"created": created, "fetched": fetched} {
+				if jobExec.GetMaxConcurrency() != tc.expectedMaxConcurrency {
+					t.Errorf("expected %s job execution to have max concurrency %d, got %d", name, tc.expectedMaxConcurrency, jobExec.GetMaxConcurrency())
+				}
+				if jobExec.GetJobQueueCapacity() != tc.expectedJobQueueCapacity {
+					t.Errorf("expected %s job execution to have job queue capacity %d, got %d", name, tc.expectedJobQueueCapacity, jobExec.GetJobQueueCapacity())
+				}
+			}
+		})
+	}
+}
+
+func TestHandleProwJobDeprecated(t *testing.T) {
+	for _, tc := range []struct {
+		name                string
+		labels              map[string]string
+		annotations         map[string]string
+		requestAnnotations  map[string]string
+		expectedDeprecated  bool
+		expectedDeprecation string
+	}{
+		{
+			name: "job is not deprecated",
+		},
+		{
+			name:                "job is deprecated with a message",
+			labels:              map[string]string{kube.DeprecatedLabel: "true"},
+			annotations:         map[string]string{kube.DeprecationMessageAnnotation: "Use other-periodic-job instead."},
+			expectedDeprecated:  true,
+			expectedDeprecation: "Use other-periodic-job instead.",
+		},
+		{
+			name:               "job is deprecated with an annotation",
+			annotations:        map[string]string{kube.DeprecatedLabel: "true"},
+			expectedDeprecated: true,
+		},
+		{
+			name:               "request can't deprecate job",
+			requestAnnotations: map[string]string{kube.DeprecatedLabel: "true"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{
+				JobConfig: config.JobConfig{
+					Periodics: []config.Periodic{{JobBase: config.JobBase{
+						Name:        "periodic-job",
+						Labels:      tc.labels,
+						Annotations: tc.annotations,
+					}}},
+				},
+			}
+			cjer := &CreateJobExecutionRequest{
+				JobName:          "periodic-job",
+				JobExecutionType: JobExecutionType_PERIODIC,
+				PodSpecOptions:   &PodSpecOptions{Annotations: tc.requestAnnotations},
+			}
+
+			jobExec, err := HandleProwJob(logrus.NewEntry(logrus.New()), nil, cjer, fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs"), &ProwCfgAdapter{Config: cfg}, nil, nil, false, []string{"*"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if jobExec.GetDeprecated() != tc.expectedDeprecated {
+				t.Errorf("expected deprecated to be %t, got %t", tc.expectedDeprecated, jobExec.GetDeprecated())
+			}
+			if jobExec.GetDeprecationMessage() != tc.expectedDeprecation {
+				t.Errorf("expected deprecation message %q, got %q", tc.expectedDeprecation, jobExec.GetDeprecationMessage())
+			}
+		})
+	}
+}
+
+func TestGetJobExecutions(t *testing.T) {
+
+	ca := &config.Agent{}
+	ca.Set(&config.Config{})
+	gw := &Gangway{
+		ConfigAgent: ca,
+		ProwJobClient: fake.NewSimpleClientset(
+			newProwJob("first", "periodic-job", "", prowcrd.PeriodicJob, prowcrd.PendingState, false),
+			newProwJob("second", "periodic-job", "", prowcrd.PeriodicJob, prowcrd.PendingState, false),
+		).ProwV1().ProwJobs("prowjobs"),
+	}
+
+	jobExecs, err := gw.GetJobExecutions(context.Background(), &GetJobExecutionsRequest{Ids: []string{"first", "missing", "second", "gone"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+	for _, jobExec := range jobExecs.GetJobExecution() {
+		ids = append(ids, jobExec.GetId())
+		if jobExec.GetJobStatus() != JobExecutionStatus_PENDING {
+			t.Errorf("expected job execution %s to be %s, got %s", jobExec.GetId(), JobExecutionStatus_PENDING, jobExec.GetJobStatus())
+		}
+	}
+	if diff := cmp.Diff([]string{"first", "second"}, ids); diff != "" {
+		t.Errorf("unexpected job executions (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"missing", "gone"}, jobExecs.GetMissingIds()); diff != "" {
+		t.Errorf("unexpected missing ids (-want +got):\n%s", diff)
+	}
+
+	if _, err := gw.GetJobExecutions(context.Background(), &GetJobExecutionsRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected %s error for a request without ids, got %v", codes.InvalidArgument, err)
+	}
+}
+
+// fakeJobExecutionStream collects the job executions sent by
+// StreamJobExecutions.
+type fakeJobExecutionStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	jobExecs []*JobExecution
+}
+
+func (s *fakeJobExecutionStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeJobExecutionStream) Send(jobExec *JobExecution) error {
+	s.jobExecs = append(s.jobExecs, jobExec)
+	return nil
+}
+
+func TestStreamJobExecutions(t *testing.T) {
+	ca := &config.Agent{}
+	ca.Set(&config.Config{})
+	gw := &Gangway{
+		ConfigAgent: ca,
+		ProwJobClient: fake.NewSimpleClientset(
+			newProwJob("first", "periodic-job", "", prowcrd.PeriodicJob, prowcrd.SuccessState, true),
+			newProwJob("second", "periodic-job", "", prowcrd.PeriodicJob, prowcrd.FailureState, true),
+			newProwJob("third", "periodic-job", "", prowcrd.PeriodicJob, prowcrd.SuccessState, true),
+			newProwJob("other", "other-job", "", prowcrd.PeriodicJob, prowcrd.SuccessState, true),
+		).ProwV1().ProwJobs("prowjobs"),
+	}
+	request := &ListJobExecutionsRequest{JobName: "periodic-job", Status: JobExecutionStatus_SUCCESS}
+
+	stream := &fakeJobExecutionStream{ctx: context.Background()}
+	if err := gw.StreamJobExecutions(request, stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []*JobExecution{
+		{Id: "first", JobName: "periodic-job", JobStatus: JobExecutionStatus_SUCCESS, JobType: JobExecutionType_PERIODIC},
+		{Id: "third", JobName: "periodic-job", JobStatus: JobExecutionStatus_SUCCESS, JobType: JobExecutionType_PERIODIC},
+	}
+	if diff := cmp.Diff(expected, stream.jobExecs, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected job executions (-want +got):\n%s", diff)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stream = &fakeJobExecutionStream{ctx: ctx}
+	if err := gw.StreamJobExecutions(request, stream); status.Code(err) != codes.Canceled {
+		t.Errorf("expected %s error for a cancelled stream, got %v", codes.Canceled, err)
+	}
+	if len(stream.jobExecs) != 0 {
+		t.Errorf("expected a cancelled stream to get no job executions, got %d", len(stream.jobExecs))
+	}
+}
+
+func TestListJobExecutionsPagination(t *testing.T) {
+	var prowJobs []prowcrd.ProwJob
+	for i := range 5 {
+		prowJobs = append(prowJobs, prowcrd.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("job-%d", i),
+				Namespace: "prowjobs",
+			},
+			Spec: prowcrd.ProwJobSpec{
+				Job:  "periodic-job",
+				Type: prowcrd.PeriodicJob,
+			},
+			Status: prowcrd.ProwJobStatus{
+				State: prowcrd.SuccessState,
+			},
+		})
+	}
+	clientset := fake.NewSimpleClientset()
+	// The fake clientset doesn't page like the API server does.
+	clientset.PrependReactor("list", "prowjobs", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		options := action.(clienttesting.ListActionImpl).GetListOptions()
+		start := 0
+		if options.Continue != "" {
+			var err error
+			if start, err = strconv.Atoi(options.Continue); err != nil {
+				return true, nil, kerrors.NewBadRequest("invalid continue token")
+			}
+		}
+		end := len(prowJobs)
+		if options.Limit > 0 && start+int(options.Limit) < end {
+			end = start + int(options.Limit)
+		}
+		list := &prowcrd.ProwJobList{Items: prowJobs[start:end]}
+		if end < len(prowJobs) {
+			list.Continue = strconv.Itoa(end)
+		}
+		return true, list, nil
+	})
+	ca := &config.Agent{}
+	ca.Set(&config.Config{})
+	gw := &Gangway{
+		ConfigAgent:   ca,
+		ProwJobClient: clientset.ProwV1().ProwJobs("prowjobs"),
+	}
+
+	var ids []string
+	var pages int
+	request := &ListJobExecutionsRequest{PageSize: 2}
+	for {
+		jobExecs, err := gw.ListJobExecutions(context.Background(), request)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		pages++
+		if len(jobExecs.GetJobExecution()) > 2 {
+			t.Errorf("expected at most 2 job executions per page, got %d", len(jobExecs.GetJobExecution()))
+		}
+		for _, jobExec := range jobExecs.GetJobExecution() {
+			ids = append(ids, jobExec.GetId())
+		}
+		if jobExecs.GetNextPageToken() == "" {
+			break
+		}
+		request.PageToken = jobExecs.GetNextPageToken()
+	}
+	if pages != 3 {
+		t.Errorf("expected 3 pages, got %d", pages)
+	}
+	if diff := cmp.Diff([]string{"job-0", "job-1", "job-2", "job-3", "job-4"}, ids); diff != "" {
+		t.Errorf("unexpected job executions (-want +got):\n%s", diff)
+	}
+
+	if _, err := gw.ListJobExecutions(context.Background(), &ListJobExecutionsRequest{PageSize: 2, PageToken: "garbage"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected %s error for an invalid page token, got %v", codes.InvalidArgument, err)
+	}
+	for _, pageSize := range []int32{-1, maxListPageSize + 1} {
+		if _, err := gw.ListJobExecutions(context.Background(), &ListJobExecutionsRequest{PageSize: pageSize}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected %s error for page size %d, got %v", codes.InvalidArgument, pageSize, err)
+		}
+	}
+}
+
+func TestGetListRequestLabelSelector(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		request  *ListJobExecutionsRequest
+		expected map[string]string
+	}{
+		{
+			name:     "no filters",
+			request:  &ListJobExecutionsRequest{},
+			expected: map[string]string{},
+		},
+		{
+			name:     "job name",
+			request:  &ListJobExecutionsRequest{JobName: "some-job"},
+			expected: map[string]string{"prow.k8s.io/job": "some-job"},
+		},
+		{
+			name:    "pull request",
+			request: &ListJobExecutionsRequest{Org: "org", Repo: "repo", Pull: 1234},
+			expected: map[string]string{
+				"prow.k8s.io/refs.org":  "org",
+				"prow.k8s.io/refs.repo": "repo",
+				"prow.k8s.io/refs.pull": "1234",
+			},
+		},
+		{
+			name:    "job name and pull request",
+			request: &ListJobExecutionsRequest{JobName: "some-job", Org: "org", Repo: "repo", Pull: 1234},
+			expected: map[string]string{
+				"prow.k8s.io/job":       "some-job",
+				"prow.k8s.io/refs.org":  "org",
+				"prow.k8s.io/refs.repo": "repo",
+				"prow.k8s.io/refs.pull": "1234",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			expected := &metav1.LabelSelector{MatchLabels: tc.expected}
+			if diff := cmp.Diff(expected, getListRequestLabelSelector(tc.request)); diff != "" {
+				t.Errorf("unexpected label selector (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListJobExecutionsByPull(t *testing.T) {
+	forPull := func(pj *prowcrd.ProwJob, pull string) runtime.Object {
+		pj.Labels[kube.OrgLabel] = "org"
+		pj.Labels[kube.RepoLabel] = "repo"
+		pj.Labels[kube.PullLabel] = pull
+		return pj
+	}
+	ca := &config.Agent{}
+	ca.Set(&config.Config{})
+	gw := &Gangway{
+		ConfigAgent: ca,
+		ProwJobClient: fake.NewSimpleClientset(
+			forPull(newProwJob("unit-passed", "unit", "", prowcrd.PresubmitJob, prowcrd.SuccessState, true), "1234"),
+			forPull(newProwJob("unit-failed", "unit", "", prowcrd.PresubmitJob, prowcrd.FailureState, true), "1234"),
+			forPull(newProwJob("e2e-passed", "e2e", "", prowcrd.PresubmitJob, prowcrd.SuccessState, true), "1234"),
+			forPull(newProwJob("unit-other-pull", "unit", "", prowcrd.PresubmitJob, prowcrd.SuccessState, true), "5678"),
+		).ProwV1().ProwJobs("prowjobs"),
+	}
+
+	for _, tc := range []struct {
+		name     string
+		request  *ListJobExecutionsRequest
+		expected []string
+	}{
+		{
+			name:     "all executions for the pull request",
+			request:  &ListJobExecutionsRequest{Org: "org", Repo: "repo", Pull: 1234},
+			expected: []string{"e2e-passed", "unit-failed", "unit-passed"},
+		},
+		{
+			name:     "executions of a job for the pull request",
+			request:  &ListJobExecutionsRequest{JobName: "unit", Org: "org", Repo: "repo", Pull: 1234},
+			expected: []string{"unit-failed", "unit-passed"},
+		},
+		{
+			name:     "successful executions for the pull request",
+			request:  &ListJobExecutionsRequest{Status: JobExecutionStatus_SUCCESS, Org: "org", Repo: "repo", Pull: 1234},
+			expected: []string{"e2e-passed", "unit-passed"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			jobExecs, err := gw.ListJobExecutions(context.Background(), tc.request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var ids []string
+			for _, jobExec := range jobExecs.GetJobExecution() {
+				ids = append(ids, jobExec.GetId())
+			}
+			sort.Strings(ids)
+			if diff := cmp.Diff(tc.expected, ids); diff != "" {
+				t.Errorf("unexpected job executions (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// concurrentlyUpdatedProwJobClient bumps the resourceVersion of a ProwJob
+// right after it was read, as a concurrent writer would, and rejects updates
+// based on a stale resourceVersion like the API server does.
+type concurrentlyUpdatedProwJobClient struct {
+	ProwJobClient
+}
+
+func (c *concurrentlyUpdatedProwJobClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*prowcrd.ProwJob, error) {
+	pj, err := c.ProwJobClient.Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	bumped := pj.DeepCopy()
+	bumped.ResourceVersion = pj.ResourceVersion + "1"
+	bumped.Annotations = map[string]string{"concurrent": "write"}
+	if _, err := c.ProwJobClient.Update(ctx, bumped, metav1.UpdateOptions{}); err != nil {
+		return nil, err
+	}
+	return pj, nil
+}
+
+func (c *concurrentlyUpdatedProwJobClient) Update(ctx context.Context, pj *prowcrd.ProwJob, opts metav1.UpdateOptions) (*prowcrd.ProwJob, error) {
+	current, err := c.ProwJobClient.Get(ctx, pj.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if current.ResourceVersion != pj.ResourceVersion {
+		return nil, kerrors.NewConflict(prowcrd.Resource("prowjobs"), pj.Name, errors.New("the object has been modified"))
+	}
+	return c.ProwJobClient.Update(ctx, pj, opts)
+}
+
+func TestCancelJobExecution(t *testing.T) {
+
+	ca := &config.Agent{}
+	ca.Set(&config.Config{
+		ProwConfig: config.ProwConfig{
+			Gangway: config.Gangway{
+				AllowedApiClients: []config.AllowedApiClient{
+					{
+						GCP: &config.ApiClientGcp{
+							EndpointApiConsumerType:   "PROJECT",
+							EndpointApiConsumerNumber: "123",
+						},
+						AllowedJobsFilters: []config.AllowedJobsFilter{{TenantID: "tenant"}},
+					},
+				},
+			},
+		},
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		HEADER_API_CONSUMER_TYPE: "PROJECT",
+		HEADER_API_CONSUMER_ID:   "123",
+	}))
+
+	for _, tc := range []struct {
+		name              string
+		id                string
+		concurrentUpdates bool
+		expectedCode      codes.Code
+		expectedState     prowcrd.ProwJobState
+	}{
+		{
+			name:          "pending job is aborted",
+			id:            "pending",
+			expectedCode:  codes.OK,
+			expectedState: prowcrd.AbortedState,
+		},
+		{
+			name:              "pending job is aborted despite a concurrent update",
+			id:                "pending",
+			concurrentUpdates: true,
+			expectedCode:      codes.OK,
+			expectedState:     prowcrd.AbortedState,
+		},
+		{
+			name:         "missing job",
+			id:           "missing",
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:          "complete job is left alone",
+			id:            "success",
+			expectedCode:  codes.FailedPrecondition,
+			expectedState: prowcrd.SuccessState,
+		},
+		{
+			name:          "job of another tenant is left alone",
+			id:            "other-tenant",
+			expectedCode:  codes.PermissionDenied,
+			expectedState: prowcrd.PendingState,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pjc := fake.NewSimpleClientset(
+				newProwJob("pending", "pending", "tenant", "", prowcrd.PendingState, false),
+				newProwJob("success", "success", "tenant", "", prowcrd.SuccessState, true),
+				newProwJob("other-tenant", "other-tenant", "other-tenant", "", prowcrd.PendingState, false),
+			).ProwV1().ProwJobs("prowjobs")
+			gw := &Gangway{
+				ConfigAgent:   ca,
+				ProwJobClient: pjc,
+			}
+			if tc.concurrentUpdates {
+				gw.ProwJobClient = &concurrentlyUpdatedProwJobClient{ProwJobClient: pjc}
+			}
+
+			response, err := gw.CancelJobExecution(ctx, &CancelJobExecutionRequest{Id: tc.id})
+			if status.Code(err) != tc.expectedCode {
+				t.Fatalf("expected code %s, got %v", tc.expectedCode, err)
+			}
+			if err == nil && response.GetStatus() != JobExecutionStatus_ABORTED {
+				t.Errorf("expected status %s, got %s", JobExecutionStatus_ABORTED, response.GetStatus())
+			}
+			if tc.expectedState == "" {
+				return
+			}
+			pj, err := pjc.Get(context.Background(), tc.id, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get ProwJob: %v", err)
+			}
+			if pj.Status.State != tc.expectedState {
+				t.Errorf("expected state %s, got %s", tc.expectedState, pj.Status.State)
+			}
+		})
+	}
+}
@@ -85,3 +85,14 @@ func (fo *FakeOpener) Writer(ctx context.Context, path string, opts ...pkgio.Wri
 
 	return &nopReadWriteCloser{Buffer: fo.Buffer[path]}, nil
 }
+
+func (fo *FakeOpener) Attributes(ctx context.Context, path string) (pkgio.Attributes, error) {
+	if fo.ReadError != nil {
+		return pkgio.Attributes{}, fo.ReadError
+	}
+	buf, ok := fo.Buffer[path]
+	if !ok {
+		return pkgio.Attributes{}, os.ErrNotExist
+	}
+	return pkgio.Attributes{Size: int64(buf.Len())}, nil
+}
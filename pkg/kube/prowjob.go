@@ -44,6 +44,9 @@ const (
 	// job names can be arbitrarily long, this is added as
 	// an annotation instead of a label.
 	ProwJobAnnotation = "prow.k8s.io/job"
+	// JobQueueLabel is added in resources created by prow and
+	// carries the name of the job queue of the job, if any.
+	JobQueueLabel = "prow.k8s.io/job-queue"
 	// ContextAnnotation is added in resources created by prow and
 	// carries the context of the job that the pod is running. Since
 	// job names can be arbitrarily long, this is added as
@@ -72,6 +75,59 @@ const (
 	// ReRunLabel is added in periodics that are configured to be
 	// re-runned several times, value starts from 0 and it's increased on re-run
 	ReRunLabel = "prow.k8s.io/re-run"
+	// ErrorReasonAnnotation is added by plank to ProwJobs that it errors
+	// and carries the reason of the error, e.g. PodPendingTimeout.
+	ErrorReasonAnnotation = "prow.k8s.io/error-reason"
+	// AdmissionSnapshotAnnotation is added by plank to ProwJobs when it
+	// starts them, if configured to, and carries a JSON object with the
+	// numbers of pending jobs at that time.
+	AdmissionSnapshotAnnotation = "prow.k8s.io/admission-snapshot"
+	// RevivalAttemptsAnnotation is added by plank to ProwJobs whose pod it
+	// revived, if configured to, and carries a JSON list with the status of
+	// the pod of each attempt that was stopped unexpectedly.
+	RevivalAttemptsAnnotation = "prow.k8s.io/revival-attempts"
+	// ConcurrencyWaitAnnotation is added by plank to ProwJobs when it
+	// starts them, if configured to, and carries the duration the job
+	// waited since its creation, e.g. because of concurrency limits.
+	ConcurrencyWaitAnnotation = "prow.k8s.io/concurrency-wait"
+	// ReconcilerInstanceAnnotation is added by plank to ProwJobs whenever it
+	// changes their status, if configured to, and carries the name of the
+	// plank instance that made the change.
+	ReconcilerInstanceAnnotation = "prow.k8s.io/reconciler-instance"
+	// ReconcileSequenceAnnotation is added by plank to ProwJobs together
+	// with ReconcilerInstanceAnnotation and carries the sequence number of
+	// the reconcile that made the change. It increases with every change the
+	// instance makes.
+	ReconcileSequenceAnnotation = "prow.k8s.io/reconcile-sequence"
+	// OOMKilledAnnotation is added by plank to ProwJobs that it errors
+	// because a container of their pod got OOMKilled.
+	OOMKilledAnnotation = "prow.k8s.io/oomkilled"
+	// StuckTriggeredAnnotation is added by plank to ProwJobs that stayed
+	// triggered without a pod for longer than the stuck triggered threshold,
+	// so that they are only reported once.
+	StuckTriggeredAnnotation = "prow.k8s.io/stuck-triggered"
+	// ReconcileErrorsAnnotation is added by plank to ProwJobs of jobs that
+	// failed to reconcile at least the reconcile error threshold times
+	// within the reconcile error window. It carries the number of errors
+	// within the window.
+	ReconcileErrorsAnnotation = "prow.k8s.io/reconcile-errors"
+	// AbortAnnotation can be set to "true" on a ProwJob to make plank abort
+	// it, if plank is configured to honor it.
+	AbortAnnotation = "prow.k8s.io/abort"
+	// PausedAnnotation can be set to "true" on a ProwJob to make plank leave
+	// it and its pod alone until the annotation is removed again.
+	PausedAnnotation = "prow.k8s.io/paused"
+	// DeprecatedLabel can be set to "true" on jobs in the config to mark them
+	// as deprecated. It can also be set as an annotation.
+	DeprecatedLabel = "prow.k8s.io/deprecated"
+	// DeprecationMessageAnnotation can be set on deprecated jobs in the config
+	// and carries a message for clients triggering them, e.g. which job to
+	// migrate to.
+	DeprecationMessageAnnotation = "prow.k8s.io/deprecation-message"
+	// IdempotencyKeyLabel is added by gangway to ProwJobs created with an
+	// idempotency key and carries a hash of the key and the API client that
+	// created the ProwJob.
+	IdempotencyKeyLabel = "prow.k8s.io/idempotency-key"
 
 	// Gerrit related labels that are used by Prow
 
@@ -86,3 +142,39 @@ const (
 	// GerritReportLabel is the gerrit label prow will cast vote on, fallback to CodeReview label if unset
 	GerritReportLabel = "prow.k8s.io/gerrit-report-label"
 )
+
+// Reasons recorded in the ErrorReasonAnnotation of errored ProwJobs.
+const (
+	// ErrorReasonPodCreationFailed means that the pod of the job was rejected
+	// by the build cluster.
+	ErrorReasonPodCreationFailed = "PodCreationFailed"
+	// ErrorReasonPodUnscheduledTimeout means that the pod of the job could not
+	// be scheduled in time.
+	ErrorReasonPodUnscheduledTimeout = "PodUnscheduledTimeout"
+	// ErrorReasonPodPendingTimeout means that the pod of the job did not start
+	// in time.
+	ErrorReasonPodPendingTimeout = "PodPendingTimeout"
+	// ErrorReasonPodEvicted means that the pod of the job got evicted.
+	ErrorReasonPodEvicted = "PodEvicted"
+	// ErrorReasonPodStoppedUnexpectedly means that the pod of the job got
+	// stopped unexpectedly more often than plank revives pods.
+	ErrorReasonPodStoppedUnexpectedly = "PodStoppedUnexpectedly"
+	// ErrorReasonPodDeleted means that the pod of the job got deleted.
+	ErrorReasonPodDeleted = "PodDeleted"
+	// ErrorReasonOOMKilled means that the test of the job ran out of memory.
+	ErrorReasonOOMKilled = "OOMKilled"
+	// ErrorReasonImagePullTimeout means that an image of the pod of the job
+	// could not be pulled in time, e.g. because it does not exist.
+	ErrorReasonImagePullTimeout = "ImagePullTimeout"
+)
+
+// IsInfraErrorReason returns true if the error reason points to a problem with
+// the infrastructure running the job rather than with the job itself.
+func IsInfraErrorReason(reason string) bool {
+	switch reason {
+	case ErrorReasonPodCreationFailed, ErrorReasonPodUnscheduledTimeout, ErrorReasonPodPendingTimeout,
+		ErrorReasonPodEvicted, ErrorReasonPodStoppedUnexpectedly, ErrorReasonPodDeleted:
+		return true
+	}
+	return false
+}
@@ -59,6 +59,18 @@ func digestRefs(ref prowapi.Refs) string {
 // the prowjob to complete. The responsible agent is expected to react to the aborted state by aborting the actual
 // test payload and then setting the ProwJob to completed.
 func TerminateOlderJobs(pjc patchClient, log *logrus.Entry, pjs []prowapi.ProwJob) error {
+	return terminateDupes(pjc, log, pjs, false)
+}
+
+// TerminateNewerJobs is like TerminateOlderJobs, but aborts all presubmit jobs from the given list that have an
+// older version instead, so that the earliest job wins.
+func TerminateNewerJobs(pjc patchClient, log *logrus.Entry, pjs []prowapi.ProwJob) error {
+	return terminateDupes(pjc, log, pjs, true)
+}
+
+// terminateDupes aborts all but one presubmit job of the same job for the same refs, keeping either the oldest
+// or the newest one.
+func terminateDupes(pjc patchClient, log *logrus.Entry, pjs []prowapi.ProwJob, keepOldest bool) error {
 	dupes := map[string]int{}
 	for i, pj := range pjs {
 		if pj.Complete() || pj.Spec.Type != prowapi.PresubmitJob {
@@ -93,7 +105,8 @@ func TerminateOlderJobs(pjc patchClient, log *logrus.Entry, pjs []prowapi.ProwJo
 			continue
 		}
 		cancelIndex := i
-		if (&pjs[prev].Status.StartTime).Before(&pj.Status.StartTime) {
+		prevStart, start := &pjs[prev].Status.StartTime, &pj.Status.StartTime
+		if (!keepOldest && prevStart.Before(start)) || (keepOldest && start.Before(prevStart)) {
 			cancelIndex = prev
 			dupes[ji] = i
 		}
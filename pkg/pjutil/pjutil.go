@@ -41,14 +41,15 @@ import (
 // Modifiers allows a client to set some fields
 // when a ProwJob is being created.
 type Modifiers struct {
-	state prowapi.ProwJobState
+	state   prowapi.ProwJobState
+	newName func() string
 }
 
 // Modifier configures a Modifiers value
 type Modifier func(*Modifiers)
 
 func defaultModifiers() Modifiers {
-	return Modifiers{state: prowapi.TriggeredState}
+	return Modifiers{state: prowapi.TriggeredState, newName: uuid.NewString}
 }
 
 // RequireScheduling returns an Option that, if enabled, set
@@ -60,12 +61,33 @@ func RequireScheduling(enableScheduling bool) Modifier {
 	return func(*Modifiers) {}
 }
 
+// TimeOrderedName returns an Option that names the ProwJob with a UUIDv7
+// instead of a random UUID, so that the names of ProwJobs sort by the time they
+// were initialized.
+func TimeOrderedName() Modifier {
+	return func(opts *Modifiers) { opts.newName = newUUIDv7 }
+}
+
+func newUUIDv7() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		// This only happens if no random bits can be read, which makes a random
+		// UUID fail as well.
+		return uuid.NewString()
+	}
+	return id.String()
+}
+
 // NewProwJob initializes a ProwJob out of a ProwJobSpec, with some extra modifiers.
 func NewProwJob(spec prowapi.ProwJobSpec, extraLabels, extraAnnotations map[string]string, modifiers ...Modifier) prowapi.ProwJob {
 	labels, annotations := decorate.LabelsAndAnnotationsForSpec(spec, extraLabels, extraAnnotations)
 	specCopy := spec.DeepCopy()
 	setReportDefault(specCopy)
-	uuidV1 := uuid.New()
+
+	defModifiers := defaultModifiers()
+	for _, modifier := range modifiers {
+		modifier(&defModifiers)
+	}
 
 	pj := prowapi.ProwJob{
 		TypeMeta: metav1.TypeMeta{
@@ -73,23 +95,17 @@ func NewProwJob(spec prowapi.ProwJobSpec, extraLabels, extraAnnotations map[stri
 			Kind:       "ProwJob",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        uuidV1.String(),
+			Name:        defModifiers.newName(),
 			Labels:      labels,
 			Annotations: annotations,
 		},
 		Spec: *specCopy,
 		Status: prowapi.ProwJobStatus{
 			StartTime: metav1.Now(),
-			State:     prowapi.TriggeredState,
+			State:     defModifiers.state,
 		},
 	}
 
-	defModifiers := defaultModifiers()
-	for _, modifier := range modifiers {
-		modifier(&defModifiers)
-	}
-	pj.Status.State = defModifiers.state
-
 	return pj
 }
 
@@ -143,6 +159,7 @@ func createRefs(pr github.PullRequest, baseSHA string) prowapi.Refs {
 				Author:     pr.User.Login,
 				SHA:        pr.Head.SHA,
 				HeadRef:    pr.Head.Ref,
+				HeadRepo:   pr.Head.Repo.FullName,
 				Title:      pr.Title,
 				Link:       pr.HTMLURL,
 				AuthorLink: pr.User.HTMLURL,
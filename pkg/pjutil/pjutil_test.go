@@ -628,6 +628,27 @@ func TestNewProwJob(t *testing.T) {
 				kube.ContextAnnotation: "job-context",
 			},
 		},
+		{
+			name: "periodic job in a job queue",
+			spec: prowapi.ProwJobSpec{
+				Job:          "job",
+				Context:      "job-context",
+				Type:         prowapi.PeriodicJob,
+				JobQueueName: "queue",
+			},
+			labels: map[string]string{},
+			expectedLabels: map[string]string{
+				kube.CreatedByProw:     "true",
+				kube.ProwJobAnnotation: "job",
+				kube.ContextAnnotation: "job-context",
+				kube.ProwJobTypeLabel:  "periodic",
+				kube.JobQueueLabel:     "queue",
+			},
+			expectedAnnotations: map[string]string{
+				kube.ProwJobAnnotation: "job",
+				kube.ContextAnnotation: "job-context",
+			},
+		},
 		{
 			name: "periodic job with extra refs",
 			spec: prowapi.ProwJobSpec{
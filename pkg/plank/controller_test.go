@@ -18,35 +18,51 @@ package plank
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/rand/v2"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
+	"syscall"
 	"testing"
 	"text/template"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/prometheus/client_golang/prometheus"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
+	kubernetesreporterapi "sigs.k8s.io/prow/pkg/crier/reporters/gcs/kubernetes/api"
+	"sigs.k8s.io/prow/pkg/entrypoint"
 	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/pjutil"
 	"sigs.k8s.io/prow/pkg/testutil"
@@ -61,6 +77,7 @@ const (
 	podPendingTimeout     = time.Hour
 	podRunningTimeout     = time.Hour * 2
 	podUnscheduledTimeout = time.Minute * 5
+	imagePullTimeout      = time.Minute * 5
 
 	podDeletionPreventionFinalizer = "keep-from-vanishing"
 )
@@ -108,6 +125,7 @@ func newFakeConfigAgent(t *testing.T, maxConcurrency int, queueCapacities map[st
 					PodPendingTimeout:     &metav1.Duration{Duration: podPendingTimeout},
 					PodRunningTimeout:     &metav1.Duration{Duration: podRunningTimeout},
 					PodUnscheduledTimeout: &metav1.Duration{Duration: podUnscheduledTimeout},
+					ImagePullTimeout:      &metav1.Duration{Duration: imagePullTimeout},
 					MaxRevivals:           &maxRevivals,
 				},
 			},
@@ -118,6 +136,28 @@ func newFakeConfigAgent(t *testing.T, maxConcurrency int, queueCapacities map[st
 	}
 }
 
+// getProwJob returns the current state of pj as read from client.
+func getProwJob(t *testing.T, ctx context.Context, client ctrlruntimeclient.Client, pj *prowapi.ProwJob) prowapi.ProwJob {
+	t.Helper()
+	var actual prowapi.ProwJob
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(pj), &actual); err != nil {
+		t.Fatalf("failed to get prowjob from client: %v", err)
+	}
+	return actual
+}
+
+// reconcileAndGet reconciles the current state of pj as read from client and
+// returns the result of the reconciliation and the state of pj afterwards.
+func reconcileAndGet(t *testing.T, ctx context.Context, r *reconciler, client ctrlruntimeclient.Client, pj *prowapi.ProwJob) (*reconcile.Result, prowapi.ProwJob) {
+	t.Helper()
+	current := getProwJob(t, ctx, client, pj)
+	res, err := r.reconcile(ctx, &current)
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	return res, getProwJob(t, ctx, client, pj)
+}
+
 func (f *fca) Config() *config.Config {
 	f.Lock()
 	defer f.Unlock()
@@ -131,132 +171,142 @@ func TestTerminateDupes(t *testing.T) {
 		return &reallyNow
 	}
 
+	pjs := []prowapi.ProwJob{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "newest", Namespace: "prowjobs"},
+			Spec: prowapi.ProwJobSpec{
+				Agent: prowapi.KubernetesAgent,
+				Type:  prowapi.PresubmitJob,
+				Job:   "j1",
+				Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
+			},
+			Status: prowapi.ProwJobStatus{
+				State:     prowapi.PendingState,
+				StartTime: metav1.NewTime(now.Add(-time.Minute)),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "prowjobs"},
+			Spec: prowapi.ProwJobSpec{
+				Agent: prowapi.KubernetesAgent,
+				Type:  prowapi.PresubmitJob,
+				Job:   "j1",
+				Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
+			},
+			Status: prowapi.ProwJobStatus{
+				State:     prowapi.TriggeredState,
+				StartTime: metav1.NewTime(now.Add(-time.Hour)),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "older", Namespace: "prowjobs"},
+			Spec: prowapi.ProwJobSpec{
+				Agent: prowapi.KubernetesAgent,
+				Type:  prowapi.PresubmitJob,
+				Job:   "j1",
+				Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
+			},
+			Status: prowapi.ProwJobStatus{
+				State:     prowapi.TriggeredState,
+				StartTime: metav1.NewTime(now.Add(-2 * time.Hour)),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "complete", Namespace: "prowjobs"},
+			Spec: prowapi.ProwJobSpec{
+				Agent: prowapi.KubernetesAgent,
+				Type:  prowapi.PresubmitJob,
+				Job:   "j1",
+				Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
+			},
+			Status: prowapi.ProwJobStatus{
+				State:          prowapi.SuccessState,
+				StartTime:      metav1.NewTime(now.Add(-3 * time.Hour)),
+				CompletionTime: nowFn(),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "newest_j2", Namespace: "prowjobs"},
+			Spec: prowapi.ProwJobSpec{
+				Agent: prowapi.KubernetesAgent,
+				Type:  prowapi.PresubmitJob,
+				Job:   "j2",
+				Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
+			},
+			Status: prowapi.ProwJobStatus{
+				State:     prowapi.TriggeredState,
+				StartTime: metav1.NewTime(now.Add(-time.Minute)),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "old_j2", Namespace: "prowjobs"},
+			Spec: prowapi.ProwJobSpec{
+				Agent: prowapi.KubernetesAgent,
+				Type:  prowapi.PresubmitJob,
+				Job:   "j2",
+				Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
+			},
+			Status: prowapi.ProwJobStatus{
+				State:     prowapi.TriggeredState,
+				StartTime: metav1.NewTime(now.Add(-time.Hour)),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "old_j3", Namespace: "prowjobs"},
+			Spec: prowapi.ProwJobSpec{
+				Agent: prowapi.KubernetesAgent,
+				Type:  prowapi.PresubmitJob,
+				Job:   "j3",
+				Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
+			},
+			Status: prowapi.ProwJobStatus{
+				State:     prowapi.TriggeredState,
+				StartTime: metav1.NewTime(now.Add(-time.Hour)),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "new_j3", Namespace: "prowjobs"},
+			Spec: prowapi.ProwJobSpec{
+				Agent: prowapi.KubernetesAgent,
+				Type:  prowapi.PresubmitJob,
+				Job:   "j3",
+				Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
+			},
+			Status: prowapi.ProwJobStatus{
+				State:     prowapi.TriggeredState,
+				StartTime: metav1.NewTime(now.Add(-time.Minute)),
+			},
+		},
+	}
+
 	testcases := []struct {
 		Name          string
+		DupePolicy    config.DupePolicy
 		PJs           []prowapi.ProwJob
 		TerminatedPJs sets.Set[string]
 	}{
 		{
 			Name: "terminate all duplicates",
 
-			PJs: []prowapi.ProwJob{
-				{
-					ObjectMeta: metav1.ObjectMeta{Name: "newest", Namespace: "prowjobs"},
-					Spec: prowapi.ProwJobSpec{
-						Agent: prowapi.KubernetesAgent,
-						Type:  prowapi.PresubmitJob,
-						Job:   "j1",
-						Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
-					},
-					Status: prowapi.ProwJobStatus{
-						State:     prowapi.PendingState,
-						StartTime: metav1.NewTime(now.Add(-time.Minute)),
-					},
-				},
-				{
-					ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "prowjobs"},
-					Spec: prowapi.ProwJobSpec{
-						Agent: prowapi.KubernetesAgent,
-						Type:  prowapi.PresubmitJob,
-						Job:   "j1",
-						Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
-					},
-					Status: prowapi.ProwJobStatus{
-						State:     prowapi.TriggeredState,
-						StartTime: metav1.NewTime(now.Add(-time.Hour)),
-					},
-				},
-				{
-					ObjectMeta: metav1.ObjectMeta{Name: "older", Namespace: "prowjobs"},
-					Spec: prowapi.ProwJobSpec{
-						Agent: prowapi.KubernetesAgent,
-						Type:  prowapi.PresubmitJob,
-						Job:   "j1",
-						Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
-					},
-					Status: prowapi.ProwJobStatus{
-						State:     prowapi.TriggeredState,
-						StartTime: metav1.NewTime(now.Add(-2 * time.Hour)),
-					},
-				},
-				{
-					ObjectMeta: metav1.ObjectMeta{Name: "complete", Namespace: "prowjobs"},
-					Spec: prowapi.ProwJobSpec{
-						Agent: prowapi.KubernetesAgent,
-						Type:  prowapi.PresubmitJob,
-						Job:   "j1",
-						Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
-					},
-					Status: prowapi.ProwJobStatus{
-						State:          prowapi.SuccessState,
-						StartTime:      metav1.NewTime(now.Add(-3 * time.Hour)),
-						CompletionTime: nowFn(),
-					},
-				},
-				{
-					ObjectMeta: metav1.ObjectMeta{Name: "newest_j2", Namespace: "prowjobs"},
-					Spec: prowapi.ProwJobSpec{
-						Agent: prowapi.KubernetesAgent,
-						Type:  prowapi.PresubmitJob,
-						Job:   "j2",
-						Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
-					},
-					Status: prowapi.ProwJobStatus{
-						State:     prowapi.TriggeredState,
-						StartTime: metav1.NewTime(now.Add(-time.Minute)),
-					},
-				},
-				{
-					ObjectMeta: metav1.ObjectMeta{Name: "old_j2", Namespace: "prowjobs"},
-					Spec: prowapi.ProwJobSpec{
-						Agent: prowapi.KubernetesAgent,
-						Type:  prowapi.PresubmitJob,
-						Job:   "j2",
-						Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
-					},
-					Status: prowapi.ProwJobStatus{
-						State:     prowapi.TriggeredState,
-						StartTime: metav1.NewTime(now.Add(-time.Hour)),
-					},
-				},
-				{
-					ObjectMeta: metav1.ObjectMeta{Name: "old_j3", Namespace: "prowjobs"},
-					Spec: prowapi.ProwJobSpec{
-						Agent: prowapi.KubernetesAgent,
-						Type:  prowapi.PresubmitJob,
-						Job:   "j3",
-						Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
-					},
-					Status: prowapi.ProwJobStatus{
-						State:     prowapi.TriggeredState,
-						StartTime: metav1.NewTime(now.Add(-time.Hour)),
-					},
-				},
-				{
-					ObjectMeta: metav1.ObjectMeta{Name: "new_j3", Namespace: "prowjobs"},
-					Spec: prowapi.ProwJobSpec{
-						Agent: prowapi.KubernetesAgent,
-						Type:  prowapi.PresubmitJob,
-						Job:   "j3",
-						Refs:  &prowapi.Refs{Pulls: []prowapi.Pull{{}}},
-					},
-					Status: prowapi.ProwJobStatus{
-						State:     prowapi.TriggeredState,
-						StartTime: metav1.NewTime(now.Add(-time.Minute)),
-					},
-				},
-			},
+			PJs: pjs,
 
 			TerminatedPJs: sets.New[string]("old", "older", "old_j2", "old_j3"),
 		},
+		{
+			Name:       "terminate all newer duplicates when preferring the oldest",
+			DupePolicy: config.DupePolicyPreferOldest,
+
+			PJs: pjs,
+
+			TerminatedPJs: sets.New[string]("newest", "old", "newest_j2", "new_j3"),
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.Name, func(t *testing.T) {
 			var prowJobs []runtime.Object
 			for i := range tc.PJs {
-				pj := &tc.PJs[i]
-				prowJobs = append(prowJobs, pj)
+				prowJobs = append(prowJobs, tc.PJs[i].DeepCopy())
 			}
 
 			ctx := context.Background()
@@ -265,6 +315,7 @@ func TestTerminateDupes(t *testing.T) {
 					ProwConfig: config.ProwConfig{
 						ProwJobNamespace: "prowjobs",
 						PodNamespace:     "pods",
+						Plank:            config.Plank{DupePolicy: tc.DupePolicy},
 					},
 				},
 			}
@@ -614,6 +665,91 @@ func TestSyncTriggeredJobs(t *testing.T) {
 			ExpectedState: prowapi.TriggeredState,
 			ExpectError:   true,
 		},
+		{
+			Name: "internal server error starting pod",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "beer",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "boop",
+					Type:    prowapi.PeriodicJob,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.TriggeredState,
+				},
+			},
+			PodErr: &kapierrors.StatusError{ErrStatus: metav1.Status{
+				Status:  metav1.StatusFailure,
+				Code:    http.StatusInternalServerError,
+				Reason:  metav1.StatusReasonInternalError,
+				Message: "etcdserver: request timed out",
+			}},
+			ExpectedState: prowapi.TriggeredState,
+			ExpectError:   true,
+		},
+		{
+			Name: "throttled starting pod",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "beer",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "boop",
+					Type:    prowapi.PeriodicJob,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.TriggeredState,
+				},
+			},
+			PodErr:        kapierrors.NewTooManyRequests("slow down", 1),
+			ExpectedState: prowapi.TriggeredState,
+			ExpectError:   true,
+		},
+		{
+			Name: "connection reset starting pod",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "beer",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "boop",
+					Type:    prowapi.PeriodicJob,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.TriggeredState,
+				},
+			},
+			PodErr:        fmt.Errorf("create pod: %w", syscall.ECONNRESET),
+			ExpectedState: prowapi.TriggeredState,
+			ExpectError:   true,
+		},
+		{
+			Name: "deadline exceeded starting pod",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "beer",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "boop",
+					Type:    prowapi.PeriodicJob,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.TriggeredState,
+				},
+			},
+			PodErr:        fmt.Errorf("create pod: %w", context.DeadlineExceeded),
+			ExpectedState: prowapi.TriggeredState,
+			ExpectError:   true,
+		},
 		{
 			Name: "running pod, failed prowjob update",
 			PJ: prowapi.ProwJob{
@@ -786,7 +922,7 @@ func TestSyncTriggeredJobs(t *testing.T) {
 			}
 			pj := tc.PJ.DeepCopy()
 			pj.UID = types.UID("under-test")
-			if _, err := r.syncTriggeredJob(ctx, pj); (err != nil) != tc.ExpectError {
+			if _, err := r.reconcile(ctx, pj); (err != nil) != tc.ExpectError {
 				if tc.ExpectError {
 					t.Errorf("for case %q expected an error, but got none", tc.Name)
 				} else {
@@ -862,6 +998,11 @@ func TestSyncPendingJob(t *testing.T) {
 		ExpectedPodRunningTimeout     *metav1.Duration
 		ExpectedPodPendingTimeout     *metav1.Duration
 		ExpectedPodUnscheduledTimeout *metav1.Duration
+		ExpectedAnnotations           map[string]string
+		ExpectedPodTimeout            string
+
+		TreatOOMAsError    *bool
+		ExtraPodFinalizers []string
 	}
 	testcases := []testCase{
 		{
@@ -946,6 +1087,37 @@ func TestSyncPendingJob(t *testing.T) {
 			ExpectedState:   prowapi.PendingState,
 			ExpectedNumPods: 0,
 		},
+		{
+			Name: "delete pod in unknown state with extra finalizers",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-41",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "boop-41",
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "boop-41",
+						Namespace:  "pods",
+						Finalizers: []string{"prow.x-k8s.io/gcsk8sreporter", "example.com/reporter"},
+					},
+					Status: v1.PodStatus{
+						Phase: v1.PodUnknown,
+					},
+				},
+			},
+			ExtraPodFinalizers: []string{"example.com/reporter"},
+			ExpectedState:      prowapi.PendingState,
+			ExpectedNumPods:    0,
+		},
 		{
 			Name: "succeeded pod",
 			PJ: prowapi.ProwJob{
@@ -1088,13 +1260,19 @@ func TestSyncPendingJob(t *testing.T) {
 			ExpectedURL:      "boop-42/failure",
 		},
 		{
-			Name: "delete evicted pod",
+			Name: "oomkilled pod",
 			PJ: prowapi.ProwJob{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "boop-42",
 					Namespace: "prowjobs",
 				},
 				Spec: prowapi.ProwJobSpec{
+					Type: prowapi.PresubmitJob,
+					Refs: &prowapi.Refs{
+						Org: "kubernetes", Repo: "kubernetes",
+						BaseRef: "baseref", BaseSHA: "basesha",
+						Pulls: []prowapi.Pull{{Number: 100, Author: "me", SHA: "sha"}},
+					},
 					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
 				},
 				Status: prowapi.ProwJobStatus{
@@ -1109,23 +1287,34 @@ func TestSyncPendingJob(t *testing.T) {
 						Namespace: "pods",
 					},
 					Status: v1.PodStatus{
-						Phase:  v1.PodFailed,
-						Reason: Evicted,
+						Phase: v1.PodFailed,
+						ContainerStatuses: []v1.ContainerStatus{{
+							Name:  "test-name",
+							State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}},
+						}},
 					},
 				},
 			},
-			ExpectedComplete: false,
-			ExpectedState:    prowapi.PendingState,
-			ExpectedNumPods:  0,
+			ExpectedComplete:    true,
+			ExpectedState:       prowapi.ErrorState,
+			ExpectedNumPods:     1,
+			ExpectedURL:         "boop-42/error",
+			ExpectedAnnotations: map[string]string{kube.OOMKilledAnnotation: "true", kube.ErrorReasonAnnotation: kube.ErrorReasonOOMKilled},
 		},
 		{
-			Name: "delete evicted pod and remove its k8sreporter finalizer",
+			Name: "oomkilled pod when treating OOMs as errors is disabled",
 			PJ: prowapi.ProwJob{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "boop-42",
 					Namespace: "prowjobs",
 				},
 				Spec: prowapi.ProwJobSpec{
+					Type: prowapi.PresubmitJob,
+					Refs: &prowapi.Refs{
+						Org: "kubernetes", Repo: "kubernetes",
+						BaseRef: "baseref", BaseSHA: "basesha",
+						Pulls: []prowapi.Pull{{Number: 100, Author: "me", SHA: "sha"}},
+					},
 					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
 				},
 				Status: prowapi.ProwJobStatus{
@@ -1136,30 +1325,34 @@ func TestSyncPendingJob(t *testing.T) {
 			Pods: []v1.Pod{
 				{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:       "boop-42",
-						Namespace:  "pods",
-						Finalizers: []string{"prow.x-k8s.io/gcsk8sreporter"},
+						Name:      "boop-42",
+						Namespace: "pods",
 					},
 					Status: v1.PodStatus{
-						Phase:  v1.PodFailed,
-						Reason: Evicted,
+						Phase: v1.PodFailed,
+						ContainerStatuses: []v1.ContainerStatus{{
+							Name:  "test-name",
+							State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}},
+						}},
 					},
 				},
 			},
-			ExpectedComplete: false,
-			ExpectedState:    prowapi.PendingState,
-			ExpectedNumPods:  0,
+			TreatOOMAsError:     ptr.To(false),
+			ExpectedComplete:    true,
+			ExpectedState:       prowapi.FailureState,
+			ExpectedNumPods:     1,
+			ExpectedURL:         "boop-42/failure",
+			ExpectedAnnotations: map[string]string{},
 		},
 		{
-			Name: "don't delete evicted pod w/ error_on_eviction, complete PJ instead",
+			Name: "delete evicted pod",
 			PJ: prowapi.ProwJob{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "boop-42",
 					Namespace: "prowjobs",
 				},
 				Spec: prowapi.ProwJobSpec{
-					ErrorOnEviction: true,
-					PodSpec:         &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
 				},
 				Status: prowapi.ProwJobStatus{
 					State:   prowapi.PendingState,
@@ -1178,13 +1371,12 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedComplete: true,
-			ExpectedState:    prowapi.ErrorState,
-			ExpectedNumPods:  1,
-			ExpectedURL:      "boop-42/error",
+			ExpectedComplete: false,
+			ExpectedState:    prowapi.PendingState,
+			ExpectedNumPods:  0,
 		},
 		{
-			Name: "don't delete evicted pod w/ revivalCount == maxRevivals, complete PJ instead",
+			Name: "delete evicted pod and remove its k8sreporter finalizer",
 			PJ: prowapi.ProwJob{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "boop-42",
@@ -1194,16 +1386,16 @@ func TestSyncPendingJob(t *testing.T) {
 					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
 				},
 				Status: prowapi.ProwJobStatus{
-					PodRevivalCount: maxRevivals,
-					State:           prowapi.PendingState,
-					PodName:         "boop-42",
+					State:   prowapi.PendingState,
+					PodName: "boop-42",
 				},
 			},
 			Pods: []v1.Pod{
 				{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:      "boop-42",
-						Namespace: "pods",
+						Name:       "boop-42",
+						Namespace:  "pods",
+						Finalizers: []string{"prow.x-k8s.io/gcsk8sreporter"},
 					},
 					Status: v1.PodStatus{
 						Phase:  v1.PodFailed,
@@ -1211,16 +1403,179 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedComplete: true,
-			ExpectedState:    prowapi.ErrorState,
-			ExpectedNumPods:  1,
-			ExpectedURL:      "boop-42/error",
-		},
+			ExpectedComplete: false,
+			ExpectedState:    prowapi.PendingState,
+			ExpectedNumPods:  0,
+		},
+		{
+			Name: "don't delete evicted pod w/ error_on_eviction, complete PJ instead",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					ErrorOnEviction: true,
+					PodSpec:         &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "boop-42",
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "boop-42",
+						Namespace: "pods",
+					},
+					Status: v1.PodStatus{
+						Phase:  v1.PodFailed,
+						Reason: Evicted,
+					},
+				},
+			},
+			ExpectedComplete: true,
+			ExpectedState:    prowapi.ErrorState,
+			ExpectedNumPods:  1,
+			ExpectedURL:      "boop-42/error",
+		},
+		{
+			Name: "don't delete evicted pod w/ revivalCount == maxRevivals, complete PJ instead",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					PodRevivalCount: maxRevivals,
+					State:           prowapi.PendingState,
+					PodName:         "boop-42",
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "boop-42",
+						Namespace: "pods",
+					},
+					Status: v1.PodStatus{
+						Phase:  v1.PodFailed,
+						Reason: Evicted,
+					},
+				},
+			},
+			ExpectedComplete: true,
+			ExpectedState:    prowapi.ErrorState,
+			ExpectedNumPods:  1,
+			ExpectedURL:      "boop-42/error",
+		},
+		{
+			Name: "delete evicted pod w/ revivalCount == maxRevivals when the job allows more revivals",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					DecorationConfig: &prowapi.DecorationConfig{
+						MaxRevivals: ptr.To(maxRevivals + 1),
+					},
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					PodRevivalCount: maxRevivals,
+					State:           prowapi.PendingState,
+					PodName:         "boop-42",
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "boop-42",
+						Namespace: "pods",
+					},
+					Status: v1.PodStatus{
+						Phase:  v1.PodFailed,
+						Reason: Evicted,
+					},
+				},
+			},
+			ExpectedComplete: false,
+			ExpectedState:    prowapi.PendingState,
+			ExpectedNumPods:  0,
+		},
+		{
+			Name: "don't delete evicted pod of a job that never allows revivals, complete PJ instead",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					DecorationConfig: &prowapi.DecorationConfig{
+						MaxRevivals: ptr.To(0),
+					},
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "boop-42",
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "boop-42",
+						Namespace: "pods",
+					},
+					Status: v1.PodStatus{
+						Phase:  v1.PodFailed,
+						Reason: Evicted,
+					},
+				},
+			},
+			ExpectedComplete: true,
+			ExpectedState:    prowapi.ErrorState,
+			ExpectedNumPods:  1,
+			ExpectedURL:      "boop-42/error",
+		},
+		{
+			Name: "delete terminated pod so it gets revived",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "boop-42",
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "boop-42",
+						Namespace: "pods",
+					},
+					Status: v1.PodStatus{
+						Phase:  v1.PodFailed,
+						Reason: Terminated,
+					},
+				},
+			},
+			ExpectedComplete: false,
+			ExpectedState:    prowapi.PendingState,
+			ExpectedNumPods:  0,
+		},
 		{
-			// TODO: this test case tests the current behavior, but the behavior
-			// is non-ideal: the pod execution did not fail, instead the node on which
-			// the pod was running terminated
-			Name: "a terminated pod is handled as-if it failed",
+			Name: "delete pod rejected by a shutting down node so it gets revived",
 			PJ: prowapi.ProwJob{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "boop-42",
@@ -1234,6 +1589,38 @@ func TestSyncPendingJob(t *testing.T) {
 					PodName: "boop-42",
 				},
 			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "boop-42",
+						Namespace: "pods",
+					},
+					Status: v1.PodStatus{
+						Phase:  v1.PodFailed,
+						Reason: NodeShutdown,
+					},
+				},
+			},
+			ExpectedComplete: false,
+			ExpectedState:    prowapi.PendingState,
+			ExpectedNumPods:  0,
+		},
+		{
+			Name: "a terminated pod w/ revivalCount == maxRevivals is handled as-if it failed",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					PodRevivalCount: maxRevivals,
+					State:           prowapi.PendingState,
+					PodName:         "boop-42",
+				},
+			},
 			Pods: []v1.Pod{
 				{
 					ObjectMeta: metav1.ObjectMeta{
@@ -1361,10 +1748,11 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedState:    prowapi.ErrorState,
-			ExpectedNumPods:  0,
-			ExpectedComplete: true,
-			ExpectedURL:      "nightmare/error",
+			ExpectedState:      prowapi.ErrorState,
+			ExpectedNumPods:    0,
+			ExpectedComplete:   true,
+			ExpectedURL:        "nightmare/error",
+			ExpectedPodTimeout: podTimeoutPending,
 		},
 		{
 			Name: "stale pending prow job with specific podPendingTimeout",
@@ -1428,10 +1816,11 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedState:    prowapi.AbortedState,
-			ExpectedNumPods:  0,
-			ExpectedComplete: true,
-			ExpectedURL:      "endless/aborted",
+			ExpectedState:      prowapi.AbortedState,
+			ExpectedNumPods:    0,
+			ExpectedComplete:   true,
+			ExpectedURL:        "endless/aborted",
+			ExpectedPodTimeout: podTimeoutRunning,
 		},
 		{
 			Name: "stale running prow job with specific podRunningTimeout",
@@ -1494,10 +1883,11 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedState:    prowapi.ErrorState,
-			ExpectedNumPods:  0,
-			ExpectedComplete: true,
-			ExpectedURL:      "homeless/error",
+			ExpectedState:      prowapi.ErrorState,
+			ExpectedNumPods:    0,
+			ExpectedComplete:   true,
+			ExpectedURL:        "homeless/error",
+			ExpectedPodTimeout: podTimeoutUnscheduled,
 		},
 		{
 			Name: "stale unschedulable prow job with specific podUnscheduledTimeout",
@@ -1565,104 +1955,216 @@ func TestSyncPendingJob(t *testing.T) {
 			ExpectedNumPods:         1,
 		},
 		{
-			Name: "unscheduled, created less than podUnscheduledTimeout ago",
+			Name: "pending, failing to pull image for longer than imagePullTimeout",
 			PJ: prowapi.ProwJob{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "just-waiting",
+					Name:      "typo",
 					Namespace: "prowjobs",
 				},
 				Spec: prowapi.ProwJobSpec{},
 				Status: prowapi.ProwJobStatus{
 					State:   prowapi.PendingState,
-					PodName: "just-waiting",
+					PodName: "typo",
 				},
 			},
 			Pods: []v1.Pod{
 				{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:              "just-waiting",
+						Name:              "typo",
 						Namespace:         "pods",
-						CreationTimestamp: metav1.Time{Time: time.Now().Add(-time.Second)},
+						CreationTimestamp: metav1.Time{Time: time.Now().Add(-imagePullTimeout)},
 					},
 					Status: v1.PodStatus{
-						Phase: v1.PodPending,
+						Phase:     v1.PodPending,
+						StartTime: startTime(time.Now().Add(-imagePullTimeout)),
+						ContainerStatuses: []v1.ContainerStatus{{
+							Name:  "test",
+							Image: "tester:typo",
+							State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+						}},
 					},
 				},
 			},
-			expectedReconcileResult: &reconcile.Result{RequeueAfter: podUnscheduledTimeout},
-			ExpectedState:           prowapi.PendingState,
-			ExpectedNumPods:         1,
+			ExpectedState:       prowapi.ErrorState,
+			ExpectedNumPods:     0,
+			ExpectedComplete:    true,
+			ExpectedURL:         "typo/error",
+			ExpectedAnnotations: map[string]string{kube.ErrorReasonAnnotation: kube.ErrorReasonImagePullTimeout},
 		},
 		{
-			Name: "Pod deleted in pending phase, job marked as errored",
+			Name: "pending, failing to pull image for less than imagePullTimeout",
 			PJ: prowapi.ProwJob{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "deleted-pod-in-pending-marks-job-as-errored",
+					Name:      "slow-registry",
 					Namespace: "prowjobs",
 				},
 				Spec: prowapi.ProwJobSpec{},
 				Status: prowapi.ProwJobStatus{
 					State:   prowapi.PendingState,
-					PodName: "deleted-pod-in-pending-marks-job-as-errored",
+					PodName: "slow-registry",
 				},
 			},
 			Pods: []v1.Pod{
 				{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:              "deleted-pod-in-pending-marks-job-as-errored",
+						Name:              "slow-registry",
 						Namespace:         "pods",
-						CreationTimestamp: metav1.Time{Time: time.Now().Add(-time.Second)},
-						DeletionTimestamp: &metav1.Time{Time: time.Now()},
-						Finalizers:        []string{podDeletionPreventionFinalizer},
+						CreationTimestamp: metav1.Time{Time: time.Now().Add(-time.Minute)},
 					},
 					Status: v1.PodStatus{
-						Phase: v1.PodPending,
+						Phase:     v1.PodPending,
+						StartTime: startTime(time.Now().Add(-time.Minute)),
+						InitContainerStatuses: []v1.ContainerStatus{{
+							Name:  "clonerefs",
+							Image: "clonerefs:latest",
+							State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ErrImagePull"}},
+						}},
 					},
 				},
 			},
-			ExpectedState:    prowapi.ErrorState,
-			ExpectedComplete: true,
-			ExpectedNumPods:  1,
+			expectedReconcileResult: &reconcile.Result{RequeueAfter: imagePullTimeout - time.Minute},
+			ExpectedState:           prowapi.PendingState,
+			ExpectedNumPods:         1,
 		},
 		{
-			Name: "Pod deleted in unset phase, job marked as errored",
+			Name: "pending, failing to pull image with specific podPendingTimeout reached first",
 			PJ: prowapi.ProwJob{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod-deleted-in-unset-phase",
+					Name:      "impatient",
 					Namespace: "prowjobs",
 				},
-				Spec: prowapi.ProwJobSpec{},
+				Spec: prowapi.ProwJobSpec{
+					DecorationConfig: &prowapi.DecorationConfig{
+						PodPendingTimeout: &metav1.Duration{Duration: 2 * time.Minute},
+					},
+				},
 				Status: prowapi.ProwJobStatus{
 					State:   prowapi.PendingState,
-					PodName: "pod-deleted-in-unset-phase",
+					PodName: "impatient",
 				},
 			},
 			Pods: []v1.Pod{
 				{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:              "pod-deleted-in-unset-phase",
+						Name:              "impatient",
 						Namespace:         "pods",
-						CreationTimestamp: metav1.Time{Time: time.Now().Add(-time.Second)},
-						DeletionTimestamp: &metav1.Time{Time: time.Now()},
-						Finalizers:        []string{podDeletionPreventionFinalizer},
+						CreationTimestamp: metav1.Time{Time: time.Now().Add(-time.Minute)},
+					},
+					Status: v1.PodStatus{
+						Phase:     v1.PodPending,
+						StartTime: startTime(time.Now().Add(-time.Minute)),
+						ContainerStatuses: []v1.ContainerStatus{{
+							Name:  "test",
+							Image: "tester:latest",
+							State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+						}},
 					},
 				},
 			},
-			ExpectedState:    prowapi.ErrorState,
-			ExpectedComplete: true,
-			ExpectedNumPods:  1,
+			expectedReconcileResult:   &reconcile.Result{RequeueAfter: time.Minute},
+			ExpectedState:             prowapi.PendingState,
+			ExpectedNumPods:           1,
+			ExpectedPodPendingTimeout: &metav1.Duration{Duration: 2 * time.Minute},
 		},
 		{
-			Name: "Pod deleted in running phase, job marked as errored",
+			Name: "unscheduled, created less than podUnscheduledTimeout ago",
 			PJ: prowapi.ProwJob{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod-deleted-in-unset-phase",
+					Name:      "just-waiting",
 					Namespace: "prowjobs",
 				},
 				Spec: prowapi.ProwJobSpec{},
 				Status: prowapi.ProwJobStatus{
 					State:   prowapi.PendingState,
-					PodName: "pod-deleted-in-unset-phase",
+					PodName: "just-waiting",
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "just-waiting",
+						Namespace:         "pods",
+						CreationTimestamp: metav1.Time{Time: time.Now().Add(-time.Second)},
+					},
+					Status: v1.PodStatus{
+						Phase: v1.PodPending,
+					},
+				},
+			},
+			expectedReconcileResult: &reconcile.Result{RequeueAfter: podUnscheduledTimeout},
+			ExpectedState:           prowapi.PendingState,
+			ExpectedNumPods:         1,
+		},
+		{
+			Name: "Pod deleted in pending phase, job marked as errored",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deleted-pod-in-pending-marks-job-as-errored",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "deleted-pod-in-pending-marks-job-as-errored",
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "deleted-pod-in-pending-marks-job-as-errored",
+						Namespace:         "pods",
+						CreationTimestamp: metav1.Time{Time: time.Now().Add(-time.Second)},
+						DeletionTimestamp: &metav1.Time{Time: time.Now()},
+						Finalizers:        []string{podDeletionPreventionFinalizer},
+					},
+					Status: v1.PodStatus{
+						Phase: v1.PodPending,
+					},
+				},
+			},
+			ExpectedState:    prowapi.ErrorState,
+			ExpectedComplete: true,
+			ExpectedNumPods:  1,
+		},
+		{
+			Name: "Pod deleted in unset phase, job marked as errored",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod-deleted-in-unset-phase",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "pod-deleted-in-unset-phase",
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "pod-deleted-in-unset-phase",
+						Namespace:         "pods",
+						CreationTimestamp: metav1.Time{Time: time.Now().Add(-time.Second)},
+						DeletionTimestamp: &metav1.Time{Time: time.Now()},
+						Finalizers:        []string{podDeletionPreventionFinalizer},
+					},
+				},
+			},
+			ExpectedState:    prowapi.ErrorState,
+			ExpectedComplete: true,
+			ExpectedNumPods:  1,
+		},
+		{
+			Name: "Pod deleted in running phase, job marked as errored",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod-deleted-in-unset-phase",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "pod-deleted-in-unset-phase",
 				},
 			},
 			Pods: []v1.Pod{
@@ -1726,7 +2228,12 @@ func TestSyncPendingJob(t *testing.T) {
 				pm[tc.Pods[i].ObjectMeta.Name] = tc.Pods[i]
 			}
 			ctx := context.Background()
-			config := newFakeConfigAgent(t, 0, nil).Config
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			if tc.TreatOOMAsError != nil {
+				fakeConfigAgent.c.Plank.TreatOOMAsError = tc.TreatOOMAsError
+			}
+			fakeConfigAgent.c.Plank.ExtraPodFinalizers = tc.ExtraPodFinalizers
+			config := fakeConfigAgent.Config
 
 			fakeMgr, err := testutil.NewFakeManager(
 				ctx,
@@ -1764,9 +2271,18 @@ func TestSyncPendingJob(t *testing.T) {
 				totURL:       totServ.URL,
 				clock:        clock.RealClock{},
 			}
-			reconcileResult, err := r.syncPendingJob(ctx, &tc.PJ)
+			var podTimeoutsBefore float64
+			if tc.ExpectedPodTimeout != "" {
+				podTimeoutsBefore = promtestutil.ToFloat64(plankMetrics.podTimeouts.WithLabelValues(tc.ExpectedPodTimeout, tc.PJ.Spec.Job))
+			}
+			reconcileResult, err := r.reconcile(ctx, &tc.PJ)
 			if err != nil {
-				t.Fatalf("syncPendingJob failed: %v", err)
+				t.Fatalf("reconcile failed: %v", err)
+			}
+			if tc.ExpectedPodTimeout != "" {
+				if diff := promtestutil.ToFloat64(plankMetrics.podTimeouts.WithLabelValues(tc.ExpectedPodTimeout, tc.PJ.Spec.Job)) - podTimeoutsBefore; diff != 1 {
+					t.Errorf("expected the %s pod timeout to be counted once, got %v", tc.ExpectedPodTimeout, diff)
+				}
 			}
 			if reconcileResult != nil {
 				// Round this to minutes so we can compare the value without risking flaky tests
@@ -1790,6 +2306,11 @@ func TestSyncPendingJob(t *testing.T) {
 			if tc.ExpectedBuildID != "" && actual.Status.BuildID != tc.ExpectedBuildID {
 				t.Errorf("expected BuildID %q, got %q", tc.ExpectedBuildID, actual.Status.BuildID)
 			}
+			if tc.ExpectedAnnotations != nil {
+				if diff := cmp.Diff(tc.ExpectedAnnotations, actual.Annotations, cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("unexpected annotations (-want +got):\n%s", diff)
+				}
+			}
 			if actual.Spec.DecorationConfig != nil && actual.Spec.DecorationConfig.PodRunningTimeout != nil &&
 				tc.ExpectedPodRunningTimeout.Duration != actual.Spec.DecorationConfig.PodRunningTimeout.Duration {
 				t.Errorf("expected PodRunningTimeout %v, got %v",
@@ -1928,24 +2449,3702 @@ func TestPeriodic(t *testing.T) {
 	if err := buildClients["trusted"].Status().Update(ctx, update); err != nil {
 		t.Fatalf("could not update pod to be succeeded: %v", err)
 	}
-	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: ctrlruntimeclient.ObjectKeyFromObject(&pj)}); err != nil {
-		t.Fatalf("Error on third sync: %v", err)
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: ctrlruntimeclient.ObjectKeyFromObject(&pj)}); err != nil {
+		t.Fatalf("Error on third sync: %v", err)
+	}
+	afterThirdSync := &prowapi.ProwJobList{}
+	if err := fakeProwJobClient.List(ctx, afterThirdSync); err != nil {
+		t.Fatalf("could not list prowJobs from the client: %v", err)
+	}
+	if len(afterThirdSync.Items) != 1 {
+		t.Fatalf("Wrong number of prow jobs: %d", len(afterThirdSync.Items))
+	}
+	if !afterThirdSync.Items[0].Complete() {
+		t.Fatal("Prow job didn't complete.")
+	}
+	if afterThirdSync.Items[0].Status.State != prowapi.SuccessState {
+		t.Fatalf("Should be success: %v", afterThirdSync.Items[0].Status.State)
+	}
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: ctrlruntimeclient.ObjectKeyFromObject(&pj)}); err != nil {
+		t.Fatalf("Error on fourth sync: %v", err)
+	}
+}
+
+func TestReconcileBatchesStatusPatches(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:     "boop",
+			Type:    prowapi.PeriodicJob,
+			Agent:   prowapi.KubernetesAgent,
+			PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State: prowapi.TriggeredState,
+		},
+	}
+
+	ctx := context.Background()
+	config := newFakeConfigAgent(t, 0, nil).Config
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	fakeProwJobClient := &patchTrackingFakeClient{Client: fakeMgr.GetClient()}
+
+	r := &reconciler{
+		pjClient: fakeProwJobClient,
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: fakectrlruntimeclient.NewClientBuilder().Build()},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: config,
+		totURL: totServ.URL,
+		clock:  clock.RealClock{},
+	}
+	if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+
+	if fakeProwJobClient.numPatches != 1 {
+		t.Errorf("expected exactly one patch, got %d", fakeProwJobClient.numPatches)
+	}
+
+	var actual prowapi.ProwJob
+	if err := fakeProwJobClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+		t.Fatalf("failed to get prowjob from client: %v", err)
+	}
+	if actual.Status.State != prowapi.PendingState {
+		t.Errorf("expected state %s, got %s", prowapi.PendingState, actual.Status.State)
+	}
+	if actual.Status.PendingTime == nil {
+		t.Error("expected pending time to be set")
+	}
+	if actual.Status.BuildID == "" {
+		t.Error("expected build ID to be set")
+	}
+	if actual.Status.PodName == "" {
+		t.Error("expected pod name to be set")
+	}
+	if actual.Status.URL == "" {
+		t.Error("expected URL to be set")
+	}
+
+	// Nothing changes while the pod is pending, so no further patch is expected.
+	if _, err := r.reconcile(ctx, actual.DeepCopy()); err != nil {
+		t.Fatalf("second reconcile failed: %v", err)
+	}
+	if fakeProwJobClient.numPatches != 1 {
+		t.Errorf("expected no patch for a reconcile without changes, got %d patches in total", fakeProwJobClient.numPatches)
+	}
+}
+
+func TestSyncTriggeredJobValidatesPodReferences(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	testCases := []struct {
+		name              string
+		validate          bool
+		existingObjs      []ctrlruntimeclient.Object
+		expectedState     prowapi.ProwJobState
+		expectedPods      int
+		expectedInMessage []string
+	}{
+		{
+			name:              "missing secret and configmap error the job",
+			validate:          true,
+			expectedState:     prowapi.ErrorState,
+			expectedInMessage: []string{`secret "missing-secret"`, `configmap "missing-configmap"`},
+		},
+		{
+			name:     "existing secret and configmap start the pod",
+			validate: true,
+			existingObjs: []ctrlruntimeclient.Object{
+				&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "missing-secret", Namespace: "pods"}},
+				&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "missing-configmap", Namespace: "pods"}},
+			},
+			expectedState: prowapi.PendingState,
+			expectedPods:  1,
+		},
+		{
+			name:          "validation disabled starts the pod",
+			expectedState: prowapi.PendingState,
+			expectedPods:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:   "boop",
+					Type:  prowapi.PeriodicJob,
+					Agent: prowapi.KubernetesAgent,
+					PodSpec: &v1.PodSpec{
+						Containers: []v1.Container{{
+							Name: "test-name",
+							Env: []v1.EnvVar{{
+								Name: "TOKEN",
+								ValueFrom: &v1.EnvVarSource{
+									ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+										LocalObjectReference: v1.LocalObjectReference{Name: "missing-configmap"},
+										Key:                  "token",
+									},
+								},
+							}},
+						}},
+						Volumes: []v1.Volume{{
+							Name:         "creds",
+							VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "missing-secret"}},
+						}},
+					},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.TriggeredState,
+				},
+			}
+
+			ctx := context.Background()
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			fakeConfigAgent.c.Plank.ValidatePodReferences = tc.validate
+			fakeMgr, err := testutil.NewFakeManager(
+				ctx,
+				[]runtime.Object{&pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			fakeBuildClient := fakectrlruntimeclient.NewClientBuilder().WithObjects(tc.existingObjs...).Build()
+
+			r := &reconciler{
+				pjClient: fakeMgr.GetClient(),
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: fakeBuildClient},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: fakeConfigAgent.Config,
+				totURL: totServ.URL,
+				clock:  clock.RealClock{},
+			}
+			if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+
+			var actual prowapi.ProwJob
+			if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+				t.Fatalf("failed to get prowjob from client: %v", err)
+			}
+			if actual.Status.State != tc.expectedState {
+				t.Errorf("expected state %s, got %s", tc.expectedState, actual.Status.State)
+			}
+			for _, expected := range tc.expectedInMessage {
+				if !strings.Contains(actual.Status.Description, expected) {
+					t.Errorf("expected description %q to contain %q", actual.Status.Description, expected)
+				}
+			}
+
+			pods := &v1.PodList{}
+			if err := fakeBuildClient.List(ctx, pods); err != nil {
+				t.Fatalf("failed to list pods: %v", err)
+			}
+			if len(pods.Items) != tc.expectedPods {
+				t.Errorf("expected %d pods, got %d", tc.expectedPods, len(pods.Items))
+			}
+		})
+	}
+}
+
+// namespaceRoutingClient creates all objects in the given namespace, like a
+// misconfigured namespace routing would.
+type namespaceRoutingClient struct {
+	ctrlruntimeclient.Client
+	namespace string
+}
+
+func (c *namespaceRoutingClient) Create(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.CreateOption) error {
+	obj.SetNamespace(c.namespace)
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func TestSyncTriggeredJobVerifiesPodNamespace(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	testCases := []struct {
+		name              string
+		routedNamespace   string
+		expectedState     prowapi.ProwJobState
+		expectedPods      int
+		expectedInMessage string
+	}{
+		{
+			name:              "pod created in a different namespace errors the job",
+			routedNamespace:   "elsewhere",
+			expectedState:     prowapi.ErrorState,
+			expectedInMessage: `was created in namespace "elsewhere" instead of the pod namespace "pods"`,
+		},
+		{
+			name:            "pod created in the pod namespace starts the job",
+			routedNamespace: "pods",
+			expectedState:   prowapi.PendingState,
+			expectedPods:    1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "boop",
+					Type:    prowapi.PeriodicJob,
+					Agent:   prowapi.KubernetesAgent,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name"}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.TriggeredState,
+				},
+			}
+
+			ctx := context.Background()
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			fakeConfigAgent.c.Plank.VerifyPodNamespace = true
+			fakeMgr, err := testutil.NewFakeManager(
+				ctx,
+				[]runtime.Object{&pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			fakeBuildClient := fakectrlruntimeclient.NewClientBuilder().Build()
+
+			r := &reconciler{
+				pjClient: fakeMgr.GetClient(),
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: &namespaceRoutingClient{Client: fakeBuildClient, namespace: tc.routedNamespace}},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: fakeConfigAgent.Config,
+				totURL: totServ.URL,
+				clock:  clock.RealClock{},
+			}
+			if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+
+			var actual prowapi.ProwJob
+			if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+				t.Fatalf("failed to get prowjob from client: %v", err)
+			}
+			if actual.Status.State != tc.expectedState {
+				t.Errorf("expected state %s, got %s", tc.expectedState, actual.Status.State)
+			}
+			if !strings.Contains(actual.Status.Description, tc.expectedInMessage) {
+				t.Errorf("expected description %q to contain %q", actual.Status.Description, tc.expectedInMessage)
+			}
+
+			pods := &v1.PodList{}
+			if err := fakeBuildClient.List(ctx, pods); err != nil {
+				t.Fatalf("failed to list pods: %v", err)
+			}
+			if len(pods.Items) != tc.expectedPods {
+				t.Errorf("expected %d pods, got %d", tc.expectedPods, len(pods.Items))
+			}
+		})
+	}
+}
+
+func TestSyncTriggeredJobWithDependencies(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	testCases := []struct {
+		name             string
+		dependencyState  prowapi.ProwJobState
+		expectedState    prowapi.ProwJobState
+		expectedPods     int
+		expectedDesc     string
+		expectRequeueing bool
+	}{
+		{
+			name:             "dependency still running",
+			dependencyState:  prowapi.PendingState,
+			expectedState:    prowapi.TriggeredState,
+			expectRequeueing: true,
+		},
+		{
+			name:            "dependency succeeded",
+			dependencyState: prowapi.SuccessState,
+			expectedState:   prowapi.PendingState,
+			expectedPods:    1,
+		},
+		{
+			name:            "dependency failed",
+			dependencyState: prowapi.FailureState,
+			expectedState:   prowapi.ErrorState,
+			expectedDesc:    "Dependency dependency did not succeed: failure.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dependency := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dependency",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:   "build",
+					Type:  prowapi.PeriodicJob,
+					Agent: prowapi.KubernetesAgent,
+				},
+				Status: prowapi.ProwJobStatus{
+					State: tc.dependencyState,
+				},
+			}
+			if tc.dependencyState != prowapi.PendingState {
+				dependency.SetComplete()
+			}
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dependent",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:       "deploy",
+					Type:      prowapi.PeriodicJob,
+					Agent:     prowapi.KubernetesAgent,
+					PodSpec:   &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+					DependsOn: []string{"dependency"},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.TriggeredState,
+				},
+			}
+
+			ctx := context.Background()
+			config := newFakeConfigAgent(t, 0, nil).Config
+			fakeMgr, err := testutil.NewFakeManager(
+				ctx,
+				[]runtime.Object{&dependency, &pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			fakeBuildClient := fakectrlruntimeclient.NewClientBuilder().Build()
+
+			r := &reconciler{
+				pjClient: fakeMgr.GetClient(),
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: fakeBuildClient},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: config,
+				totURL: totServ.URL,
+				clock:  clock.RealClock{},
+			}
+			res, err := r.reconcile(ctx, pj.DeepCopy())
+			if err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+			if requeueing := res != nil && res.RequeueAfter > 0; requeueing != tc.expectRequeueing {
+				t.Errorf("expected requeueing to be %t, got result %v", tc.expectRequeueing, res)
+			}
+
+			var actual prowapi.ProwJob
+			if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+				t.Fatalf("failed to get prowjob from client: %v", err)
+			}
+			if actual.Status.State != tc.expectedState {
+				t.Errorf("expected state %s, got %s", tc.expectedState, actual.Status.State)
+			}
+			if tc.expectedDesc != "" && actual.Status.Description != tc.expectedDesc {
+				t.Errorf("expected description %q, got %q", tc.expectedDesc, actual.Status.Description)
+			}
+
+			pods := &v1.PodList{}
+			if err := fakeBuildClient.List(ctx, pods); err != nil {
+				t.Fatalf("failed to list pods: %v", err)
+			}
+			if len(pods.Items) != tc.expectedPods {
+				t.Errorf("expected %d pods, got %d", tc.expectedPods, len(pods.Items))
+			}
+		})
+	}
+}
+
+type fakeHistorySink struct {
+	summaries []ProwJobSummary
+}
+
+func (s *fakeHistorySink) Record(_ context.Context, summary ProwJobSummary) error {
+	s.summaries = append(s.summaries, summary)
+	return nil
+}
+
+func TestReconcileRecordsCompletedJobsInHistorySink(t *testing.T) {
+	startTime := metav1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	pendingTime := metav1.NewTime(startTime.Add(time.Minute))
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "boop-42",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:       "boop",
+			Type:      prowapi.PostsubmitJob,
+			PodSpec:   &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+			Refs:      &prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "abcdef"},
+			ExtraRefs: []prowapi.Refs{{Org: "other", Repo: "dep", BaseRef: "main"}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:       prowapi.PendingState,
+			StartTime:   startTime,
+			PendingTime: &pendingTime,
+			PodName:     "boop-42",
+			BuildID:     "0987654321",
+		},
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "boop-42",
+			Namespace: "pods",
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodSucceeded,
+		},
+	}
+
+	ctx := context.Background()
+	config := newFakeConfigAgent(t, 0, nil).Config
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	sink := &fakeHistorySink{}
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: fakectrlruntimeclient.NewClientBuilder().WithObjects(pod).Build()},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: config,
+		clock:  clock.RealClock{},
+	}
+	WithHistorySink(sink)(r)
+	if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+
+	if len(sink.summaries) != 1 {
+		t.Fatalf("expected one summary to be recorded, got %d", len(sink.summaries))
+	}
+	summary := sink.summaries[0]
+	if summary.CompletionTime.IsZero() {
+		t.Error("expected completion time to be set")
+	}
+	summary.CompletionTime = time.Time{}
+	expected := ProwJobSummary{
+		Name:        "boop-42",
+		Job:         "boop",
+		Type:        prowapi.PostsubmitJob,
+		State:       prowapi.SuccessState,
+		Description: "Job succeeded.",
+		BuildID:     "0987654321",
+		URL:         "boop-42/success",
+		Cluster:     prowapi.DefaultClusterAlias,
+		StartTime:   startTime.Time,
+		PendingTime: &pendingTime.Time,
+		Refs:        &prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "abcdef"},
+		ExtraRefs:   []prowapi.Refs{{Org: "other", Repo: "dep", BaseRef: "main"}},
+	}
+	if diff := cmp.Diff(expected, summary); diff != "" {
+		t.Errorf("unexpected summary (-want +got):\n%s", diff)
+	}
+
+	// Completed jobs are not synced again, so they are recorded only once.
+	var actual prowapi.ProwJob
+	if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+		t.Fatalf("failed to get prowjob from client: %v", err)
+	}
+	if _, err := r.reconcile(ctx, &actual); err != nil {
+		t.Fatalf("second reconcile failed: %v", err)
+	}
+	if len(sink.summaries) != 1 {
+		t.Errorf("expected the job to be recorded only once, got %d summaries", len(sink.summaries))
+	}
+}
+
+func TestReconcileRequeuesWhenProwJobQuotaIsExceeded(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:     "boop",
+			Type:    prowapi.PeriodicJob,
+			Agent:   prowapi.KubernetesAgent,
+			PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State: prowapi.TriggeredState,
+		},
+	}
+
+	ctx := context.Background()
+	config := newFakeConfigAgent(t, 0, nil).Config
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+
+	r := &reconciler{
+		pjClient: &quotaExceededFakeClient{Client: fakeMgr.GetClient()},
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: fakectrlruntimeclient.NewClientBuilder().Build()},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: config,
+		totURL: totServ.URL,
+		clock:  clock.RealClock{},
+	}
+	quotaExceededBefore := promtestutil.ToFloat64(plankMetrics.prowJobQuotaExceeded.WithLabelValues("prowjobs"))
+
+	res, err := r.reconcile(ctx, pj.DeepCopy())
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if res == nil || res.RequeueAfter != quotaExceededRequeueInterval {
+		t.Errorf("expected to be requeued after %v, got %v", quotaExceededRequeueInterval, res)
+	}
+	if diff := promtestutil.ToFloat64(plankMetrics.prowJobQuotaExceeded.WithLabelValues("prowjobs")) - quotaExceededBefore; diff != 1 {
+		t.Errorf("expected the quota exceeded metric to be incremented once, got %v", diff)
+	}
+
+	var actual prowapi.ProwJob
+	if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+		t.Fatalf("failed to get prowjob from client: %v", err)
+	}
+	if actual.Status.State != prowapi.TriggeredState {
+		t.Errorf("expected job to stay in state %s, got %s", prowapi.TriggeredState, actual.Status.State)
+	}
+}
+
+func TestSyncTriggeredJobEnforcesPeriodicMinInterval(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	now := time.Now()
+	testCases := []struct {
+		name          string
+		minInterval   *metav1.Duration
+		previousStart time.Time
+		expectedState prowapi.ProwJobState
+		expectedPods  int
+	}{
+		{
+			name:          "no minimum interval configured",
+			previousStart: now.Add(-time.Minute),
+			expectedState: prowapi.PendingState,
+			expectedPods:  1,
+		},
+		{
+			name:          "previous run started too recently",
+			minInterval:   &metav1.Duration{Duration: time.Hour},
+			previousStart: now.Add(-time.Minute),
+			expectedState: prowapi.AbortedState,
+		},
+		{
+			name:          "previous run started long enough ago",
+			minInterval:   &metav1.Duration{Duration: time.Hour},
+			previousStart: now.Add(-2 * time.Hour),
+			expectedState: prowapi.PendingState,
+			expectedPods:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			previousPendingTime := metav1.NewTime(tc.previousStart)
+			previous := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "previous",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:   "periodic",
+					Type:  prowapi.PeriodicJob,
+					Agent: prowapi.KubernetesAgent,
+				},
+				Status: prowapi.ProwJobStatus{
+					State:       prowapi.PendingState,
+					StartTime:   metav1.NewTime(tc.previousStart),
+					PendingTime: &previousPendingTime,
+				},
+			}
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "next",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "periodic",
+					Type:    prowapi.PeriodicJob,
+					Agent:   prowapi.KubernetesAgent,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:     prowapi.TriggeredState,
+					StartTime: metav1.NewTime(now),
+				},
+			}
+
+			ctx := context.Background()
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			if tc.minInterval != nil {
+				fakeConfigAgent.c.Plank.PeriodicMinIntervals = map[string]*metav1.Duration{"periodic": tc.minInterval}
+			}
+			fakeMgr, err := testutil.NewFakeManager(
+				ctx,
+				[]runtime.Object{&previous, &pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			fakeBuildClient := fakectrlruntimeclient.NewClientBuilder().Build()
+
+			r := &reconciler{
+				pjClient: fakeMgr.GetClient(),
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: fakeBuildClient},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: fakeConfigAgent.Config,
+				totURL: totServ.URL,
+				clock:  clock.RealClock{},
+			}
+			if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+
+			var actual prowapi.ProwJob
+			if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+				t.Fatalf("failed to get prowjob from client: %v", err)
+			}
+			if actual.Status.State != tc.expectedState {
+				t.Errorf("expected state %s, got %s (%s)", tc.expectedState, actual.Status.State, actual.Status.Description)
+			}
+
+			pods := &v1.PodList{}
+			if err := fakeBuildClient.List(ctx, pods); err != nil {
+				t.Fatalf("failed to list pods: %v", err)
+			}
+			if len(pods.Items) != tc.expectedPods {
+				t.Errorf("expected %d pods, got %d", tc.expectedPods, len(pods.Items))
+			}
+		})
+	}
+}
+
+func TestSyncTriggeredJobRecordsAdmissionSnapshot(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	pendingJob := func(name, job, queue, cluster string) runtime.Object {
+		return &prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "prowjobs",
+			},
+			Spec: prowapi.ProwJobSpec{
+				Job:          job,
+				Type:         prowapi.PeriodicJob,
+				Agent:        prowapi.KubernetesAgent,
+				Cluster:      cluster,
+				JobQueueName: queue,
+			},
+			Status: prowapi.ProwJobStatus{
+				State: prowapi.PendingState,
+			},
+		}
+	}
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "admitted",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:          "boop",
+			Type:         prowapi.PeriodicJob,
+			Agent:        prowapi.KubernetesAgent,
+			JobQueueName: "queue",
+			PodSpec:      &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State: prowapi.TriggeredState,
+		},
+	}
+
+	ctx := context.Background()
+	fakeConfigAgent := newFakeConfigAgent(t, 0, map[string]int{"queue": 10})
+	fakeConfigAgent.c.Plank.RecordAdmissionSnapshot = true
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{
+			&pj,
+			pendingJob("same-job", "boop", "", prowapi.DefaultClusterAlias),
+			pendingJob("same-queue", "other", "queue", prowapi.DefaultClusterAlias),
+			pendingJob("other-cluster", "other", "", "other-cluster"),
+		},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: fakectrlruntimeclient.NewClientBuilder().Build()},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: fakeConfigAgent.Config,
+		totURL: totServ.URL,
+		clock:  clock.RealClock{},
+	}
+	if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+
+	var actual prowapi.ProwJob
+	if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+		t.Fatalf("failed to get prowjob from client: %v", err)
+	}
+	if actual.Status.State != prowapi.PendingState {
+		t.Errorf("expected state %s, got %s", prowapi.PendingState, actual.Status.State)
+	}
+	raw, ok := actual.Annotations[kube.AdmissionSnapshotAnnotation]
+	if !ok {
+		t.Fatalf("expected annotation %s to be set", kube.AdmissionSnapshotAnnotation)
+	}
+	var snapshot admissionSnapshot
+	if err := json.Unmarshal([]byte(raw), &snapshot); err != nil {
+		t.Fatalf("failed to unmarshal admission snapshot %q: %v", raw, err)
+	}
+	expected := admissionSnapshot{Pending: 3, JobPending: 1, QueuePending: 1, ClusterPending: 2}
+	if diff := cmp.Diff(expected, snapshot); diff != "" {
+		t.Errorf("unexpected admission snapshot (-want +got):\n%s", diff)
+	}
+}
+
+func TestReconcileCountsAPICalls(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:     "boop",
+			Type:    prowapi.PeriodicJob,
+			Agent:   prowapi.KubernetesAgent,
+			PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State: prowapi.TriggeredState,
+		},
+	}
+
+	ctx := context.Background()
+	config := newFakeConfigAgent(t, 0, nil).Config
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+
+	r := newReconciler(ctx, fakeMgr.GetClient(), nil, config, nil, totServ.URL, nil)
+	r.buildClients[prowapi.DefaultClusterAlias] = buildClient{Client: newCountingClient(fakectrlruntimeclient.NewClientBuilder().Build())}
+
+	calls := func(verb, resource string) float64 {
+		return promtestutil.ToFloat64(plankMetrics.apiCalls.WithLabelValues(verb, resource))
+	}
+	expected := map[[2]string]float64{
+		{"list", "prowjob"}:  1, // terminateDupes
+		{"patch", "prowjob"}: 1, // status update
+		{"get", "prowjob"}:   0, // no concurrency limits, so no waiting for the cache
+		{"get", "pod"}:       2, // looking for an existing pod and waiting for the created one
+		{"create", "pod"}:    1,
+		{"delete", "pod"}:    0,
+	}
+	before := map[[2]string]float64{}
+	for key := range expected {
+		before[key] = calls(key[0], key[1])
+	}
+
+	if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+
+	for key, count := range expected {
+		if actual := calls(key[0], key[1]) - before[key]; actual != count {
+			t.Errorf("expected %v %s calls for %s, got %v", count, key[0], key[1], actual)
+		}
+	}
+}
+
+func TestSyncPendingJobCompletesOnTerminatedContainers(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	terminated := v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 0, FinishedAt: metav1.Now()}}
+	testCases := []struct {
+		name                 string
+		completeOnTerminated bool
+		deleteLingeringPods  bool
+		testState            v1.ContainerState
+		expectedState        prowapi.ProwJobState
+		expectPod            bool
+	}{
+		{
+			name:          "option disabled, job stays pending",
+			testState:     terminated,
+			expectedState: prowapi.PendingState,
+			expectPod:     true,
+		},
+		{
+			name:                 "option enabled, job succeeds",
+			completeOnTerminated: true,
+			testState:            terminated,
+			expectedState:        prowapi.SuccessState,
+			expectPod:            true,
+		},
+		{
+			name:                 "option enabled with deletion, job succeeds and pod is deleted",
+			completeOnTerminated: true,
+			deleteLingeringPods:  true,
+			testState:            terminated,
+			expectedState:        prowapi.SuccessState,
+		},
+		{
+			name:                 "option enabled, failed test container keeps job pending",
+			completeOnTerminated: true,
+			testState:            v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1, FinishedAt: metav1.Now()}},
+			expectedState:        prowapi.PendingState,
+			expectPod:            true,
+		},
+		{
+			name:                 "option enabled, running test container keeps job pending",
+			completeOnTerminated: true,
+			testState:            v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+			expectedState:        prowapi.PendingState,
+			expectPod:            true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "boop",
+					Type:    prowapi.PeriodicJob,
+					Agent:   prowapi.KubernetesAgent,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test"}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "boop-42",
+				},
+			}
+			pod := v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "pods",
+				},
+				Status: v1.PodStatus{
+					Phase:     v1.PodRunning,
+					StartTime: startTime(time.Now()),
+					ContainerStatuses: []v1.ContainerStatus{
+						{Name: "test", State: tc.testState},
+						{Name: "sidecar", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+					},
+				},
+			}
+
+			ctx := context.Background()
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			fakeConfigAgent.c.Plank.CompleteOnTerminatedContainers = tc.completeOnTerminated
+			fakeConfigAgent.c.Plank.DeleteLingeringPods = tc.deleteLingeringPods
+			fakeMgr, err := testutil.NewFakeManager(
+				ctx,
+				[]runtime.Object{&pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			fakeProwJobClient := fakeMgr.GetClient()
+			fakeBuildClient := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(&pod).Build()
+			r := &reconciler{
+				pjClient: fakeProwJobClient,
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: fakeBuildClient},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: fakeConfigAgent.Config,
+				totURL: totServ.URL,
+				clock:  clock.RealClock{},
+			}
+			if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+
+			var actual prowapi.ProwJob
+			if err := fakeProwJobClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+				t.Fatalf("failed to get prowjob from client: %v", err)
+			}
+			if actual.Status.State != tc.expectedState {
+				t.Errorf("expected state %s, got %s", tc.expectedState, actual.Status.State)
+			}
+			if actual.Complete() != (tc.expectedState == prowapi.SuccessState) {
+				t.Errorf("expected completion to be %t, got %t", tc.expectedState == prowapi.SuccessState, actual.Complete())
+			}
+			err = fakeBuildClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pod), &v1.Pod{})
+			if tc.expectPod && err != nil {
+				t.Errorf("expected pod to exist, got: %v", err)
+			}
+			if !tc.expectPod && !kapierrors.IsNotFound(err) {
+				t.Errorf("expected pod to be deleted, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestSyncPendingJobRecordsRevivalAttempts(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "boop-42",
+			Namespace: "prowjobs",
+			Annotations: map[string]string{
+				kube.RevivalAttemptsAnnotation: `[{"attempt":0,"pod_name":"boop-42","cause":"unreachable"}]`,
+			},
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:     "boop",
+			Type:    prowapi.PeriodicJob,
+			Agent:   prowapi.KubernetesAgent,
+			PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test"}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:           prowapi.PendingState,
+			PodName:         "boop-42",
+			PodRevivalCount: 1,
+		},
+	}
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "boop-42",
+			Namespace: "pods",
+		},
+		Status: v1.PodStatus{
+			Phase:   v1.PodFailed,
+			Reason:  Evicted,
+			Message: "The node was low on resource: memory.",
+			InitContainerStatuses: []v1.ContainerStatus{
+				{Name: "clonerefs", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Completed", ExitCode: 0}}},
+			},
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "test", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error", Message: "killed", ExitCode: 137}}},
+				{Name: "sidecar", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+	fakeConfigAgent.c.Plank.RecordRevivalAttempts = true
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	fakeBuildClient := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(&pod).Build()
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: fakeBuildClient},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: fakeConfigAgent.Config,
+		totURL: totServ.URL,
+		clock:  clock.RealClock{},
+	}
+	if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+
+	var actual prowapi.ProwJob
+	if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+		t.Fatalf("failed to get prowjob from client: %v", err)
+	}
+	if actual.Status.PodRevivalCount != 2 {
+		t.Errorf("expected pod revival count 2, got %d", actual.Status.PodRevivalCount)
+	}
+	if err := fakeBuildClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pod), &v1.Pod{}); !kapierrors.IsNotFound(err) {
+		t.Errorf("expected pod to be deleted for revival, got: %v", err)
+	}
+
+	raw, ok := actual.Annotations[kube.RevivalAttemptsAnnotation]
+	if !ok {
+		t.Fatalf("expected annotation %s to be set", kube.RevivalAttemptsAnnotation)
+	}
+	var attempts []revivalAttempt
+	if err := json.Unmarshal([]byte(raw), &attempts); err != nil {
+		t.Fatalf("failed to unmarshal revival attempts %q: %v", raw, err)
+	}
+	zero, killed := int32(0), int32(137)
+	expected := []revivalAttempt{
+		{Attempt: 0, PodName: "boop-42", Cause: PodUnexpectedStopCauseUnreachable},
+		{
+			Attempt: 1,
+			PodName: "boop-42",
+			Cause:   PodUnexpectedStopCauseEvicted,
+			Reason:  Evicted,
+			Message: "The node was low on resource: memory.",
+			Containers: []revivalAttemptContainer{
+				{Name: "clonerefs", State: "terminated", Reason: "Completed", ExitCode: &zero},
+				{Name: "test", State: "terminated", Reason: "Error", Message: "killed", ExitCode: &killed},
+				{Name: "sidecar", State: "running"},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, attempts); diff != "" {
+		t.Errorf("unexpected revival attempts (-want +got):\n%s", diff)
+	}
+}
+
+func TestSyncPendingJobWaitsForPodInfoUpload(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "boop-42",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:     "boop",
+			Type:    prowapi.PeriodicJob,
+			Agent:   prowapi.KubernetesAgent,
+			PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test"}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:   prowapi.PendingState,
+			PodName: "boop-42",
+		},
+	}
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "boop-42",
+			Namespace:  "pods",
+			Finalizers: []string{kubernetesreporterapi.FinalizerName},
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodSucceeded,
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "test", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 0, FinishedAt: metav1.Now()}}},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+	fakeConfigAgent.c.Plank.WaitForPodInfoUpload = true
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	fakeBuildClient := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(&pod).Build()
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: fakeBuildClient},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: fakeConfigAgent.Config,
+		totURL: totServ.URL,
+		clock:  clock.RealClock{},
+	}
+
+	if _, actual := reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj); actual.Complete() || actual.Status.State != prowapi.PendingState {
+		t.Errorf("expected job to stay pending while the pod has the %s finalizer, got state %s", kubernetesreporterapi.FinalizerName, actual.Status.State)
+	}
+
+	// Crier uploaded the pod info and removed its finalizer.
+	var current v1.Pod
+	if err := fakeBuildClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pod), &current); err != nil {
+		t.Fatalf("failed to get pod: %v", err)
+	}
+	current.Finalizers = nil
+	if err := fakeBuildClient.Update(ctx, &current); err != nil {
+		t.Fatalf("failed to remove finalizer from pod: %v", err)
+	}
+
+	if _, actual := reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj); !actual.Complete() || actual.Status.State != prowapi.SuccessState {
+		t.Errorf("expected job to succeed once the finalizer got removed, got state %s", actual.Status.State)
+	}
+}
+
+func TestSyncTriggeredJobFailsOverFromDrainingCluster(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	testCases := []struct {
+		name             string
+		draining         []string
+		expectedCluster  string
+		expectPodCreated map[string]bool
+	}{
+		{
+			name:             "job of draining cluster starts on failover cluster",
+			draining:         []string{"primary"},
+			expectedCluster:  "failover",
+			expectPodCreated: map[string]bool{"primary": false, "failover": true},
+		},
+		{
+			name:             "job of healthy cluster starts on its cluster",
+			expectedCluster:  "primary",
+			expectPodCreated: map[string]bool{"primary": true, "failover": false},
+		},
+		{
+			name:             "job starts on its cluster if the failover cluster is draining too",
+			draining:         []string{"primary", "failover"},
+			expectedCluster:  "primary",
+			expectPodCreated: map[string]bool{"primary": true, "failover": false},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "boop",
+					Type:    prowapi.PeriodicJob,
+					Agent:   prowapi.KubernetesAgent,
+					Cluster: "primary",
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.TriggeredState,
+				},
+			}
+
+			ctx := context.Background()
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			fakeConfigAgent.c.Plank.DrainingClusters = tc.draining
+			fakeConfigAgent.c.Plank.ClusterFailover = map[string][]string{"primary": {"failover"}}
+			fakeMgr, err := testutil.NewFakeManager(
+				ctx,
+				[]runtime.Object{&pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			buildClients := map[string]buildClient{
+				"primary":  {Client: fakectrlruntimeclient.NewClientBuilder().Build()},
+				"failover": {Client: fakectrlruntimeclient.NewClientBuilder().Build()},
+			}
+			r := &reconciler{
+				pjClient:     fakeMgr.GetClient(),
+				buildClients: buildClients,
+				log:          logrus.NewEntry(logrus.StandardLogger()),
+				config:       fakeConfigAgent.Config,
+				totURL:       totServ.URL,
+				clock:        clock.RealClock{},
+			}
+			if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+
+			var actual prowapi.ProwJob
+			if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+				t.Fatalf("failed to get prowjob from client: %v", err)
+			}
+			if actual.Status.State != prowapi.PendingState {
+				t.Errorf("expected state %s, got %s", prowapi.PendingState, actual.Status.State)
+			}
+			if actual.Spec.Cluster != tc.expectedCluster {
+				t.Errorf("expected job to run on cluster %q, got %q", tc.expectedCluster, actual.Spec.Cluster)
+			}
+			for cluster, expected := range tc.expectPodCreated {
+				pods := &v1.PodList{}
+				if err := buildClients[cluster].List(ctx, pods); err != nil {
+					t.Fatalf("failed to list pods in cluster %s: %v", cluster, err)
+				}
+				if created := len(pods.Items) > 0; created != expected {
+					t.Errorf("expected pod to be created in cluster %s: %t, got %t", cluster, expected, created)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncTriggeredJobEnforcesGlobalMaxRunningPods(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	newPod := func(name string, phase v1.PodPhase) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "pods",
+				Labels:    map[string]string{kube.CreatedByProw: "true"},
+			},
+			Status: v1.PodStatus{Phase: phase},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		maxPods       int
+		expectedState prowapi.ProwJobState
+	}{
+		{
+			name:          "cap reached, job stays triggered",
+			maxPods:       3,
+			expectedState: prowapi.TriggeredState,
+		},
+		{
+			name:          "cap not reached, job starts",
+			maxPods:       4,
+			expectedState: prowapi.PendingState,
+		},
+		{
+			name:          "no cap, job starts",
+			expectedState: prowapi.PendingState,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "boop",
+					Type:    prowapi.PeriodicJob,
+					Agent:   prowapi.KubernetesAgent,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.TriggeredState,
+				},
+			}
+
+			ctx := context.Background()
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			fakeConfigAgent.c.Plank.GlobalMaxRunningPods = tc.maxPods
+			fakeMgr, err := testutil.NewFakeManager(
+				ctx,
+				[]runtime.Object{&pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			// Three pods are running across both clusters, the finished and the
+			// foreign ones don't count.
+			defaultClusterClient := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(
+				newPod("running", v1.PodRunning),
+				newPod("pending", v1.PodPending),
+				newPod("succeeded", v1.PodSucceeded),
+				&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "foreign", Namespace: "pods"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+			).Build()
+			otherClusterClient := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(
+				newPod("running", v1.PodRunning),
+				newPod("failed", v1.PodFailed),
+			).Build()
+			r := &reconciler{
+				pjClient: fakeMgr.GetClient(),
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: defaultClusterClient},
+					"other":                     {Client: otherClusterClient},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: fakeConfigAgent.Config,
+				totURL: totServ.URL,
+				clock:  clock.RealClock{},
+			}
+			if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+
+			var actual prowapi.ProwJob
+			if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+				t.Fatalf("failed to get prowjob from client: %v", err)
+			}
+			if actual.Status.State != tc.expectedState {
+				t.Errorf("expected state %s, got %s", tc.expectedState, actual.Status.State)
+			}
+		})
+	}
+}
+
+func TestSyncTriggeredJobRecordsConcurrencyWait(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+			UID:       "blabla",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:          "concurrency-wait",
+			Type:         prowapi.PeriodicJob,
+			Agent:        prowapi.KubernetesAgent,
+			JobQueueName: "queue",
+			PodSpec:      &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:     prowapi.TriggeredState,
+			StartTime: metav1.NewTime(fakeClock.Now()),
+		},
+	}
+	blocking := &prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blocking",
+			Namespace: "prowjobs",
+			UID:       "blocking",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:          "other",
+			Type:         prowapi.PeriodicJob,
+			Agent:        prowapi.KubernetesAgent,
+			JobQueueName: "queue",
+		},
+		Status: prowapi.ProwJobStatus{
+			State: prowapi.PendingState,
+		},
+	}
+
+	ctx := context.Background()
+	fakeConfigAgent := newFakeConfigAgent(t, 0, map[string]int{"queue": 1})
+	fakeConfigAgent.c.Plank.RecordConcurrencyWait = true
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj, blocking},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: fakectrlruntimeclient.NewClientBuilder().Build()},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: fakeConfigAgent.Config,
+		totURL: totServ.URL,
+		clock:  fakeClock,
+	}
+
+	// The queue is full, so the job has to wait.
+	for i := 0; i < 3; i++ {
+		fakeClock.Step(20 * time.Second)
+		if _, actual := reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj); actual.Status.State != prowapi.TriggeredState {
+			t.Fatalf("expected job to wait in state %s, got %s", prowapi.TriggeredState, actual.Status.State)
+		}
+	}
+
+	if err := fakeMgr.GetClient().Delete(ctx, blocking); err != nil {
+		t.Fatalf("failed to delete blocking job: %v", err)
+	}
+	fakeClock.Step(30 * time.Second)
+	_, actual := reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj)
+	if actual.Status.State != prowapi.PendingState {
+		t.Fatalf("expected job to be started, got state %s", actual.Status.State)
+	}
+	if wait := actual.Annotations[kube.ConcurrencyWaitAnnotation]; wait != "1m30s" {
+		t.Errorf("expected annotation %s to be %q, got %q", kube.ConcurrencyWaitAnnotation, "1m30s", wait)
+	}
+
+	metric := &dto.Metric{}
+	if err := plankMetrics.concurrencyWait.WithLabelValues("concurrency-wait", "queue").(prometheus.Histogram).Write(metric); err != nil {
+		t.Fatalf("failed to read metric: %v", err)
+	}
+	if count, sum := metric.GetHistogram().GetSampleCount(), metric.GetHistogram().GetSampleSum(); count != 1 || sum != 90 {
+		t.Errorf("expected a single wait of 90s to be observed, got %d observations summing up to %vs", count, sum)
+	}
+}
+
+func TestReconcileStampsLastReconcileTime(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:     "last-reconcile-time",
+			Type:    prowapi.PeriodicJob,
+			Agent:   prowapi.KubernetesAgent,
+			PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:     prowapi.TriggeredState,
+			StartTime: metav1.NewTime(fakeClock.Now()),
+		},
+	}
+
+	ctx := context.Background()
+	fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	podClient := fakectrlruntimeclient.NewClientBuilder().Build()
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: podClient},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: fakeConfigAgent.Config,
+		totURL: totServ.URL,
+		clock:  fakeClock,
+	}
+	expectLastReconcileTime := func(pj prowapi.ProwJob, expected time.Time) {
+		t.Helper()
+		if pj.Status.LastReconcileTime == nil {
+			t.Fatalf("expected last reconcile time to be %v, got none", expected)
+		}
+		if !pj.Status.LastReconcileTime.Time.Equal(expected) {
+			t.Errorf("expected last reconcile time to be %v, got %v", expected, pj.Status.LastReconcileTime.Time)
+		}
+	}
+
+	fakeClock.Step(time.Minute)
+	started := fakeClock.Now()
+	_, actual := reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj)
+	if actual.Status.State != prowapi.PendingState {
+		t.Fatalf("expected job to be started, got state %s", actual.Status.State)
+	}
+	expectLastReconcileTime(actual, started)
+
+	// Nothing changes while the pod is still pending.
+	fakeClock.Step(time.Minute)
+	_, actual = reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj)
+	expectLastReconcileTime(actual, started)
+
+	var pod v1.Pod
+	if err := podClient.Get(ctx, types.NamespacedName{Namespace: "pods", Name: pj.Name}, &pod); err != nil {
+		t.Fatalf("failed to get pod: %v", err)
+	}
+	pod.Status.Phase = v1.PodSucceeded
+	if err := podClient.Status().Update(ctx, &pod); err != nil {
+		t.Fatalf("failed to update pod: %v", err)
+	}
+	fakeClock.Step(time.Minute)
+	finished := fakeClock.Now()
+	_, actual = reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj)
+	if actual.Status.State != prowapi.SuccessState {
+		t.Fatalf("expected job to succeed, got state %s", actual.Status.State)
+	}
+	expectLastReconcileTime(actual, finished)
+}
+
+func TestReconcileRecordsReconcileAttribution(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:     "reconcile-attribution",
+			Type:    prowapi.PeriodicJob,
+			Agent:   prowapi.KubernetesAgent,
+			PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:     prowapi.TriggeredState,
+			StartTime: metav1.Now(),
+		},
+	}
+
+	ctx := context.Background()
+	fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+	fakeConfigAgent.c.Plank.RecordReconcileAttribution = true
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	podClient := fakectrlruntimeclient.NewClientBuilder().Build()
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: podClient},
+		},
+		log:      logrus.NewEntry(logrus.StandardLogger()),
+		config:   fakeConfigAgent.Config,
+		totURL:   totServ.URL,
+		clock:    clock.RealClock{},
+		instance: "plank-0",
+	}
+	reconcileAndExpect := func(expectedSequence string) {
+		t.Helper()
+		var current prowapi.ProwJob
+		if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &current); err != nil {
+			t.Fatalf("failed to get prowjob from client: %v", err)
+		}
+		if _, err := r.reconcile(ctx, &current); err != nil {
+			t.Fatalf("reconcile failed: %v", err)
+		}
+		var actual prowapi.ProwJob
+		if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+			t.Fatalf("failed to get prowjob from client: %v", err)
+		}
+		if instance := actual.Annotations[kube.ReconcilerInstanceAnnotation]; instance != "plank-0" {
+			t.Errorf("expected annotation %s to be %q, got %q", kube.ReconcilerInstanceAnnotation, "plank-0", instance)
+		}
+		if sequence := actual.Annotations[kube.ReconcileSequenceAnnotation]; sequence != expectedSequence {
+			t.Errorf("expected annotation %s to be %q, got %q", kube.ReconcileSequenceAnnotation, expectedSequence, sequence)
+		}
+	}
+
+	// Starting the job changes its status.
+	reconcileAndExpect("1")
+	// Nothing changes while the pod is still pending.
+	reconcileAndExpect("1")
+
+	var pod v1.Pod
+	if err := podClient.Get(ctx, types.NamespacedName{Namespace: "pods", Name: pj.Name}, &pod); err != nil {
+		t.Fatalf("failed to get pod: %v", err)
+	}
+	pod.Status.Phase = v1.PodSucceeded
+	if err := podClient.Status().Update(ctx, &pod); err != nil {
+		t.Fatalf("failed to update pod: %v", err)
+	}
+	reconcileAndExpect("2")
+}
+
+func TestReconcileRecordsTransitionEvents(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:     "transition-events",
+			Type:    prowapi.PeriodicJob,
+			Agent:   prowapi.KubernetesAgent,
+			PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:     prowapi.TriggeredState,
+			StartTime: metav1.Now(),
+		},
+	}
+
+	ctx := context.Background()
+	fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	podClient := fakectrlruntimeclient.NewClientBuilder().Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: podClient},
+		},
+		log:      logrus.NewEntry(logrus.StandardLogger()),
+		config:   fakeConfigAgent.Config,
+		totURL:   totServ.URL,
+		clock:    clock.RealClock{},
+		recorder: recorder,
+	}
+	reconcileAndExpect := func(expected []string) {
+		t.Helper()
+		var current prowapi.ProwJob
+		if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &current); err != nil {
+			t.Fatalf("failed to get prowjob from client: %v", err)
+		}
+		if _, err := r.reconcile(ctx, &current); err != nil {
+			t.Fatalf("reconcile failed: %v", err)
+		}
+		var actual []string
+		for len(recorder.Events) > 0 {
+			actual = append(actual, <-recorder.Events)
+		}
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("unexpected events (-want +got):\n%s", diff)
+		}
+	}
+
+	reconcileAndExpect([]string{"Normal PodCreated Transitioned from triggered to pending: Job triggered."})
+	// Nothing is recorded while the state doesn't change.
+	reconcileAndExpect(nil)
+
+	var pod v1.Pod
+	if err := podClient.Get(ctx, types.NamespacedName{Namespace: "pods", Name: pj.Name}, &pod); err != nil {
+		t.Fatalf("failed to get pod: %v", err)
+	}
+	pod.Status.Phase = v1.PodFailed
+	if err := podClient.Status().Update(ctx, &pod); err != nil {
+		t.Fatalf("failed to update pod: %v", err)
+	}
+	reconcileAndExpect([]string{"Warning PodFailed Transitioned from pending to failure: Job failed."})
+}
+
+func TestStartPodSetsMaxJobLogBytes(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	testCases := []struct {
+		name           string
+		maxJobLogBytes int64
+		expected       string
+	}{
+		{
+			name:           "limit is passed to the entrypoint",
+			maxJobLogBytes: 1024,
+			expected:       "1024",
+		},
+		{
+			name: "no limit is configured",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:   "max-job-log-bytes",
+					Type:  prowapi.PeriodicJob,
+					Agent: prowapi.KubernetesAgent,
+					DecorationConfig: &prowapi.DecorationConfig{
+						Timeout:     &prowapi.Duration{Duration: time.Hour},
+						GracePeriod: &prowapi.Duration{Duration: 10 * time.Second},
+						UtilityImages: &prowapi.UtilityImages{
+							CloneRefs:  "clonerefs:tag",
+							InitUpload: "initupload:tag",
+							Entrypoint: "entrypoint:tag",
+							Sidecar:    "sidecar:tag",
+						},
+						GCSConfiguration: &prowapi.GCSConfiguration{
+							Bucket:       "my-bucket",
+							PathStrategy: prowapi.PathStrategyExplicit,
+						},
+					},
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test", Image: "tester", Command: []string{"/bin/thing"}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.TriggeredState,
+				},
+			}
+
+			ctx := context.Background()
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			fakeConfigAgent.c.Plank.MaxJobLogBytes = tc.maxJobLogBytes
+			fakeMgr, err := testutil.NewFakeManager(
+				ctx,
+				[]runtime.Object{&pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			podClient := fakectrlruntimeclient.NewClientBuilder().Build()
+			r := &reconciler{
+				pjClient: fakeMgr.GetClient(),
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: podClient},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: fakeConfigAgent.Config,
+				totURL: totServ.URL,
+				clock:  clock.RealClock{},
+			}
+			if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+
+			var pod v1.Pod
+			if err := podClient.Get(ctx, types.NamespacedName{Namespace: "pods", Name: pj.Name}, &pod); err != nil {
+				t.Fatalf("failed to get pod: %v", err)
+			}
+			for _, container := range pod.Spec.Containers {
+				var actual string
+				for _, env := range container.Env {
+					if env.Name == entrypoint.MaxLogBytesEnvVar {
+						actual = env.Value
+					}
+				}
+				if container.Name == "test" && actual != tc.expected {
+					t.Errorf("expected %s of the test container to be %q, got %q", entrypoint.MaxLogBytesEnvVar, tc.expected, actual)
+				}
+				if container.Name != "test" && actual != "" {
+					t.Errorf("expected %s not to be set on container %s, got %q", entrypoint.MaxLogBytesEnvVar, container.Name, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestStartPodNormalizesImagePullPolicies(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:   "image-pull-policies",
+			Type:  prowapi.PeriodicJob,
+			Agent: prowapi.KubernetesAgent,
+			PodSpec: &v1.PodSpec{
+				InitContainers: []v1.Container{
+					{Name: "init-digest", Image: "gcr.io/k8s-prow/init@sha256:0123456789abcdef", ImagePullPolicy: v1.PullAlways},
+				},
+				Containers: []v1.Container{
+					{Name: "digest", Image: "tester@sha256:0123456789abcdef", ImagePullPolicy: v1.PullAlways},
+					{Name: "latest", Image: "tester:latest", ImagePullPolicy: v1.PullNever},
+					{Name: "untagged", Image: "registry.local:5000/tester"},
+					{Name: "tagged", Image: "registry.local:5000/tester:v1", ImagePullPolicy: v1.PullNever},
+				},
+			},
+		},
+		Status: prowapi.ProwJobStatus{
+			State: prowapi.TriggeredState,
+		},
+	}
+
+	ctx := context.Background()
+	fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+	fakeConfigAgent.c.Plank.ImagePullPolicyRules = &config.ImagePullPolicyRules{
+		Digest: v1.PullIfNotPresent,
+		Latest: v1.PullAlways,
+	}
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	podClient := fakectrlruntimeclient.NewClientBuilder().Build()
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: podClient},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: fakeConfigAgent.Config,
+		totURL: totServ.URL,
+		clock:  clock.RealClock{},
+	}
+	if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+
+	var pod v1.Pod
+	if err := podClient.Get(ctx, types.NamespacedName{Namespace: "pods", Name: pj.Name}, &pod); err != nil {
+		t.Fatalf("failed to get pod: %v", err)
+	}
+	expected := map[string]v1.PullPolicy{
+		"init-digest": v1.PullIfNotPresent,
+		"digest":      v1.PullIfNotPresent,
+		"latest":      v1.PullAlways,
+		"untagged":    v1.PullAlways,
+		"tagged":      v1.PullNever,
+	}
+	actual := map[string]v1.PullPolicy{}
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		actual[container.Name] = container.ImagePullPolicy
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected image pull policies (-want +got):\n%s", diff)
+	}
+}
+
+func TestStartPodReportsMissingDecorationDefaults(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:     "missing-decoration-defaults",
+			Type:    prowapi.PeriodicJob,
+			Agent:   prowapi.KubernetesAgent,
+			Cluster: "no-defaults",
+			DecorationConfig: &prowapi.DecorationConfig{
+				Timeout:     &prowapi.Duration{Duration: time.Hour},
+				GracePeriod: &prowapi.Duration{Duration: 10 * time.Second},
+				UtilityImages: &prowapi.UtilityImages{
+					CloneRefs:  "clonerefs:tag",
+					InitUpload: "initupload:tag",
+					Entrypoint: "entrypoint:tag",
+					Sidecar:    "sidecar:tag",
+				},
+			},
+			PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test", Image: "tester", Command: []string{"/bin/thing"}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State: prowapi.TriggeredState,
+		},
+	}
+
+	ctx := context.Background()
+	fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			"no-defaults": {Client: fakectrlruntimeclient.NewClientBuilder().Build()},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: fakeConfigAgent.Config,
+		totURL: totServ.URL,
+		clock:  clock.RealClock{},
+	}
+	counter := plankMetrics.missingDecorationDefaults.WithLabelValues("no-defaults")
+	before := promtestutil.ToFloat64(counter)
+	if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if increase := promtestutil.ToFloat64(counter) - before; increase != 1 {
+		t.Errorf("expected the missing decoration defaults metric to be incremented once, got an increase of %v", increase)
+	}
+
+	var actual prowapi.ProwJob
+	if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+		t.Fatalf("failed to get prowjob from client: %v", err)
+	}
+	if actual.Status.State != prowapi.PendingState {
+		t.Errorf("expected job to be started anyway, got state %s", actual.Status.State)
+	}
+}
+
+func TestStartPodRetriesConflictWithNewBuildID(t *testing.T) {
+	var buildIDs int
+	totServ := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buildIDs++
+		fmt.Fprint(w, buildIDs)
+	}))
+	defer totServ.Close()
+
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:     "conflicting",
+			Type:    prowapi.PeriodicJob,
+			Agent:   prowapi.KubernetesAgent,
+			PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State: prowapi.TriggeredState,
+		},
+	}
+
+	ctx := context.Background()
+	fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+	fakeConfigAgent.c.Plank.RetryPodCreateConflicts = true
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	podClient := &conflictOnceClient{Client: fakectrlruntimeclient.NewClientBuilder().Build()}
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: podClient},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: fakeConfigAgent.Config,
+		totURL: totServ.URL,
+		clock:  clock.RealClock{},
+	}
+	if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+
+	var actual prowapi.ProwJob
+	if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+		t.Fatalf("failed to get prowjob from client: %v", err)
+	}
+	if actual.Status.State != prowapi.PendingState {
+		t.Fatalf("expected job to be started after retrying, got state %s", actual.Status.State)
+	}
+	if actual.Status.BuildID != "2" {
+		t.Errorf("expected job to get a new build ID %q, got %q", "2", actual.Status.BuildID)
+	}
+	var pod v1.Pod
+	if err := podClient.Get(ctx, types.NamespacedName{Namespace: "pods", Name: pj.Name}, &pod); err != nil {
+		t.Fatalf("failed to get pod: %v", err)
+	}
+	if buildID := pod.Labels[kube.ProwBuildIDLabel]; buildID != "2" {
+		t.Errorf("expected pod to have the new build ID %q, got %q", "2", buildID)
+	}
+}
+
+func TestStartPodAdoptsExistingPod(t *testing.T) {
+	testCases := []struct {
+		name            string
+		podOwner        string
+		expectedBuildID string
+		expectedErr     bool
+	}{
+		{
+			name:            "existing pod of the job is adopted",
+			podOwner:        "blabla",
+			expectedBuildID: "42",
+		},
+		{
+			name:        "existing pod of another job is not adopted",
+			podOwner:    "other",
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+			defer totServ.Close()
+
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "existing",
+					Type:    prowapi.PeriodicJob,
+					Agent:   prowapi.KubernetesAgent,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.TriggeredState,
+				},
+			}
+			existingPod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      pj.Name,
+					Namespace: "pods",
+					Labels: map[string]string{
+						kube.ProwJobIDLabel:   tc.podOwner,
+						kube.ProwBuildIDLabel: "42",
+					},
+				},
+			}
+
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			fakeConfigAgent.c.Plank.RetryPodCreateConflicts = true
+			podClient := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(existingPod).Build()
+			r := &reconciler{
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: podClient},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: fakeConfigAgent.Config,
+				totURL: totServ.URL,
+				clock:  clock.RealClock{},
+			}
+			buildID, podName, err := r.startPod(context.Background(), &pj)
+			if tc.expectedErr {
+				if !kapierrors.IsAlreadyExists(err) {
+					t.Fatalf("expected an AlreadyExists error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("startPod failed: %v", err)
+			}
+			if buildID != tc.expectedBuildID || pj.Status.BuildID != tc.expectedBuildID {
+				t.Errorf("expected job to get the build ID %q of the existing pod, got %q", tc.expectedBuildID, buildID)
+			}
+			if podName != existingPod.Name {
+				t.Errorf("expected the existing pod %q to be adopted, got %q", existingPod.Name, podName)
+			}
+		})
+	}
+}
+
+func TestSyncPendingJobHandlesPodsStuckTerminating(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	testCases := []struct {
+		name                  string
+		terminatingFor        time.Duration
+		removeStuckFinalizers bool
+		expectStuck           bool
+		expectFinalizer       bool
+	}{
+		{
+			name:            "pod terminating within the timeout",
+			terminatingFor:  time.Minute,
+			expectFinalizer: true,
+		},
+		{
+			name:            "pod stuck terminating is reported",
+			terminatingFor:  time.Hour,
+			expectStuck:     true,
+			expectFinalizer: true,
+		},
+		{
+			name:                  "finalizer of pod stuck terminating is removed",
+			terminatingFor:        time.Hour,
+			removeStuckFinalizers: true,
+			expectStuck:           true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "stuck-terminating",
+					Type:    prowapi.PeriodicJob,
+					Agent:   prowapi.KubernetesAgent,
+					Cluster: "stuck-terminating",
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "blabla",
+				},
+			}
+			deletionTimestamp := metav1.NewTime(fakeClock.Now().Add(-tc.terminatingFor))
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "blabla",
+					Namespace:         "pods",
+					DeletionTimestamp: &deletionTimestamp,
+					Finalizers:        []string{kubernetesreporterapi.FinalizerName, "example.com/other"},
+				},
+				Status: v1.PodStatus{
+					Phase:     v1.PodRunning,
+					StartTime: startTime(fakeClock.Now().Add(-2 * time.Hour)),
+				},
+			}
+
+			ctx := context.Background()
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			fakeConfigAgent.c.Plank.StuckTerminatingTimeout = &metav1.Duration{Duration: 10 * time.Minute}
+			fakeConfigAgent.c.Plank.RemoveStuckFinalizers = tc.removeStuckFinalizers
+			fakeMgr, err := testutil.NewFakeManager(
+				ctx,
+				[]runtime.Object{&pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			podClient := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(pod).Build()
+			r := &reconciler{
+				pjClient: fakeMgr.GetClient(),
+				buildClients: map[string]buildClient{
+					"stuck-terminating": {Client: podClient},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: fakeConfigAgent.Config,
+				totURL: totServ.URL,
+				clock:  fakeClock,
+			}
+			counter := plankMetrics.podsStuckTerminating.WithLabelValues("stuck-terminating")
+			before := promtestutil.ToFloat64(counter)
+			if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+
+			if stuck := promtestutil.ToFloat64(counter) > before; stuck != tc.expectStuck {
+				t.Errorf("expected pod to be reported as stuck terminating: %t, got %t", tc.expectStuck, stuck)
+			}
+			var actual v1.Pod
+			if err := podClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(pod), &actual); err != nil {
+				t.Fatalf("failed to get pod: %v", err)
+			}
+			if hasFinalizer := slices.Contains(actual.Finalizers, kubernetesreporterapi.FinalizerName); hasFinalizer != tc.expectFinalizer {
+				t.Errorf("expected pod to have finalizer %s: %t, got %t", kubernetesreporterapi.FinalizerName, tc.expectFinalizer, hasFinalizer)
+			}
+			if !slices.Contains(actual.Finalizers, "example.com/other") {
+				t.Errorf("expected finalizers not owned by Prow to be kept, got %v", actual.Finalizers)
+			}
+		})
+	}
+}
+
+func TestSyncTriggeredJobReportsStuckTriggered(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	const threshold = 10 * time.Minute
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:          "stuck-triggered",
+			Type:         prowapi.PeriodicJob,
+			Agent:        prowapi.KubernetesAgent,
+			JobQueueName: "blocked",
+			PodSpec:      &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:     prowapi.TriggeredState,
+			StartTime: metav1.NewTime(fakeClock.Now()),
+		},
+	}
+
+	ctx := context.Background()
+	fakeConfigAgent := newFakeConfigAgent(t, 0, map[string]int{"blocked": 0})
+	fakeConfigAgent.c.Plank.StuckTriggeredThreshold = &metav1.Duration{Duration: threshold}
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: fakectrlruntimeclient.NewClientBuilder().Build()},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: fakeConfigAgent.Config,
+		totURL: totServ.URL,
+		clock:  fakeClock,
+	}
+	counter := plankMetrics.stuckTriggered.WithLabelValues("stuck-triggered")
+	before := promtestutil.ToFloat64(counter)
+
+	fakeClock.Step(threshold - time.Minute)
+	_, actual := reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj)
+	if diff := promtestutil.ToFloat64(counter) - before; diff != 0 {
+		t.Errorf("expected job within the threshold not to be reported, got %v reports", diff)
+	}
+	if _, ok := actual.Annotations[kube.StuckTriggeredAnnotation]; ok {
+		t.Errorf("expected job within the threshold not to be annotated, got annotations %v", actual.Annotations)
+	}
+
+	for range 2 {
+		fakeClock.Step(2 * time.Minute)
+		_, actual = reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj)
+		if actual.Status.State != prowapi.TriggeredState {
+			t.Fatalf("expected job to stay triggered, got state %s", actual.Status.State)
+		}
+		if diff := promtestutil.ToFloat64(counter) - before; diff != 1 {
+			t.Errorf("expected job past the threshold to be reported once, got %v reports", diff)
+		}
+		if actual.Annotations[kube.StuckTriggeredAnnotation] != "true" {
+			t.Errorf("expected job past the threshold to be annotated, got annotations %v", actual.Annotations)
+		}
+	}
+}
+
+func TestReconcileAnnotatesJobsWithRepeatedReconcileErrors(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	const window = 10 * time.Minute
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:     "reconcile-errors",
+			Type:    prowapi.PeriodicJob,
+			Agent:   prowapi.KubernetesAgent,
+			PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:     prowapi.TriggeredState,
+			StartTime: metav1.NewTime(fakeClock.Now()),
+		},
+	}
+
+	ctx := context.Background()
+	fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+	fakeConfigAgent.c.Plank.ReconcileErrorThreshold = 3
+	fakeConfigAgent.c.Plank.ReconcileErrorWindow = &metav1.Duration{Duration: window}
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: &clientWrapper{
+				Client: fakectrlruntimeclient.NewClientBuilder().Build(),
+				createError: &kapierrors.StatusError{ErrStatus: metav1.Status{
+					Status:  metav1.StatusFailure,
+					Code:    http.StatusInternalServerError,
+					Reason:  metav1.StatusReasonInternalError,
+					Message: "etcdserver: request timed out",
+				}},
+			}},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: fakeConfigAgent.Config,
+		totURL: totServ.URL,
+		clock:  fakeClock,
+	}
+	counter := plankMetrics.reconcileErrors.WithLabelValues("reconcile-errors")
+	before := promtestutil.ToFloat64(counter)
+	reconcileFailing := func() {
+		if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: ctrlruntimeclient.ObjectKeyFromObject(&pj)}); err == nil {
+			t.Fatal("expected reconcile to fail")
+		}
+	}
+
+	for i := 1; i < 3; i++ {
+		fakeClock.Step(time.Minute)
+		reconcileFailing()
+		actual := getProwJob(t, ctx, fakeMgr.GetClient(), &pj)
+		if diff := promtestutil.ToFloat64(counter) - before; diff != float64(i) {
+			t.Errorf("expected %d reconcile errors to be counted, got %v", i, diff)
+		}
+		if _, ok := actual.Annotations[kube.ReconcileErrorsAnnotation]; ok {
+			t.Errorf("expected job below the threshold not to be annotated, got annotations %v", actual.Annotations)
+		}
+	}
+
+	fakeClock.Step(time.Minute)
+	reconcileFailing()
+	actual := getProwJob(t, ctx, fakeMgr.GetClient(), &pj)
+	if diff := promtestutil.ToFloat64(counter) - before; diff != 3 {
+		t.Errorf("expected 3 reconcile errors to be counted, got %v", diff)
+	}
+	if actual.Annotations[kube.ReconcileErrorsAnnotation] != "3" {
+		t.Errorf("expected job at the threshold to be annotated with 3 errors, got annotations %v", actual.Annotations)
+	}
+
+	// Errors outside of the window don't count towards the threshold anymore.
+	fakeClock.Step(window)
+	reconcileFailing()
+	actual = getProwJob(t, ctx, fakeMgr.GetClient(), &pj)
+	if diff := promtestutil.ToFloat64(counter) - before; diff != 4 {
+		t.Errorf("expected 4 reconcile errors to be counted, got %v", diff)
+	}
+	if actual.Annotations[kube.ReconcileErrorsAnnotation] != "3" {
+		t.Errorf("expected annotation not to be updated below the threshold, got annotations %v", actual.Annotations)
+	}
+}
+
+// listErrorClient fails to list objects while err is set.
+type listErrorClient struct {
+	ctrlruntimeclient.Client
+	err error
+}
+
+func (c *listErrorClient) List(ctx context.Context, list ctrlruntimeclient.ObjectList, opts ...ctrlruntimeclient.ListOption) error {
+	if c.err != nil {
+		return c.err
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func TestSyncTriggeredJobWaitsForUnhealthyCluster(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	const interval = time.Minute
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:     "unhealthy-cluster",
+			Type:    prowapi.PeriodicJob,
+			Agent:   prowapi.KubernetesAgent,
+			PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:     prowapi.TriggeredState,
+			StartTime: metav1.NewTime(fakeClock.Now()),
+		},
+	}
+
+	ctx := context.Background()
+	fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+	fakeConfigAgent.c.Plank.ClusterHealthCheckInterval = &metav1.Duration{Duration: interval}
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	podClient := &listErrorClient{
+		Client: fakectrlruntimeclient.NewClientBuilder().Build(),
+		err:    errors.New("dial tcp: connection refused"),
+	}
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: podClient},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: fakeConfigAgent.Config,
+		totURL: totServ.URL,
+		clock:  fakeClock,
+	}
+	assertNumPods := func(expected int) {
+		t.Helper()
+		pods := &v1.PodList{}
+		if err := podClient.Client.List(ctx, pods); err != nil {
+			t.Fatalf("failed to list pods: %v", err)
+		}
+		if len(pods.Items) != expected {
+			t.Errorf("expected %d pods, got %d", expected, len(pods.Items))
+		}
+	}
+
+	res, actual := reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj)
+	if actual.Status.State != prowapi.TriggeredState {
+		t.Errorf("expected job to stay triggered, got state %s", actual.Status.State)
+	}
+	if expected := "Waiting for build cluster default to become reachable."; actual.Status.Description != expected {
+		t.Errorf("expected description %q, got %q", expected, actual.Status.Description)
+	}
+	if res == nil || res.RequeueAfter != interval {
+		t.Errorf("expected job to be requeued after %v, got %+v", interval, res)
+	}
+	assertNumPods(0)
+	health, ok := r.ClusterHealth()[prowapi.DefaultClusterAlias]
+	if !ok || health.Healthy || health.Error == "" || !health.LastProbeTime.Equal(fakeClock.Now()) {
+		t.Errorf("expected cluster to be reported unhealthy, got %+v", r.ClusterHealth())
+	}
+
+	// The result of the probe is reused within the interval.
+	podClient.err = nil
+	fakeClock.Step(interval / 2)
+	if _, actual = reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj); actual.Status.State != prowapi.TriggeredState {
+		t.Errorf("expected job to stay triggered within the health check interval, got state %s", actual.Status.State)
+	}
+	assertNumPods(0)
+
+	fakeClock.Step(interval / 2)
+	if _, actual = reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj); actual.Status.State != prowapi.PendingState {
+		t.Errorf("expected job to be started once the cluster is healthy, got state %s", actual.Status.State)
+	}
+	assertNumPods(1)
+	if health := r.ClusterHealth()[prowapi.DefaultClusterAlias]; !health.Healthy || health.Error != "" {
+		t.Errorf("expected cluster to be reported healthy, got %+v", health)
+	}
+}
+
+func TestSyncTriggeredJobWaitsForPodCreationWindow(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	fakeClock := clocktesting.NewFakeClock(time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC))
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:     "off-peak",
+			Type:    prowapi.PeriodicJob,
+			Agent:   prowapi.KubernetesAgent,
+			PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:     prowapi.TriggeredState,
+			StartTime: metav1.NewTime(fakeClock.Now()),
+		},
+	}
+
+	ctx := context.Background()
+	fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+	fakeConfigAgent.c.Plank.PodCreationWindows = map[string][]config.PodCreationWindow{
+		prowapi.DefaultClusterAlias: {{Cron: "0 22 * * *", Duration: &metav1.Duration{Duration: 2 * time.Hour}}},
+	}
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	podClient := fakectrlruntimeclient.NewClientBuilder().Build()
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: podClient},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: fakeConfigAgent.Config,
+		totURL: totServ.URL,
+		clock:  fakeClock,
+	}
+	assertNumPods := func(expected int) {
+		t.Helper()
+		pods := &v1.PodList{}
+		if err := podClient.List(ctx, pods); err != nil {
+			t.Fatalf("failed to list pods: %v", err)
+		}
+		if len(pods.Items) != expected {
+			t.Errorf("expected %d pods, got %d", expected, len(pods.Items))
+		}
+	}
+
+	res, actual := reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj)
+	if actual.Status.State != prowapi.TriggeredState {
+		t.Errorf("expected job to stay triggered, got state %s", actual.Status.State)
+	}
+	if expected := "Waiting for the next pod creation window of build cluster default."; actual.Status.Description != expected {
+		t.Errorf("expected description %q, got %q", expected, actual.Status.Description)
+	}
+	if expected := 10 * time.Hour; res == nil || res.RequeueAfter != expected {
+		t.Errorf("expected job to be requeued after %v, got %+v", expected, res)
+	}
+	assertNumPods(0)
+
+	fakeClock.Step(10*time.Hour + time.Minute)
+	if _, actual = reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj); actual.Status.State != prowapi.PendingState {
+		t.Errorf("expected job to be started once the window opened, got state %s", actual.Status.State)
+	}
+	assertNumPods(1)
+}
+
+func TestSyncTriggeredJobJittersPeriodicStart(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	const jitter = time.Minute
+	for _, jobType := range []prowapi.ProwJobType{prowapi.PeriodicJob, prowapi.PostsubmitJob} {
+		t.Run(string(jobType), func(t *testing.T) {
+			fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "jittered",
+					Type:    jobType,
+					Agent:   prowapi.KubernetesAgent,
+					Refs:    &prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "abcdef"},
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:     prowapi.TriggeredState,
+					StartTime: metav1.NewTime(fakeClock.Now()),
+				},
+			}
+
+			ctx := context.Background()
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			fakeConfigAgent.c.Plank.PeriodicStartJitter = &metav1.Duration{Duration: jitter}
+			fakeMgr, err := testutil.NewFakeManager(
+				ctx,
+				[]runtime.Object{&pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			r := &reconciler{
+				pjClient: fakeMgr.GetClient(),
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: fakectrlruntimeclient.NewClientBuilder().Build()},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: fakeConfigAgent.Config,
+				totURL: totServ.URL,
+				clock:  fakeClock,
+			}
+
+			res, actual := reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj)
+			if jobType != prowapi.PeriodicJob {
+				if actual.Status.State != prowapi.PendingState {
+					t.Errorf("expected %s job to be started right away, got state %s", jobType, actual.Status.State)
+				}
+				return
+			}
+			if actual.Status.State != prowapi.TriggeredState {
+				t.Fatalf("expected periodic job start to be delayed, got state %s", actual.Status.State)
+			}
+			if res == nil || res.RequeueAfter <= 0 || res.RequeueAfter >= jitter {
+				t.Fatalf("expected periodic job to be requeued within the jitter of %v, got %v", jitter, res)
+			}
+
+			fakeClock.Step(res.RequeueAfter)
+			if _, actual := reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj); actual.Status.State != prowapi.PendingState {
+				t.Errorf("expected periodic job to be started after the delay, got state %s", actual.Status.State)
+			}
+		})
+	}
+}
+
+func TestSyncPendingJobRevivalBackoff(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	newReconciler := func(t *testing.T, fakeClock *clocktesting.FakeClock, pj *prowapi.ProwJob, pods ...runtime.Object) (*reconciler, ctrlruntimeclient.Client) {
+		fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+		fakeConfigAgent.c.Plank.RevivalBackoffBase = &metav1.Duration{Duration: 10 * time.Second}
+		fakeConfigAgent.c.Plank.RevivalBackoffMax = &metav1.Duration{Duration: time.Minute}
+		fakeMgr, err := testutil.NewFakeManager(
+			context.Background(),
+			[]runtime.Object{pj},
+			func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+				return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+			},
+		)
+		if err != nil {
+			t.Fatalf("Failed to setup fake manager: %v", err)
+		}
+		podClient := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(pods...).Build()
+		return &reconciler{
+			pjClient: fakeMgr.GetClient(),
+			buildClients: map[string]buildClient{
+				prowapi.DefaultClusterAlias: {Client: podClient},
+			},
+			log:    logrus.NewEntry(logrus.StandardLogger()),
+			config: fakeConfigAgent.Config,
+			totURL: totServ.URL,
+			clock:  fakeClock,
+		}, podClient
+	}
+	newProwJob := func(revivalCount int) *prowapi.ProwJob {
+		return &prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "blabla",
+				Namespace: "prowjobs",
+			},
+			Spec: prowapi.ProwJobSpec{
+				Job:              "revived",
+				Type:             prowapi.PeriodicJob,
+				Agent:            prowapi.KubernetesAgent,
+				DecorationConfig: &prowapi.DecorationConfig{MaxRevivals: ptr.To(100)},
+				PodSpec:          &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+			},
+			Status: prowapi.ProwJobStatus{
+				State:           prowapi.PendingState,
+				PodName:         "blabla",
+				PodRevivalCount: revivalCount,
+			},
+		}
+	}
+
+	t.Run("requeue grows with the revival count", func(t *testing.T) {
+		for revivalCount, expected := range map[int]time.Duration{
+			0:  10 * time.Second,
+			1:  20 * time.Second,
+			2:  40 * time.Second,
+			10: time.Minute,
+		} {
+			pj := newProwJob(revivalCount)
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "blabla", Namespace: "pods"},
+				Status:     v1.PodStatus{Phase: v1.PodFailed, Reason: Evicted},
+			}
+			r, _ := newReconciler(t, clocktesting.NewFakeClock(time.Now()), pj, pod)
+			res, err := r.reconcile(context.Background(), pj.DeepCopy())
+			if err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+			if res == nil || res.RequeueAfter != expected {
+				t.Errorf("expected job revived %d times before to be requeued after %v, got %v", revivalCount, expected, res)
+			}
+		}
+	})
+
+	t.Run("pod is recreated after the backoff", func(t *testing.T) {
+		fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+		pj := newProwJob(2)
+		revivedAt := metav1.NewTime(fakeClock.Now())
+		pj.Status.LastReconcileTime = &revivedAt
+		pj.Spec.DecorationConfig = nil
+		r, podClient := newReconciler(t, fakeClock, pj)
+
+		fakeClock.Step(15 * time.Second)
+		res, err := r.reconcile(context.Background(), pj.DeepCopy())
+		if err != nil {
+			t.Fatalf("reconcile failed: %v", err)
+		}
+		if res == nil || res.RequeueAfter != 5*time.Second {
+			t.Errorf("expected pod recreation to be delayed by the rest of the backoff of 5s, got %v", res)
+		}
+		var pods v1.PodList
+		if err := podClient.List(context.Background(), &pods); err != nil {
+			t.Fatalf("failed to list pods: %v", err)
+		}
+		if len(pods.Items) != 0 {
+			t.Fatalf("expected no pod to be created during the backoff, got %d", len(pods.Items))
+		}
+
+		fakeClock.Step(5 * time.Second)
+		if _, err := r.reconcile(context.Background(), pj.DeepCopy()); err != nil {
+			t.Fatalf("reconcile failed: %v", err)
+		}
+		if err := podClient.List(context.Background(), &pods); err != nil {
+			t.Fatalf("failed to list pods: %v", err)
+		}
+		if len(pods.Items) != 1 {
+			t.Errorf("expected the pod to be recreated after the backoff, got %d pods", len(pods.Items))
+		}
+	})
+}
+
+func TestSyncPendingJobWaitsForCacheWarmup(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	const warmup = time.Minute
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	pj := &prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:     "cold-cache",
+			Type:    prowapi.PeriodicJob,
+			Agent:   prowapi.KubernetesAgent,
+			PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:   prowapi.PendingState,
+			PodName: "blabla",
+			BuildID: "1234",
+		},
+	}
+	fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+	fakeConfigAgent.c.Plank.CacheWarmupPeriod = &metav1.Duration{Duration: warmup}
+	fakeMgr, err := testutil.NewFakeManager(
+		context.Background(),
+		[]runtime.Object{pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	// The pod is running, but the cache of the build client does not know
+	// about it yet.
+	podClient := fakectrlruntimeclient.NewClientBuilder().Build()
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: podClient},
+		},
+		log:       logrus.NewEntry(logrus.StandardLogger()),
+		config:    fakeConfigAgent.Config,
+		totURL:    totServ.URL,
+		clock:     fakeClock,
+		startTime: fakeClock.Now(),
+	}
+	listPods := func() []v1.Pod {
+		var pods v1.PodList
+		if err := podClient.List(context.Background(), &pods); err != nil {
+			t.Fatalf("failed to list pods: %v", err)
+		}
+		return pods.Items
+	}
+
+	fakeClock.Step(warmup / 4)
+	res, err := r.reconcile(context.Background(), pj.DeepCopy())
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if res == nil || res.RequeueAfter != 3*warmup/4 {
+		t.Errorf("expected job to be requeued after the rest of the warmup period of %v, got %v", 3*warmup/4, res)
+	}
+	var actual prowapi.ProwJob
+	if err := fakeMgr.GetClient().Get(context.Background(), ctrlruntimeclient.ObjectKeyFromObject(pj), &actual); err != nil {
+		t.Fatalf("failed to get prowjob from client: %v", err)
+	}
+	if actual.Status.State != prowapi.PendingState || actual.Status.BuildID != "1234" || actual.Status.PodRevivalCount != 0 {
+		t.Errorf("expected job not to be reset during the warmup period, got status %+v", actual.Status)
+	}
+	if pods := listPods(); len(pods) != 0 {
+		t.Errorf("expected no pod to be created during the warmup period, got %d", len(pods))
+	}
+
+	fakeClock.Step(3 * warmup / 4)
+	if _, err := r.reconcile(context.Background(), pj.DeepCopy()); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if pods := listPods(); len(pods) != 1 {
+		t.Errorf("expected the missing pod to be recreated after the warmup period, got %d pods", len(pods))
+	}
+}
+
+// blockingListClient blocks all lists until their context is done, like a
+// client of an API server that doesn't answer.
+type blockingListClient struct {
+	ctrlruntimeclient.Client
+}
+
+func (c *blockingListClient) List(ctx context.Context, list ctrlruntimeclient.ObjectList, opts ...ctrlruntimeclient.ListOption) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestReconcileRespectsContext(t *testing.T) {
+	testCases := []struct {
+		name             string
+		reconcileTimeout *metav1.Duration
+		cancel           bool
+		expectedErr      error
+	}{
+		{
+			name:             "reconcile times out",
+			reconcileTimeout: &metav1.Duration{Duration: 100 * time.Millisecond},
+			expectedErr:      context.DeadlineExceeded,
+		},
+		{
+			name:        "reconcile is cancelled",
+			cancel:      true,
+			expectedErr: context.Canceled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "slow-apiserver",
+					Type:    prowapi.PeriodicJob,
+					Agent:   prowapi.KubernetesAgent,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.TriggeredState,
+				},
+			}
+			fakeConfigAgent := newFakeConfigAgent(t, 1, nil)
+			fakeConfigAgent.c.Plank.ReconcileTimeout = tc.reconcileTimeout
+			fakeMgr, err := testutil.NewFakeManager(
+				context.Background(),
+				[]runtime.Object{pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			r := &reconciler{
+				pjClient: &blockingListClient{Client: fakeMgr.GetClient()},
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: fakectrlruntimeclient.NewClientBuilder().Build()},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: fakeConfigAgent.Config,
+				clock:  clock.RealClock{},
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.cancel {
+				cancel()
+			}
+			errs := make(chan error, 1)
+			go func() {
+				_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: ctrlruntimeclient.ObjectKeyFromObject(pj)})
+				errs <- err
+			}()
+			select {
+			case err := <-errs:
+				if !errors.Is(err, tc.expectedErr) {
+					t.Errorf("expected reconcile to fail with %v, got %v", tc.expectedErr, err)
+				}
+			case <-time.After(wait.ForeverTestTimeout):
+				t.Fatal("reconcile did not return")
+			}
+		})
+	}
+}
+
+func TestReconcileAbortsAnnotatedJobs(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	testCases := []struct {
+		name                 string
+		honorAbortAnnotation bool
+		expectAborted        bool
+	}{
+		{
+			name:                 "annotated job is aborted",
+			honorAbortAnnotation: true,
+			expectAborted:        true,
+		},
+		{
+			name: "annotation is ignored unless configured",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "blabla",
+					Namespace:   "prowjobs",
+					Annotations: map[string]string{kube.AbortAnnotation: "true"},
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "aborted",
+					Type:    prowapi.PeriodicJob,
+					Agent:   prowapi.KubernetesAgent,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "blabla",
+				},
+			}
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "blabla", Namespace: "pods"},
+				Status:     v1.PodStatus{Phase: v1.PodRunning, StartTime: &metav1.Time{Time: time.Now()}},
+			}
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			fakeConfigAgent.c.Plank.HonorAbortAnnotation = tc.honorAbortAnnotation
+			fakeMgr, err := testutil.NewFakeManager(
+				context.Background(),
+				[]runtime.Object{pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			podClient := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(pod).Build()
+			r := &reconciler{
+				pjClient: fakeMgr.GetClient(),
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: podClient},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: fakeConfigAgent.Config,
+				totURL: totServ.URL,
+				clock:  clock.RealClock{},
+			}
+
+			if _, err := r.reconcile(context.Background(), pj.DeepCopy()); err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+
+			var actual prowapi.ProwJob
+			if err := fakeMgr.GetClient().Get(context.Background(), ctrlruntimeclient.ObjectKeyFromObject(pj), &actual); err != nil {
+				t.Fatalf("failed to get prowjob from client: %v", err)
+			}
+			var pods v1.PodList
+			if err := podClient.List(context.Background(), &pods); err != nil {
+				t.Fatalf("failed to list pods: %v", err)
+			}
+			if tc.expectAborted {
+				if actual.Status.State != prowapi.AbortedState || !actual.Complete() {
+					t.Errorf("expected job to be aborted and complete, got state %s, complete %t", actual.Status.State, actual.Complete())
+				}
+				if len(pods.Items) != 0 {
+					t.Errorf("expected the pod of the job to be deleted, got %d pods", len(pods.Items))
+				}
+			} else {
+				if actual.Status.State != prowapi.PendingState || actual.Complete() {
+					t.Errorf("expected job to stay pending, got state %s, complete %t", actual.Status.State, actual.Complete())
+				}
+				if len(pods.Items) != 1 {
+					t.Errorf("expected the pod of the job to remain, got %d pods", len(pods.Items))
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileSkipsPausedJobs(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	testCases := []struct {
+		name  string
+		state prowapi.ProwJobState
+		pods  []runtime.Object
+	}{
+		{
+			name:  "paused triggered job gets no pod",
+			state: prowapi.TriggeredState,
+		},
+		{
+			name:  "paused pending job keeps its finished pod",
+			state: prowapi.PendingState,
+			pods: []runtime.Object{&v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "blabla", Namespace: "pods"},
+				Status:     v1.PodStatus{Phase: v1.PodSucceeded},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "blabla",
+					Namespace:   "prowjobs",
+					Annotations: map[string]string{kube.PausedAnnotation: "true"},
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "paused",
+					Type:    prowapi.PeriodicJob,
+					Agent:   prowapi.KubernetesAgent,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:     tc.state,
+					StartTime: metav1.NewTime(time.Now().Truncate(time.Second)),
+				},
+			}
+			if tc.state == prowapi.PendingState {
+				pj.Status.PodName = "blabla"
+			}
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			fakeConfigAgent.c.Plank.PausedRequeueInterval = &metav1.Duration{Duration: 30 * time.Second}
+			fakeMgr, err := testutil.NewFakeManager(
+				context.Background(),
+				[]runtime.Object{pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			podClient := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(tc.pods...).Build()
+			r := &reconciler{
+				pjClient: fakeMgr.GetClient(),
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: podClient},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: fakeConfigAgent.Config,
+				totURL: totServ.URL,
+				clock:  clock.RealClock{},
+			}
+
+			res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: ctrlruntimeclient.ObjectKeyFromObject(pj)})
+			if err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+			if expected := 30 * time.Second; res.RequeueAfter != expected {
+				t.Errorf("expected paused job to be requeued after %v, got %v", expected, res.RequeueAfter)
+			}
+
+			var actual prowapi.ProwJob
+			if err := fakeMgr.GetClient().Get(context.Background(), ctrlruntimeclient.ObjectKeyFromObject(pj), &actual); err != nil {
+				t.Fatalf("failed to get prowjob from client: %v", err)
+			}
+			if diff := cmp.Diff(pj.Status, actual.Status); diff != "" {
+				t.Errorf("expected the status of the paused job to be unchanged (-want +got):\n%s", diff)
+			}
+			var pods v1.PodList
+			if err := podClient.List(context.Background(), &pods); err != nil {
+				t.Fatalf("failed to list pods: %v", err)
+			}
+			if len(pods.Items) != len(tc.pods) {
+				t.Errorf("expected %d pods, got %d", len(tc.pods), len(pods.Items))
+			}
+		})
+	}
+}
+
+func TestSyncPendingJobRequeueJitter(t *testing.T) {
+	const backoff = 10 * time.Second
+	testCases := []struct {
+		name         string
+		jitterFactor float64
+		expected     func(seed uint64) time.Duration
+	}{
+		{
+			name: "requeue is unchanged without jitter",
+			expected: func(uint64) time.Duration {
+				return backoff
+			},
+		},
+		{
+			name:         "requeue is stretched by up to the jitter factor",
+			jitterFactor: 0.5,
+			expected: func(seed uint64) time.Duration {
+				return backoff + time.Duration(float64(backoff)*0.5*rand.New(rand.NewPCG(seed, seed)).Float64())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "jittered",
+					Type:    prowapi.PeriodicJob,
+					Agent:   prowapi.KubernetesAgent,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "blabla",
+				},
+			}
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "blabla", Namespace: "pods"},
+				Status:     v1.PodStatus{Phase: v1.PodFailed, Reason: Evicted},
+			}
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			fakeConfigAgent.c.Plank.RevivalBackoffBase = &metav1.Duration{Duration: backoff}
+			fakeConfigAgent.c.Plank.RequeueJitterFactor = tc.jitterFactor
+			fakeMgr, err := testutil.NewFakeManager(
+				context.Background(),
+				[]runtime.Object{pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			const seed = 42
+			r := &reconciler{
+				pjClient: fakeMgr.GetClient(),
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(pod).Build()},
+				},
+				log:         logrus.NewEntry(logrus.StandardLogger()),
+				config:      fakeConfigAgent.Config,
+				clock:       clocktesting.NewFakeClock(time.Now()),
+				randFloat64: rand.New(rand.NewPCG(seed, seed)).Float64,
+			}
+
+			res, err := r.reconcile(context.Background(), pj.DeepCopy())
+			if err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+			if expected := tc.expected(seed); res == nil || res.RequeueAfter != expected {
+				t.Errorf("expected job to be requeued after %v, got %v", expected, res)
+			}
+		})
+	}
+}
+
+func TestSyncPendingJobRecordsPodNode(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: map[string]string{v1.LabelTopologyZone: "zone-a"},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		disabled     bool
+		phase        v1.PodPhase
+		nodes        []runtime.Object
+		recordedNode string
+		expectedNode string
+		expectedZone string
+	}{
+		{
+			name:         "running pod records node and zone",
+			phase:        v1.PodRunning,
+			nodes:        []runtime.Object{node},
+			expectedNode: "node-1",
+			expectedZone: "zone-a",
+		},
+		{
+			name:         "finished pod records node and zone",
+			phase:        v1.PodFailed,
+			nodes:        []runtime.Object{node},
+			expectedNode: "node-1",
+			expectedZone: "zone-a",
+		},
+		{
+			name:         "missing node only records node name",
+			phase:        v1.PodRunning,
+			expectedNode: "node-1",
+		},
+		{
+			name:  "pending pod records nothing yet",
+			phase: v1.PodPending,
+			nodes: []runtime.Object{node},
+		},
+		{
+			name:     "nothing is recorded if disabled",
+			disabled: true,
+			phase:    v1.PodRunning,
+			nodes:    []runtime.Object{node},
+		},
+		{
+			name:         "node is not looked up again once recorded",
+			phase:        v1.PodRunning,
+			nodes:        []runtime.Object{node},
+			recordedNode: "node-1",
+			expectedNode: "node-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "pod-node",
+					Type:    prowapi.PeriodicJob,
+					Agent:   prowapi.KubernetesAgent,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:    prowapi.PendingState,
+					PodName:  "blabla",
+					NodeName: tc.recordedNode,
+				},
+			}
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "blabla", Namespace: "pods", CreationTimestamp: metav1.Now()},
+				Spec:       v1.PodSpec{NodeName: "node-1"},
+				Status:     v1.PodStatus{Phase: tc.phase, StartTime: ptr.To(metav1.Now())},
+			}
+
+			ctx := context.Background()
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			fakeConfigAgent.c.Plank.RecordPodNode = !tc.disabled
+			fakeMgr, err := testutil.NewFakeManager(
+				ctx,
+				[]runtime.Object{&pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			// Nodes are only known to the uncached reader, like for a build
+			// client whose cache is limited to the pod namespace.
+			podClient := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(pod).Build()
+			apiReader := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(tc.nodes...).Build()
+			r := &reconciler{
+				pjClient: fakeMgr.GetClient(),
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: podClient, apiReader: apiReader},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: fakeConfigAgent.Config,
+				totURL: totServ.URL,
+				clock:  clock.RealClock{},
+			}
+			if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+
+			var actual prowapi.ProwJob
+			if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+				t.Fatalf("failed to get prowjob from client: %v", err)
+			}
+			if actual.Status.NodeName != tc.expectedNode {
+				t.Errorf("expected node name %q, got %q", tc.expectedNode, actual.Status.NodeName)
+			}
+			if actual.Status.NodeZone != tc.expectedZone {
+				t.Errorf("expected node zone %q, got %q", tc.expectedZone, actual.Status.NodeZone)
+			}
+		})
+	}
+}
+
+func TestSyncPendingJobRecordsPodScheduledTime(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blabla",
+			Namespace: "prowjobs",
+		},
+		Spec: prowapi.ProwJobSpec{
+			Job:     "pod-scheduled",
+			Type:    prowapi.PeriodicJob,
+			Agent:   prowapi.KubernetesAgent,
+			PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:   prowapi.PendingState,
+			PodName: "blabla",
+		},
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "blabla", Namespace: "pods", CreationTimestamp: metav1.Now()},
+		Status: v1.PodStatus{
+			Phase:      v1.PodPending,
+			Conditions: []v1.PodCondition{{Type: v1.PodScheduled, Status: v1.ConditionFalse}},
+		},
+	}
+
+	ctx := context.Background()
+	fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+	fakeMgr, err := testutil.NewFakeManager(
+		ctx,
+		[]runtime.Object{&pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	podClient := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(pod).Build()
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: podClient},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: fakeConfigAgent.Config,
+		totURL: totServ.URL,
+		clock:  clock.RealClock{},
+	}
+	schedulePod := func(scheduledTime time.Time) {
+		t.Helper()
+		current := &v1.Pod{}
+		if err := podClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(pod), current); err != nil {
+			t.Fatalf("failed to get pod: %v", err)
+		}
+		current.Status.Phase = v1.PodRunning
+		current.Status.StartTime = ptr.To(metav1.Now())
+		current.Status.Conditions = []v1.PodCondition{{Type: v1.PodScheduled, Status: v1.ConditionTrue, LastTransitionTime: metav1.NewTime(scheduledTime)}}
+		if err := podClient.Status().Update(ctx, current); err != nil {
+			t.Fatalf("failed to update pod: %v", err)
+		}
+	}
+
+	if _, actual := reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj); actual.Status.PodScheduledTime != nil {
+		t.Errorf("expected no scheduled time for an unscheduled pod, got %v", actual.Status.PodScheduledTime)
+	}
+
+	scheduledTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+	schedulePod(scheduledTime)
+	if _, actual := reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj); actual.Status.PodScheduledTime == nil || !actual.Status.PodScheduledTime.Time.Equal(scheduledTime) {
+		t.Errorf("expected scheduled time %v, got %v", scheduledTime, actual.Status.PodScheduledTime)
 	}
-	afterThirdSync := &prowapi.ProwJobList{}
-	if err := fakeProwJobClient.List(ctx, afterThirdSync); err != nil {
-		t.Fatalf("could not list prowJobs from the client: %v", err)
+
+	// The first observed scheduled time is kept.
+	schedulePod(time.Now().Truncate(time.Second))
+	if _, actual := reconcileAndGet(t, ctx, r, fakeMgr.GetClient(), &pj); actual.Status.PodScheduledTime == nil || !actual.Status.PodScheduledTime.Time.Equal(scheduledTime) {
+		t.Errorf("expected scheduled time to stay %v, got %v", scheduledTime, actual.Status.PodScheduledTime)
 	}
-	if len(afterThirdSync.Items) != 1 {
-		t.Fatalf("Wrong number of prow jobs: %d", len(afterThirdSync.Items))
+}
+
+func TestSyncPendingJobRepairsPodLabels(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	testCases := []struct {
+		name            string
+		disabled        bool
+		expectedBuildID string
+	}{
+		{
+			name:            "drifted build ID label is repaired",
+			expectedBuildID: "123",
+		},
+		{
+			name:            "labels are left alone if disabled",
+			disabled:        true,
+			expectedBuildID: "456",
+		},
 	}
-	if !afterThirdSync.Items[0].Complete() {
-		t.Fatal("Prow job didn't complete.")
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "pod-labels",
+					Type:    prowapi.PeriodicJob,
+					Agent:   prowapi.KubernetesAgent,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "blabla",
+					BuildID: "123",
+				},
+			}
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "pods",
+					Labels: map[string]string{
+						kube.CreatedByProw:     "true",
+						kube.ProwJobAnnotation: "pod-labels",
+						kube.ProwJobTypeLabel:  string(prowapi.PeriodicJob),
+						kube.ProwJobIDLabel:    "blabla",
+						kube.ProwBuildIDLabel:  "456",
+						"custom":               "label",
+					},
+				},
+				Status: v1.PodStatus{Phase: v1.PodRunning, StartTime: ptr.To(metav1.Now())},
+			}
+
+			ctx := context.Background()
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			fakeConfigAgent.c.Plank.RepairPodLabels = !tc.disabled
+			fakeMgr, err := testutil.NewFakeManager(
+				ctx,
+				[]runtime.Object{&pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			podClient := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(pod).Build()
+			r := &reconciler{
+				pjClient: fakeMgr.GetClient(),
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: podClient},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: fakeConfigAgent.Config,
+				totURL: totServ.URL,
+				clock:  clock.RealClock{},
+			}
+			if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+
+			actual := &v1.Pod{}
+			if err := podClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(pod), actual); err != nil {
+				t.Fatalf("failed to get pod: %v", err)
+			}
+			if actual.Labels[kube.ProwBuildIDLabel] != tc.expectedBuildID {
+				t.Errorf("expected build ID label %q, got %q", tc.expectedBuildID, actual.Labels[kube.ProwBuildIDLabel])
+			}
+			if actual.Labels["custom"] != "label" {
+				t.Errorf("expected unrelated labels to be kept, got %v", actual.Labels)
+			}
+		})
 	}
-	if afterThirdSync.Items[0].Status.State != prowapi.SuccessState {
-		t.Fatalf("Should be success: %v", afterThirdSync.Items[0].Status.State)
+}
+
+func TestSyncTriggeredJobRecordsPodQOSClass(t *testing.T) {
+	totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+	defer totServ.Close()
+
+	resources := func(requests, limits string) v1.ResourceRequirements {
+		var requirements v1.ResourceRequirements
+		if requests != "" {
+			requirements.Requests = v1.ResourceList{v1.ResourceCPU: resource.MustParse(requests), v1.ResourceMemory: resource.MustParse(requests + "Gi")}
+		}
+		if limits != "" {
+			requirements.Limits = v1.ResourceList{v1.ResourceCPU: resource.MustParse(limits), v1.ResourceMemory: resource.MustParse(limits + "Gi")}
+		}
+		return requirements
 	}
-	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: ctrlruntimeclient.ObjectKeyFromObject(&pj)}); err != nil {
-		t.Fatalf("Error on fourth sync: %v", err)
+
+	testCases := []struct {
+		name       string
+		disabled   bool
+		containers []v1.Container
+		expected   v1.PodQOSClass
+	}{
+		{
+			name:       "no resources",
+			containers: []v1.Container{{Name: "test"}},
+			expected:   v1.PodQOSBestEffort,
+		},
+		{
+			name:       "requests below limits",
+			containers: []v1.Container{{Name: "test", Resources: resources("1", "2")}},
+			expected:   v1.PodQOSBurstable,
+		},
+		{
+			name:       "requests only",
+			containers: []v1.Container{{Name: "test", Resources: resources("1", "")}},
+			expected:   v1.PodQOSBurstable,
+		},
+		{
+			name:       "requests equal to limits",
+			containers: []v1.Container{{Name: "test", Resources: resources("2", "2")}},
+			expected:   v1.PodQOSGuaranteed,
+		},
+		{
+			name:       "limits only",
+			containers: []v1.Container{{Name: "test", Resources: resources("", "2")}},
+			expected:   v1.PodQOSGuaranteed,
+		},
+		{
+			name:       "one of several containers without resources",
+			containers: []v1.Container{{Name: "test", Resources: resources("2", "2")}, {Name: "helper"}},
+			expected:   v1.PodQOSBurstable,
+		},
+		{
+			name:       "option disabled",
+			disabled:   true,
+			containers: []v1.Container{{Name: "test", Resources: resources("2", "2")}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "boop",
+					Type:    prowapi.PeriodicJob,
+					Agent:   prowapi.KubernetesAgent,
+					PodSpec: &v1.PodSpec{Containers: tc.containers},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.TriggeredState,
+				},
+			}
+
+			ctx := context.Background()
+			fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+			fakeConfigAgent.c.Plank.RecordPodQOSClass = !tc.disabled
+			fakeMgr, err := testutil.NewFakeManager(
+				ctx,
+				[]runtime.Object{&pj},
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			r := &reconciler{
+				pjClient: fakeMgr.GetClient(),
+				buildClients: map[string]buildClient{
+					prowapi.DefaultClusterAlias: {Client: fakectrlruntimeclient.NewClientBuilder().Build()},
+				},
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				config: fakeConfigAgent.Config,
+				totURL: totServ.URL,
+				clock:  clock.RealClock{},
+			}
+			if _, err := r.reconcile(ctx, pj.DeepCopy()); err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+
+			var actual prowapi.ProwJob
+			if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+				t.Fatalf("failed to get prowjob from client: %v", err)
+			}
+			if actual.Status.State != prowapi.PendingState {
+				t.Errorf("expected state %s, got %s", prowapi.PendingState, actual.Status.State)
+			}
+			if actual.Status.PodQOSClass != tc.expected {
+				t.Errorf("expected pod QoS class %q, got %q", tc.expected, actual.Status.PodQOSClass)
+			}
+		})
 	}
 }
 
@@ -2130,7 +6329,7 @@ func TestMaxConcurrencyWithNewlyTriggeredJobs(t *testing.T) {
 					}
 				}
 			}
-			r := newReconciler(ctx, fakeProwJobClient, nil, config, nil, "")
+			r := newReconciler(ctx, fakeProwJobClient, nil, config, nil, "", nil)
 			r.buildClients = buildClients
 			for _, job := range test.PJs {
 				request := reconcile.Request{NamespacedName: types.NamespacedName{
@@ -2157,16 +6356,22 @@ func TestMaxConcurrency(t *testing.T) {
 	type pendingJob struct {
 		Duplicates int
 		JobQueue   string
+		Cluster    string
 	}
 
 	type testCase struct {
-		Name               string
-		JobQueueCapacities map[string]int
-		ProwJob            prowapi.ProwJob
-		ExistingProwJobs   []prowapi.ProwJob
-		PendingJobs        map[string]pendingJob
+		Name                  string
+		MaxConcurrency        int
+		ClusterMaxConcurrency map[string]int
+		JobQueueCapacities    map[string]int
+		ProwJob               prowapi.ProwJob
+		ExistingProwJobs      []prowapi.ProwJob
+		PendingJobs           map[string]pendingJob
 
 		ExpectedResult bool
+		// ExpectedBlockedReason is the reason the job is expected to be
+		// counted as held back for.
+		ExpectedBlockedReason string
 	}
 	testCases := []testCase{
 		{
@@ -2183,8 +6388,9 @@ func TestMaxConcurrency(t *testing.T) {
 					Job:            "my-pj",
 				},
 			},
-			PendingJobs:    map[string]pendingJob{"my-pj": {Duplicates: 10}},
-			ExpectedResult: false,
+			PendingJobs:           map[string]pendingJob{"my-pj": {Duplicates: 10}},
+			ExpectedResult:        false,
+			ExpectedBlockedReason: concurrencyBlockedJobMaxConcurrency,
 		},
 		{
 			Name: "Num pending plus older instances equals max concurrency",
@@ -2206,8 +6412,9 @@ func TestMaxConcurrency(t *testing.T) {
 					},
 				},
 			},
-			PendingJobs:    map[string]pendingJob{"my-pj": {Duplicates: 9}},
-			ExpectedResult: false,
+			PendingJobs:           map[string]pendingJob{"my-pj": {Duplicates: 9}},
+			ExpectedResult:        false,
+			ExpectedBlockedReason: concurrencyBlockedJobMaxConcurrency,
 		},
 		{
 			Name: "Num pending plus older instances exceeds max concurrency",
@@ -2228,8 +6435,9 @@ func TestMaxConcurrency(t *testing.T) {
 					},
 				},
 			},
-			PendingJobs:    map[string]pendingJob{"my-pj": {Duplicates: 10}},
-			ExpectedResult: false,
+			PendingJobs:           map[string]pendingJob{"my-pj": {Duplicates: 10}},
+			ExpectedResult:        false,
+			ExpectedBlockedReason: concurrencyBlockedJobMaxConcurrency,
 		},
 		{
 			Name: "Have other jobs that are newer, can execute",
@@ -2275,10 +6483,11 @@ func TestMaxConcurrency(t *testing.T) {
 			ExpectedResult: true,
 		},
 		{
-			Name:               "Job queue capacity 0 never runs",
-			ProwJob:            prowapi.ProwJob{Spec: prowapi.ProwJobSpec{JobQueueName: "queue"}},
-			JobQueueCapacities: map[string]int{"queue": 0},
-			ExpectedResult:     false,
+			Name:                  "Job queue capacity 0 never runs",
+			ProwJob:               prowapi.ProwJob{Spec: prowapi.ProwJobSpec{JobQueueName: "queue"}},
+			JobQueueCapacities:    map[string]int{"queue": 0},
+			ExpectedResult:        false,
+			ExpectedBlockedReason: concurrencyBlockedJobQueueCapacity,
 		},
 		{
 			Name:               "Job queue capacity -1 always runs",
@@ -2296,9 +6505,74 @@ func TestMaxConcurrency(t *testing.T) {
 					JobQueueName:   "queue",
 				},
 			},
-			JobQueueCapacities: map[string]int{"queue": 10},
-			PendingJobs:        map[string]pendingJob{"my-pj": {Duplicates: 10, JobQueue: "queue"}},
-			ExpectedResult:     false,
+			JobQueueCapacities:    map[string]int{"queue": 10},
+			PendingJobs:           map[string]pendingJob{"my-pj": {Duplicates: 10, JobQueue: "queue"}},
+			ExpectedResult:        false,
+			ExpectedBlockedReason: concurrencyBlockedJobQueueCapacity,
+		},
+		{
+			Name: "Num pending in cluster equals cluster max concurrency",
+			ProwJob: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+				Spec:       prowapi.ProwJobSpec{Job: "my-pj", Cluster: "trusted"},
+			},
+			ClusterMaxConcurrency: map[string]int{"trusted": 2},
+			PendingJobs:           map[string]pendingJob{"other-pj": {Duplicates: 2, Cluster: "trusted"}},
+			ExpectedResult:        false,
+			ExpectedBlockedReason: concurrencyBlockedClusterMaxConcurrency,
+		},
+		{
+			Name: "Num pending plus older triggered instances in cluster equals cluster max concurrency",
+			ProwJob: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+				Spec:       prowapi.ProwJobSpec{Job: "my-pj", Cluster: "trusted"},
+			},
+			ExistingProwJobs: []prowapi.ProwJob{
+				{
+					Spec:   prowapi.ProwJobSpec{Agent: prowapi.KubernetesAgent, Job: "another-pj", Cluster: "trusted"},
+					Status: prowapi.ProwJobStatus{State: prowapi.TriggeredState},
+				},
+			},
+			ClusterMaxConcurrency: map[string]int{"trusted": 2},
+			PendingJobs:           map[string]pendingJob{"other-pj": {Duplicates: 1, Cluster: "trusted"}},
+			ExpectedResult:        false,
+			ExpectedBlockedReason: concurrencyBlockedClusterMaxConcurrency,
+		},
+		{
+			Name: "Num pending in other clusters doesn't count against cluster max concurrency",
+			ProwJob: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+				Spec:       prowapi.ProwJobSpec{Job: "my-pj", Cluster: "trusted"},
+			},
+			ClusterMaxConcurrency: map[string]int{"trusted": 2},
+			PendingJobs:           map[string]pendingJob{"other-pj": {Duplicates: 5}},
+			ExpectedResult:        true,
+		},
+		{
+			Name: "Cluster without cluster max concurrency falls back to max concurrency",
+			ProwJob: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+				Spec:       prowapi.ProwJobSpec{Job: "my-pj"},
+			},
+			MaxConcurrency:        10,
+			ClusterMaxConcurrency: map[string]int{"trusted": 2},
+			PendingJobs:           map[string]pendingJob{"other-pj": {Duplicates: 5}},
+			ExpectedResult:        true,
+		},
+		{
+			Name: "Num pending within cluster max concurrency but exceeds max concurrency",
+			ProwJob: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+				Spec:       prowapi.ProwJobSpec{Job: "my-pj", Cluster: "trusted"},
+			},
+			MaxConcurrency:        3,
+			ClusterMaxConcurrency: map[string]int{"trusted": 5},
+			PendingJobs: map[string]pendingJob{
+				"other-pj":   {Duplicates: 1, Cluster: "trusted"},
+				"another-pj": {Duplicates: 2},
+			},
+			ExpectedResult:        false,
+			ExpectedBlockedReason: concurrencyBlockedMaxConcurrency,
 		},
 	}
 
@@ -2327,6 +6601,7 @@ func TestMaxConcurrency(t *testing.T) {
 							Agent:        prowapi.KubernetesAgent,
 							Job:          jobName,
 							JobQueueName: jobsToCreateParams.JobQueue,
+							Cluster:      jobsToCreateParams.Cluster,
 						},
 						Status: prowapi.ProwJobStatus{
 							State: prowapi.PendingState,
@@ -2336,7 +6611,9 @@ func TestMaxConcurrency(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			config := newFakeConfigAgent(t, 0, tc.JobQueueCapacities).Config
+			fakeConfigAgent := newFakeConfigAgent(t, tc.MaxConcurrency, tc.JobQueueCapacities)
+			fakeConfigAgent.c.Plank.ClusterMaxConcurrency = tc.ClusterMaxConcurrency
+			config := fakeConfigAgent.Config
 
 			fakeMgr, err := testutil.NewFakeManager(
 				ctx,
@@ -2356,6 +6633,17 @@ func TestMaxConcurrency(t *testing.T) {
 				config:       config,
 				clock:        clock.RealClock{},
 			}
+			reasons := []string{
+				concurrencyBlockedMaxConcurrency,
+				concurrencyBlockedClusterMaxConcurrency,
+				concurrencyBlockedGlobalMaxRunningPods,
+				concurrencyBlockedJobMaxConcurrency,
+				concurrencyBlockedJobQueueCapacity,
+			}
+			blockedBefore := map[string]float64{}
+			for _, reason := range reasons {
+				blockedBefore[reason] = promtestutil.ToFloat64(plankMetrics.concurrencyBlocked.WithLabelValues(tc.ProwJob.Spec.Job, tc.ProwJob.Spec.JobQueueName, reason))
+			}
 			// We filter ourselves out via the UID, so make sure its not the empty string
 			tc.ProwJob.UID = types.UID("under-test")
 			result, err := r.canExecuteConcurrently(ctx, &tc.ProwJob)
@@ -2366,13 +6654,78 @@ func TestMaxConcurrency(t *testing.T) {
 			if result != tc.ExpectedResult {
 				t.Errorf("Expected max_concurrency to allow job: %t, result was %t", tc.ExpectedResult, result)
 			}
+			for _, reason := range reasons {
+				var expected float64
+				if reason == tc.ExpectedBlockedReason {
+					expected = 1
+				}
+				if diff := promtestutil.ToFloat64(plankMetrics.concurrencyBlocked.WithLabelValues(tc.ProwJob.Spec.Job, tc.ProwJob.Spec.JobQueueName, reason)) - blockedBefore[reason]; diff != expected {
+					t.Errorf("Expected job to be counted as blocked by %s %v times, got %v", reason, expected, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestShouldWaitForCachedProwJob(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from     prowapi.ProwJobState
+		to       prowapi.ProwJobState
+		spec     prowapi.ProwJobSpec
+		expected bool
+	}{
+		{
+			name:     "pending job that completes",
+			from:     prowapi.PendingState,
+			to:       prowapi.SuccessState,
+			expected: true,
+		},
+		{
+			name: "pending job that stays pending",
+			from: prowapi.PendingState,
+			to:   prowapi.PendingState,
+		},
+		{
+			name: "triggered job that starts",
+			from: prowapi.TriggeredState,
+			to:   prowapi.PendingState,
+		},
+		{
+			name:     "triggered job with max concurrency that starts",
+			from:     prowapi.TriggeredState,
+			to:       prowapi.PendingState,
+			spec:     prowapi.ProwJobSpec{MaxConcurrency: 1},
+			expected: true,
+		},
+		{
+			name:     "triggered job with a job queue that starts",
+			from:     prowapi.TriggeredState,
+			to:       prowapi.PendingState,
+			spec:     prowapi.ProwJobSpec{JobQueueName: "queue"},
+			expected: true,
+		},
+		{
+			name: "aborted job that completes",
+			from: prowapi.AbortedState,
+			to:   prowapi.AbortedState,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prevPJ := &prowapi.ProwJob{Spec: tc.spec, Status: prowapi.ProwJobStatus{State: tc.from}}
+			pj := &prowapi.ProwJob{Spec: tc.spec, Status: prowapi.ProwJobStatus{State: tc.to}}
+			if actual := shouldWaitForCachedProwJob(prevPJ, pj); actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
 		})
 	}
 }
 
 type patchTrackingFakeClient struct {
 	ctrlruntimeclient.Client
-	patched sets.Set[string]
+	patched    sets.Set[string]
+	numPatches int
 }
 
 func (c *patchTrackingFakeClient) Patch(ctx context.Context, obj ctrlruntimeclient.Object, patch ctrlruntimeclient.Patch, opts ...ctrlruntimeclient.PatchOption) error {
@@ -2380,16 +6733,30 @@ func (c *patchTrackingFakeClient) Patch(ctx context.Context, obj ctrlruntimeclie
 		c.patched = sets.New[string]()
 	}
 	c.patched.Insert(obj.GetName())
+	c.numPatches++
 	return c.Client.Patch(ctx, obj, patch, opts...)
 }
 
+type quotaExceededFakeClient struct {
+	ctrlruntimeclient.Client
+}
+
+func (c *quotaExceededFakeClient) Patch(ctx context.Context, obj ctrlruntimeclient.Object, patch ctrlruntimeclient.Patch, opts ...ctrlruntimeclient.PatchOption) error {
+	return kapierrors.NewForbidden(prowapi.Resource("prowjobs"), obj.GetName(), errors.New("exceeded quota: object-counts, requested: count/prowjobs.prow.k8s.io=1, used: count/prowjobs.prow.k8s.io=100, limited: count/prowjobs.prow.k8s.io=100"))
+}
+
 type deleteTrackingFakeClient struct {
 	deleteError error
 	ctrlruntimeclient.Client
-	deleted sets.Set[string]
+	deleted       sets.Set[string]
+	deleteOptions map[string]*ctrlruntimeclient.DeleteOptions
 }
 
 func (c *deleteTrackingFakeClient) Delete(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.DeleteOption) error {
+	if c.deleteOptions == nil {
+		c.deleteOptions = map[string]*ctrlruntimeclient.DeleteOptions{}
+	}
+	c.deleteOptions[obj.GetName()] = (&ctrlruntimeclient.DeleteOptions{}).ApplyOptions(opts)
 	if c.deleteError != nil {
 		return c.deleteError
 	}
@@ -2423,16 +6790,33 @@ func (c *clientWrapper) Delete(ctx context.Context, obj ctrlruntimeclient.Object
 	return c.Client.Delete(ctx, obj, opts...)
 }
 
+// conflictOnceClient fails the first creation with a conflict.
+type conflictOnceClient struct {
+	ctrlruntimeclient.Client
+	conflicted bool
+}
+
+func (c *conflictOnceClient) Create(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.CreateOption) error {
+	if !c.conflicted {
+		c.conflicted = true
+		return kapierrors.NewConflict(schema.GroupResource{Resource: "pods"}, obj.GetName(), errors.New("the object has been modified"))
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
 func TestSyncAbortedJob(t *testing.T) {
 	t.Parallel()
 
 	type testCase struct {
-		Name           string
-		Pod            *v1.Pod
-		DeleteError    error
-		ExpectSyncFail bool
-		ExpectDelete   bool
-		ExpectComplete bool
+		Name                    string
+		Pod                     *v1.Pod
+		DeleteError             error
+		AbortGracePeriodSeconds *int64
+		ExtraPodFinalizers      []string
+		ExpectSyncFail          bool
+		ExpectDelete            bool
+		ExpectComplete          bool
+		ExpectPodRemains        bool
 	}
 
 	testCases := []testCase{
@@ -2462,6 +6846,41 @@ func TestSyncAbortedJob(t *testing.T) {
 			ExpectDelete:   false,
 			ExpectComplete: false,
 		},
+		{
+			Name:                    "Pod is deleted with abort grace period",
+			Pod:                     &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-pj"}},
+			AbortGracePeriodSeconds: ptr.To[int64](30),
+			ExpectDelete:            true,
+			ExpectComplete:          true,
+		},
+		{
+			Name:                    "NotFound on delete with abort grace period is tolerated",
+			Pod:                     &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-pj"}},
+			DeleteError:             kapierrors.NewNotFound(schema.GroupResource{}, "my-pj"),
+			AbortGracePeriodSeconds: ptr.To[int64](30),
+			ExpectDelete:            false,
+			ExpectComplete:          true,
+		},
+		{
+			Name:             "Pod with unknown finalizer keeps hanging",
+			Pod:              &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-pj", Finalizers: []string{"example.com/reporter"}}},
+			ExpectDelete:     true,
+			ExpectComplete:   true,
+			ExpectPodRemains: true,
+		},
+		{
+			Name:               "Extra finalizers are removed before deleting the pod",
+			Pod:                &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-pj", Finalizers: []string{"example.com/reporter"}}},
+			ExtraPodFinalizers: []string{"example.com/reporter"},
+			ExpectDelete:       true,
+			ExpectComplete:     true,
+		},
+		{
+			Name:               "Extra finalizers of missing pod are ignored",
+			ExtraPodFinalizers: []string{"example.com/reporter"},
+			ExpectDelete:       false,
+			ExpectComplete:     true,
+		},
 	}
 
 	const cluster = "cluster"
@@ -2489,7 +6908,12 @@ func TestSyncAbortedJob(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			config := func() *config.Config { return &config.Config{} }
+			config := func() *config.Config {
+				return &config.Config{ProwConfig: config.ProwConfig{Plank: config.Plank{
+					AbortGracePeriodSeconds: tc.AbortGracePeriodSeconds,
+					ExtraPodFinalizers:      tc.ExtraPodFinalizers,
+				}}}
+			}
 
 			fakeMgr, err := testutil.NewFakeManager(
 				ctx,
@@ -2508,6 +6932,7 @@ func TestSyncAbortedJob(t *testing.T) {
 				config:       config,
 				pjClient:     fakeMgr.GetClient(),
 				buildClients: map[string]buildClient{cluster: {Client: podClient}},
+				clock:        clock.RealClock{},
 			}
 
 			res, err := r.reconcile(ctx, pj)
@@ -2528,6 +6953,18 @@ func TestSyncAbortedJob(t *testing.T) {
 			if tc.ExpectDelete != podClient.deleted.Has(pj.Name) {
 				t.Errorf("expected delete: %t, got delete: %t", tc.ExpectDelete, podClient.deleted.Has(pj.Name))
 			}
+			if opts := podClient.deleteOptions[pj.Name]; opts == nil {
+				t.Error("expected pod to be deleted with options, but it was not deleted at all")
+			} else if diff := cmp.Diff(tc.AbortGracePeriodSeconds, opts.GracePeriodSeconds); diff != "" {
+				t.Errorf("unexpected grace period seconds (-want +got):\n%s", diff)
+			}
+
+			if tc.ExpectDelete {
+				err := podClient.Get(ctx, types.NamespacedName{Name: pj.Name}, &v1.Pod{})
+				if podRemains := err == nil; podRemains != tc.ExpectPodRemains {
+					t.Errorf("expected pod to remain: %t, got pod remains: %t (err: %v)", tc.ExpectPodRemains, podRemains, err)
+				}
+			}
 		})
 	}
 }
@@ -2597,10 +7034,11 @@ func TestPodPredicate(t *testing.T) {
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			predicate, err := podPredicate(tc.selector, nil)
+			selector, err := podSelector(tc.selector)
 			if err != nil {
-				t.Fatalf("Failed to create pod predicate: %v", err)
+				t.Fatalf("Failed to create pod selector: %v", err)
 			}
+			predicate := podPredicate(selector, nil)
 
 			actualResult := predicate.Create(event.TypedCreateEvent[*corev1.Pod]{Object: tc.obj}) &&
 				predicate.Update(event.TypedUpdateEvent[*corev1.Pod]{ObjectNew: tc.obj}) &&
@@ -2613,3 +7051,141 @@ func TestPodPredicate(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteOrphanedPods(t *testing.T) {
+	now := time.Now()
+	newPod := func(name string, age time.Duration, labels map[string]string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "pods",
+				Labels:            labels,
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+		}
+	}
+	prowLabels := map[string]string{kube.CreatedByProw: "true"}
+	pj := &prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "live", Namespace: "prowjobs"},
+		Spec:       prowapi.ProwJobSpec{Agent: prowapi.KubernetesAgent},
+		Status:     prowapi.ProwJobStatus{State: prowapi.PendingState, PodName: "live"},
+	}
+	pods := []runtime.Object{
+		newPod("live", time.Hour, prowLabels),
+		newPod("orphaned", time.Hour, prowLabels),
+		newPod("young-orphan", time.Minute, prowLabels),
+		newPod("not-by-prow", time.Hour, nil),
+	}
+
+	fakeConfigAgent := newFakeConfigAgent(t, 0, nil)
+	fakeConfigAgent.c.Plank.OrphanedPodTTL = &metav1.Duration{Duration: 30 * time.Minute}
+	fakeMgr, err := testutil.NewFakeManager(
+		context.Background(),
+		[]runtime.Object{pj},
+		func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+			return setupIndexes(ctx, indexer, fakeConfigAgent.Config)
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to setup fake manager: %v", err)
+	}
+	podClient := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(pods...).Build()
+	r := &reconciler{
+		pjClient: fakeMgr.GetClient(),
+		buildClients: map[string]buildClient{
+			prowapi.DefaultClusterAlias: {Client: podClient},
+		},
+		log:    logrus.NewEntry(logrus.StandardLogger()),
+		config: fakeConfigAgent.Config,
+		clock:  clocktesting.NewFakeClock(now),
+	}
+	selector, err := podSelector("")
+	if err != nil {
+		t.Fatalf("Failed to create pod selector: %v", err)
+	}
+
+	r.deleteOrphanedPods(context.Background(), selector)
+
+	remaining := &v1.PodList{}
+	if err := podClient.List(context.Background(), remaining); err != nil {
+		t.Fatalf("Failed to list pods: %v", err)
+	}
+	var names []string
+	for _, pod := range remaining.Items {
+		names = append(names, pod.Name)
+	}
+	if diff := cmp.Diff([]string{"live", "not-by-prow", "young-orphan"}, names, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("unexpected remaining pods (-want +got):\n%s", diff)
+	}
+}
+
+func TestPodEventHandlerDebouncesUpdates(t *testing.T) {
+	testCases := []struct {
+		name               string
+		debounce           *metav1.Duration
+		expectedReconciles int
+	}{
+		{
+			name:               "every update is reconciled without debounce",
+			expectedReconciles: 5,
+		},
+		{
+			name:               "updates within the window are coalesced",
+			debounce:           &metav1.Duration{Duration: time.Second},
+			expectedReconciles: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := func() *config.Config {
+				return &config.Config{ProwConfig: config.ProwConfig{
+					ProwJobNamespace: "prowjobs",
+					Plank:            config.Plank{PodUpdateDebounce: tc.debounce},
+				}}
+			}
+			fakeClock := clocktesting.NewFakeClock(time.Now())
+			queue := workqueue.NewTypedRateLimitingQueueWithConfig(
+				workqueue.DefaultTypedControllerRateLimiter[reconcile.Request](),
+				workqueue.TypedRateLimitingQueueConfig[reconcile.Request]{Clock: fakeClock},
+			)
+			defer queue.ShutDown()
+
+			var reconciles int
+			reconcileQueued := func() {
+				for queue.Len() > 0 {
+					request, _ := queue.Get()
+					if request.Name != "my-pj" || request.Namespace != "prowjobs" {
+						t.Errorf("unexpected request %v", request)
+					}
+					reconciles++
+					queue.Done(request)
+				}
+			}
+
+			handler := podEventHandler(cfg)
+			pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-pj", Namespace: "pods"}}
+			for _, phase := range []v1.PodPhase{v1.PodPending, v1.PodPending, v1.PodRunning, v1.PodRunning, v1.PodSucceeded} {
+				newPod := pod.DeepCopy()
+				newPod.Status.Phase = phase
+				handler.Update(context.Background(), event.TypedUpdateEvent[*v1.Pod]{ObjectOld: pod, ObjectNew: newPod}, queue)
+				pod = newPod
+				reconcileQueued()
+				fakeClock.Step(100 * time.Millisecond)
+			}
+
+			if tc.debounce != nil {
+				fakeClock.Step(tc.debounce.Duration)
+				if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+					return queue.Len() > 0, nil
+				}); err != nil {
+					t.Fatalf("debounced update was never queued: %v", err)
+				}
+				reconcileQueued()
+			}
+			if reconciles != tc.expectedReconciles {
+				t.Errorf("expected %d reconciles, got %d", tc.expectedReconciles, reconciles)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plank
+
+import (
+	"context"
+	"time"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+// ProwJobSummary is a compact record of a completed ProwJob. Unlike the
+// ProwJob itself it is meant to be kept around after sinker deleted the job.
+type ProwJobSummary struct {
+	Name           string
+	Job            string
+	Type           prowv1.ProwJobType
+	State          prowv1.ProwJobState
+	Description    string
+	BuildID        string
+	URL            string
+	Cluster        string
+	StartTime      time.Time
+	PendingTime    *time.Time
+	CompletionTime time.Time
+	Refs           *prowv1.Refs
+	ExtraRefs      []prowv1.Refs
+}
+
+// HistorySink stores the summaries of completed ProwJobs, e.g. in a database
+// that can be queried for the history of a job.
+type HistorySink interface {
+	Record(ctx context.Context, summary ProwJobSummary) error
+}
+
+// WithHistorySink makes plank record the summaries of completed ProwJobs in
+// the given sink.
+func WithHistorySink(sink HistorySink) Option {
+	return func(r *reconciler) {
+		if sink != nil {
+			r.historySink = sink
+		}
+	}
+}
+
+type noopHistorySink struct{}
+
+func (noopHistorySink) Record(context.Context, ProwJobSummary) error { return nil }
+
+// summarizeProwJob builds the summary of a completed ProwJob.
+func summarizeProwJob(pj *prowv1.ProwJob) ProwJobSummary {
+	summary := ProwJobSummary{
+		Name:        pj.Name,
+		Job:         pj.Spec.Job,
+		Type:        pj.Spec.Type,
+		State:       pj.Status.State,
+		Description: pj.Status.Description,
+		BuildID:     pj.Status.BuildID,
+		URL:         pj.Status.URL,
+		Cluster:     pj.ClusterAlias(),
+		StartTime:   pj.Status.StartTime.Time,
+		Refs:        pj.Spec.Refs.DeepCopy(),
+	}
+	for _, refs := range pj.Spec.ExtraRefs {
+		summary.ExtraRefs = append(summary.ExtraRefs, *refs.DeepCopy())
+	}
+	if pj.Status.PendingTime != nil {
+		pendingTime := pj.Status.PendingTime.Time
+		summary.PendingTime = &pendingTime
+	}
+	if pj.Status.CompletionTime != nil {
+		summary.CompletionTime = pj.Status.CompletionTime.Time
+	}
+	return summary
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plank
+
+import (
+	"context"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Prometheus Metrics
+var (
+	plankMetrics = struct {
+		// Count ProwJob writes rejected because the namespace is over its quota.
+		prowJobQuotaExceeded *prometheus.CounterVec
+		// Count calls to the API servers by verb and resource.
+		apiCalls *prometheus.CounterVec
+		// Time jobs waited to be admitted for execution.
+		concurrencyWait *prometheus.HistogramVec
+		// Count decorated jobs started without a place to upload to.
+		missingDecorationDefaults *prometheus.CounterVec
+		// Count pods found stuck terminating.
+		podsStuckTerminating *prometheus.CounterVec
+		// Count jobs terminated because their pod hit a timeout.
+		podTimeouts *prometheus.CounterVec
+		// Count jobs found triggered without a pod for too long.
+		stuckTriggered *prometheus.CounterVec
+		// Count failed reconciles by job.
+		reconcileErrors *prometheus.CounterVec
+		// Count times jobs were held back by a concurrency limit.
+		concurrencyBlocked *prometheus.CounterVec
+	}{
+		prowJobQuotaExceeded: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_plank_prowjob_quota_exceeded_total",
+			Help: "Count of ProwJob writes that failed because the ProwJob namespace exceeded its quota.",
+		}, []string{
+			"namespace",
+		}),
+		apiCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_plank_api_calls_total",
+			Help: "Count of client calls made by plank by verb and resource.",
+		}, []string{
+			"verb",
+			"resource",
+		}),
+		concurrencyWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "prow_plank_concurrency_wait_seconds",
+			Help:    "Time triggered jobs waited from their creation until plank started them, e.g. because of concurrency limits.",
+			Buckets: []float64{1, 10, 30, 60, 5 * 60, 15 * 60, 30 * 60, 60 * 60, 2 * 60 * 60, 6 * 60 * 60},
+		}, []string{
+			"job",
+			"queue",
+		}),
+		missingDecorationDefaults: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_plank_missing_decoration_defaults_total",
+			Help: "Count of decorated jobs started on a cluster without a GCS configuration, whose artifacts will not be uploaded.",
+		}, []string{
+			"cluster",
+		}),
+		podsStuckTerminating: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_plank_pods_stuck_terminating_total",
+			Help: "Count of times plank found the pod of a pending job terminating for longer than the stuck terminating timeout.",
+		}, []string{
+			"cluster",
+		}),
+		podTimeouts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_plank_pod_timeouts_total",
+			Help: "Count of jobs plank terminated because their pod stayed unscheduled, pending or running for longer than the respective timeout.",
+		}, []string{
+			"timeout",
+			"job",
+		}),
+		stuckTriggered: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_plank_stuck_triggered_total",
+			Help: "Count of jobs plank found triggered without a pod for longer than the stuck triggered threshold.",
+		}, []string{
+			"job",
+		}),
+		reconcileErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_plank_reconcile_errors_total",
+			Help: "Count of ProwJob reconciles that failed with an error by job.",
+		}, []string{
+			"job",
+		}),
+		concurrencyBlocked: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_plank_concurrency_blocked_total",
+			Help: "Count of times plank held back a triggered job by the concurrency limit that blocked it.",
+		}, []string{
+			"job",
+			"queue",
+			"reason",
+		}),
+	}
+)
+
+func init() {
+	prometheus.MustRegister(plankMetrics.prowJobQuotaExceeded)
+	prometheus.MustRegister(plankMetrics.apiCalls)
+	prometheus.MustRegister(plankMetrics.concurrencyWait)
+	prometheus.MustRegister(plankMetrics.missingDecorationDefaults)
+	prometheus.MustRegister(plankMetrics.podsStuckTerminating)
+	prometheus.MustRegister(plankMetrics.podTimeouts)
+	prometheus.MustRegister(plankMetrics.stuckTriggered)
+	prometheus.MustRegister(plankMetrics.reconcileErrors)
+	prometheus.MustRegister(plankMetrics.concurrencyBlocked)
+}
+
+// countingClient counts the calls made through the client in the
+// prow_plank_api_calls_total metric. Reads may be served from a cache, so
+// they don't necessarily hit the API server.
+type countingClient struct {
+	ctrlruntimeclient.Client
+}
+
+func newCountingClient(client ctrlruntimeclient.Client) ctrlruntimeclient.Client {
+	return &countingClient{Client: client}
+}
+
+func (c *countingClient) count(verb string, obj runtime.Object) {
+	resource := "unknown"
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		resource = strings.ToLower(strings.TrimSuffix(gvk.Kind, "List"))
+	}
+	plankMetrics.apiCalls.WithLabelValues(verb, resource).Inc()
+}
+
+func (c *countingClient) Get(ctx context.Context, key ctrlruntimeclient.ObjectKey, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.GetOption) error {
+	c.count("get", obj)
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *countingClient) List(ctx context.Context, list ctrlruntimeclient.ObjectList, opts ...ctrlruntimeclient.ListOption) error {
+	c.count("list", list)
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c *countingClient) Create(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.CreateOption) error {
+	c.count("create", obj)
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *countingClient) Delete(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.DeleteOption) error {
+	c.count("delete", obj)
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *countingClient) Update(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.UpdateOption) error {
+	c.count("update", obj)
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *countingClient) Patch(ctx context.Context, obj ctrlruntimeclient.Object, patch ctrlruntimeclient.Patch, opts ...ctrlruntimeclient.PatchOption) error {
+	c.count("patch", obj)
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *countingClient) DeleteAllOf(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.DeleteAllOfOption) error {
+	c.count("deletecollection", obj)
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
@@ -21,25 +21,39 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	authorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -49,6 +63,7 @@ import (
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
 	kubernetesreporterapi "sigs.k8s.io/prow/pkg/crier/reporters/gcs/kubernetes/api"
+	"sigs.k8s.io/prow/pkg/entrypoint"
 	"sigs.k8s.io/prow/pkg/flagutil"
 	"sigs.k8s.io/prow/pkg/io"
 	"sigs.k8s.io/prow/pkg/io/providers"
@@ -60,11 +75,38 @@ import (
 
 const ControllerName = "plank"
 
+// quotaExceededRequeueInterval is how long to wait before retrying to write a
+// ProwJob after the write got rejected because of a resource quota.
+const quotaExceededRequeueInterval = 30 * time.Second
+
+// prowPodFinalizers are the finalizers Prow components put on the pods of
+// ProwJobs, which plank has to remove before it can delete a pod for good.
+var prowPodFinalizers = []string{kubernetesreporterapi.FinalizerName}
+
+// Kinds of pod timeouts, as used in the prow_plank_pod_timeouts_total metric.
+const (
+	podTimeoutUnscheduled = "unscheduled"
+	podTimeoutPending     = "pending"
+	podTimeoutRunning     = "running"
+)
+
+// Reasons a job is held back, as used in the prow_plank_concurrency_blocked_total metric.
+const (
+	concurrencyBlockedMaxConcurrency        = "max_concurrency"
+	concurrencyBlockedClusterMaxConcurrency = "cluster_max_concurrency"
+	concurrencyBlockedGlobalMaxRunningPods  = "global_max_running_pods"
+	concurrencyBlockedJobMaxConcurrency     = "job_max_concurrency"
+	concurrencyBlockedJobQueueCapacity      = "job_queue_capacity"
+)
+
 // PodStatus constants
 const (
 	Evicted    = "Evicted"
 	OOMKilled  = "OOMKilled"
 	Terminated = "Terminated"
+	// NodeShutdown is the reason on a pod that was rejected by a node that is
+	// shutting down.
+	NodeShutdown = "NodeShutdown"
 )
 
 // NodeStatus constants
@@ -97,6 +139,17 @@ func RequiredTestPodVerbs() []string {
 	}
 }
 
+// RequiredNodeVerbs returns the verbs we need to be able to use on the nodes
+// of build clusters to record the zones of the nodes of pods.
+func RequiredNodeVerbs() []string {
+	return []string{
+		"get",
+	}
+}
+
+// Option configures optional behavior of the plank controller.
+type Option func(*reconciler)
+
 func Add(
 	mgr controllerruntime.Manager,
 	buildClusters map[string]cluster.Cluster,
@@ -105,8 +158,9 @@ func Add(
 	opener io.Opener,
 	totURL string,
 	additionalSelector string,
+	opts ...Option,
 ) error {
-	return add(mgr, buildClusters, knownClusters, cfg, opener, totURL, additionalSelector, nil, nil, 10, "")
+	return add(mgr, buildClusters, knownClusters, cfg, opener, totURL, additionalSelector, nil, nil, 10, "", opts...)
 }
 
 func add(
@@ -121,13 +175,15 @@ func add(
 	predicateCallback func(bool),
 	numWorkers int,
 	controllerName string,
+	opts ...Option,
 ) error {
 	pjPredicate := prowJobPredicate(predicateCallback)
 
-	podPred, err := podPredicate(additionalSelector, predicateCallback)
+	podSel, err := podSelector(additionalSelector)
 	if err != nil {
 		return fmt.Errorf("failed to construct Pod predicate: %w", err)
 	}
+	podPred := podPredicate(podSel, predicateCallback)
 
 	ctx := context.Background()
 	if err := setupIndexes(ctx, mgr.GetFieldIndexer(), cfg); err != nil {
@@ -145,7 +201,10 @@ func add(
 		WithEventFilter(pjPredicate).
 		WithOptions(controller.Options{MaxConcurrentReconciles: numWorkers})
 
-	r := newReconciler(ctx, mgr.GetClient(), overwriteReconcile, cfg, opener, totURL)
+	r := newReconciler(ctx, mgr.GetClient(), overwriteReconcile, cfg, opener, totURL, mgr.GetEventRecorderFor(controllerName))
+	for _, opt := range opts {
+		opt(r)
+	}
 	for buildClusterName, buildCluster := range buildClusters {
 		r.log.WithFields(logrus.Fields{
 			"buildCluster": buildClusterName,
@@ -155,12 +214,14 @@ func add(
 		blder.WatchesRawSource(source.Kind(
 			buildCluster.GetCache(),
 			&corev1.Pod{},
-			podEventRequestMapper(cfg().ProwJobNamespace),
+			podEventHandler(cfg),
 			podPred,
 		))
 
 		bc := buildClient{
-			Client: buildCluster.GetClient()}
+			Client:    newCountingClient(buildCluster.GetClient()),
+			apiReader: buildCluster.GetAPIReader(),
+		}
 		if restConfig, ok := knownClusters[buildClusterName]; ok {
 			authzClient, err := authorizationv1.NewForConfig(&restConfig)
 			if err != nil {
@@ -183,6 +244,10 @@ func add(
 		return fmt.Errorf("failed to add cluster status runnable to manager: %w", err)
 	}
 
+	if err := mgr.Add(manager.RunnableFunc(r.sweepOrphanedPods(orphanedPodSweepInterval, podSel))); err != nil {
+		return fmt.Errorf("failed to add orphaned pod sweep runnable to manager: %w", err)
+	}
+
 	return nil
 }
 
@@ -194,16 +259,20 @@ func setupIndexes(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer, c
 	return nil
 }
 
-func newReconciler(ctx context.Context, pjClient ctrlruntimeclient.Client, overwriteReconcile reconcile.Func, cfg config.Getter, opener io.Opener, totURL string) *reconciler {
+func newReconciler(ctx context.Context, pjClient ctrlruntimeclient.Client, overwriteReconcile reconcile.Func, cfg config.Getter, opener io.Opener, totURL string, recorder record.EventRecorder) *reconciler {
 	return &reconciler{
-		pjClient:           pjClient,
+		pjClient:           newCountingClient(pjClient),
 		buildClients:       map[string]buildClient{},
 		overwriteReconcile: overwriteReconcile,
 		log:                logrus.NewEntry(logrus.StandardLogger()).WithField("controller", ControllerName),
 		config:             cfg,
 		opener:             opener,
 		totURL:             totURL,
+		historySink:        noopHistorySink{},
 		clock:              clock.RealClock{},
+		startTime:          time.Now(),
+		recorder:           recorder,
+		instance:           instanceName(),
 		maxConcurrencySerializationLocks: &shardedLock{
 			mapLock: &sync.Mutex{},
 			locks:   map[string]*sync.Mutex{},
@@ -223,7 +292,22 @@ type reconciler struct {
 	config             config.Getter
 	opener             io.Opener
 	totURL             string
+	historySink        HistorySink
 	clock              clock.WithTickerAndDelayedExecution
+	// startTime is when the reconciler got created, used to tell whether its
+	// caches may still be warming up.
+	startTime time.Time
+	// randFloat64 returns random numbers in [0, 1) for the requeue jitter.
+	// Defaults to rand.Float64 if nil.
+	randFloat64 func() float64
+	// recorder records the state transitions of jobs as events on them.
+	// May be nil.
+	recorder record.EventRecorder
+	// instance is the name of this plank instance and reconcileSequence
+	// counts the reconciles that changed the status of a job, so that the
+	// changes can be attributed to them.
+	instance          string
+	reconcileSequence atomic.Int64
 	/* maxConcurrencySerializationLocks and jobQueueSerializationLocks are used to serialize
 	   reconciliation of ProwJobs that have concurrency limits that might affect eachother.
 
@@ -241,6 +325,81 @@ type reconciler struct {
 	*/
 	maxConcurrencySerializationLocks *shardedLock
 	jobQueueSerializationLocks       *shardedLock
+	// reconcileErrors tracks the recent reconcile errors of every job.
+	reconcileErrors reconcileErrorTracker
+	// clusterHealth caches the results of the build cluster health probes.
+	clusterHealth clusterHealthCache
+}
+
+// clusterHealthProbeTimeout is how long a build cluster may take to answer a
+// health probe before it is considered unhealthy.
+const clusterHealthProbeTimeout = 5 * time.Second
+
+// ClusterHealth is the result of the last health probe of a build cluster.
+type ClusterHealth struct {
+	// Healthy is whether the cluster answered the probe.
+	Healthy bool
+	// LastProbeTime is when the cluster was probed.
+	LastProbeTime time.Time
+	// Error is why the probe failed, if it did.
+	Error string
+}
+
+// clusterHealthCache holds the result of the last health probe of every
+// build cluster.
+type clusterHealthCache struct {
+	lock   sync.Mutex
+	health map[string]ClusterHealth
+}
+
+func (c *clusterHealthCache) get(cluster string) (ClusterHealth, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	health, ok := c.health[cluster]
+	return health, ok
+}
+
+func (c *clusterHealthCache) set(cluster string, health ClusterHealth) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.health == nil {
+		c.health = map[string]ClusterHealth{}
+	}
+	c.health[cluster] = health
+}
+
+// defaultReconcileErrorWindow is the window within which reconcile errors
+// count towards the reconcile error threshold if none is configured.
+const defaultReconcileErrorWindow = 10 * time.Minute
+
+// defaultPausedRequeueInterval is how often paused jobs are checked for
+// having been unpaused if no interval is configured.
+const defaultPausedRequeueInterval = time.Minute
+
+// reconcileErrorTracker keeps the times of the recent reconcile errors of
+// every job, to tell how often a job failed to reconcile within a window.
+type reconcileErrorTracker struct {
+	lock   sync.Mutex
+	errors map[string][]time.Time
+}
+
+// record adds a reconcile error of the job at the given time and returns the
+// number of its errors within the window up to then.
+func (t *reconcileErrorTracker) record(job string, now time.Time, window time.Duration) int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.errors == nil {
+		t.errors = map[string][]time.Time{}
+	}
+	var recent []time.Time
+	for _, errTime := range t.errors[job] {
+		if now.Sub(errTime) < window {
+			recent = append(recent, errTime)
+		}
+	}
+	recent = append(recent, now)
+	t.errors[job] = recent
+	return len(recent)
 }
 
 type shardedLock struct {
@@ -251,6 +410,18 @@ type shardedLock struct {
 type buildClient struct {
 	ctrlruntimeclient.Client
 	ssar authorizationv1.SelfSubjectAccessReviewInterface
+	// apiReader reads directly from the API server, bypassing the cache. It is
+	// used for objects plank does not watch, e.g. secrets and configmaps.
+	apiReader ctrlruntimeclient.Reader
+}
+
+// reader returns the uncached reader of the build client, falling back to the
+// cached client if none is set.
+func (c buildClient) reader() ctrlruntimeclient.Reader {
+	if c.apiReader != nil {
+		return c.apiReader
+	}
+	return c.Client
 }
 
 func (s *shardedLock) getLock(key string) *sync.Mutex {
@@ -280,6 +451,70 @@ func (r *reconciler) syncMetrics(ctx context.Context) error {
 	}
 }
 
+// orphanedPodSweepInterval is how often plank looks for orphaned pods.
+const orphanedPodSweepInterval = 5 * time.Minute
+
+// sweepOrphanedPods periodically deletes the pods matching selector that
+// outlived their ProwJob, as long as plank.orphaned_pod_ttl is set.
+func (r *reconciler) sweepOrphanedPods(interval time.Duration, selector labels.Selector) func(context.Context) error {
+	return func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				r.deleteOrphanedPods(ctx, selector)
+			}
+		}
+	}
+}
+
+// deleteOrphanedPods deletes the pods matching selector that are older than
+// plank.orphaned_pod_ttl and whose ProwJob doesn't exist anymore. Nothing
+// else cleans these up, as pods only get deleted when syncing their ProwJob.
+func (r *reconciler) deleteOrphanedPods(ctx context.Context, selector labels.Selector) {
+	cfg := r.config()
+	ttl := cfg.Plank.OrphanedPodTTL
+	if ttl == nil || ttl.Duration <= 0 {
+		return
+	}
+	for cluster, client := range r.buildClients {
+		log := r.log.WithField("cluster", cluster)
+		pods := &corev1.PodList{}
+		if err := client.List(ctx, pods, ctrlruntimeclient.InNamespace(cfg.PodNamespace), ctrlruntimeclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+			log.WithError(err).Error("Failed to list pods to look for orphaned ones.")
+			continue
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if r.clock.Since(pod.CreationTimestamp.Time) < ttl.Duration {
+				continue
+			}
+			log := log.WithField("pod", pod.Name)
+			// Pods are named after their ProwJob.
+			err := r.pjClient.Get(ctx, types.NamespacedName{Namespace: cfg.ProwJobNamespace, Name: pod.Name}, &prowv1.ProwJob{})
+			if err == nil {
+				continue
+			}
+			if !kerrors.IsNotFound(err) {
+				log.WithError(err).Error("Failed to get the ProwJob of pod.")
+				continue
+			}
+			if err := r.removeExtraPodFinalizers(ctx, client, pod); err != nil {
+				log.WithError(err).Error("Failed to remove the extra finalizers of orphaned pod.")
+				continue
+			}
+			if err := ctrlruntimeclient.IgnoreNotFound(client.Delete(ctx, pod)); err != nil {
+				log.WithError(err).Error("Failed to delete orphaned pod.")
+				continue
+			}
+			log.Info("Deleted orphaned pod whose ProwJob doesn't exist anymore.")
+		}
+	}
+}
+
 type ClusterStatus string
 
 const (
@@ -328,6 +563,16 @@ func (r *reconciler) syncClusterStatus(
 							} else {
 								status = ClusterStatusError
 							}
+						} else if r.config().Plank.RecordPodNode {
+							// Check for node verbs, needed to record the zones of nodes.
+							if err := flagutil.CheckNodeAuthorizations(client.ssar, RequiredNodeVerbs()); err != nil {
+								r.log.WithField("cluster", cluster).WithError(err).Warn("Error checking node verbs to check for build cluster usability.")
+								if errors.Is(err, flagutil.MissingPermissions) {
+									status = ClusterStatusMissingPermissions
+								} else {
+									status = ClusterStatusError
+								}
+							}
 						}
 					}
 					clusters[cluster] = status
@@ -370,7 +615,24 @@ func (r *reconciler) defaultReconcile(ctx context.Context, request reconcile.Req
 	}
 	originalPJ := pj.DeepCopy()
 
-	res, err := r.serializeIfNeeded(ctx, pj)
+	// Leave paused jobs alone. Removing the annotation is an update that gets
+	// the job reconciled right away, the requeue is only a safety net.
+	if pj.Annotations[kube.PausedAnnotation] == "true" {
+		r.log.WithFields(pjutil.ProwJobFields(pj)).Info("Not reconciling paused job.")
+		interval := defaultPausedRequeueInterval
+		if configured := r.config().Plank.PausedRequeueInterval; configured != nil {
+			interval = configured.Duration
+		}
+		return reconcile.Result{RequeueAfter: interval}, nil
+	}
+
+	syncCtx := ctx
+	if timeout := r.config().Plank.ReconcileTimeout; timeout != nil && timeout.Duration > 0 {
+		var cancel context.CancelFunc
+		syncCtx, cancel = context.WithTimeout(ctx, timeout.Duration)
+		defer cancel()
+	}
+	res, err := r.serializeIfNeeded(syncCtx, pj)
 	if IsTerminalError(err) {
 		// Unfixable cases like missing build clusters, do not return an error to prevent requeuing
 		log := r.log.WithError(err).WithFields(pjutil.ProwJobFields(pj))
@@ -393,10 +655,43 @@ func (r *reconciler) defaultReconcile(ctx context.Context, request reconcile.Req
 	}
 	if err != nil {
 		r.log.WithError(err).WithField("name", request.Name).Error("Reconciliation failed")
+		r.recordReconcileError(ctx, originalPJ)
 	}
 	return *res, err
 }
 
+// recordReconcileError counts a failed reconcile of the job and annotates the
+// ProwJob with the number of recent errors of its job if it reached the
+// reconcile error threshold.
+func (r *reconciler) recordReconcileError(ctx context.Context, pj *prowv1.ProwJob) {
+	plankMetrics.reconcileErrors.WithLabelValues(pj.Spec.Job).Inc()
+	threshold := r.config().Plank.ReconcileErrorThreshold
+	if threshold <= 0 {
+		return
+	}
+	window := defaultReconcileErrorWindow
+	if configured := r.config().Plank.ReconcileErrorWindow; configured != nil {
+		window = configured.Duration
+	}
+	count := r.reconcileErrors.record(pj.Spec.Job, r.clock.Now(), window)
+	if count < threshold || pj.Annotations[kube.ReconcileErrorsAnnotation] == strconv.Itoa(count) {
+		return
+	}
+	// Only patch the annotation, as the status changes of the failed
+	// reconcile were not persisted.
+	annotated := pj.DeepCopy()
+	if annotated.Annotations == nil {
+		annotated.Annotations = map[string]string{}
+	}
+	annotated.Annotations[kube.ReconcileErrorsAnnotation] = strconv.Itoa(count)
+	log := r.log.WithFields(pjutil.ProwJobFields(pj)).WithField("reconcile-errors", count)
+	if err := r.pjClient.Patch(ctx, annotated, ctrlruntimeclient.MergeFrom(pj)); err != nil {
+		log.WithError(err).Warn("Failed to annotate job with its reconcile errors.")
+		return
+	}
+	log.Warn("Job keeps failing to reconcile.")
+}
+
 // serializeIfNeeded serializes the reconciliation of Jobs that have a MaxConcurrency or a JobQueueName set, otherwise
 // multiple reconciliations of the same job or queue may race and not properly respect that setting.
 func (r *reconciler) serializeIfNeeded(ctx context.Context, pj *prowv1.ProwJob) (*reconcile.Result, error) {
@@ -429,16 +724,174 @@ func (r *reconciler) reconcile(ctx context.Context, pj *prowv1.ProwJob) (*reconc
 		return nil, fmt.Errorf("terminateDupes failed: %w", err)
 	}
 
+	// The sync functions only mutate pj in memory. All of the status changes
+	// they make are applied with a single patch below, so that jobs going
+	// through several intermediate states in one reconcile don't cause a write
+	// for each of them.
+	prevPJ := pj.DeepCopy()
+
+	if r.config().Plank.HonorAbortAnnotation && pj.Annotations[kube.AbortAnnotation] == "true" && !pj.Complete() && pj.Status.State != prowv1.AbortedState {
+		r.log.WithFields(pjutil.ProwJobFields(pj)).Info("Aborting job annotated to be aborted.")
+		pj.Status.State = prowv1.AbortedState
+		pj.Status.Description = fmt.Sprintf("Job aborted by the %s annotation.", kube.AbortAnnotation)
+	}
+
+	var res *reconcile.Result
+	var err error
 	switch pj.Status.State {
 	case prowv1.PendingState:
-		return r.syncPendingJob(ctx, pj)
+		res, err = r.syncPendingJob(ctx, pj)
+		res = r.jitterRequeue(res)
 	case prowv1.TriggeredState:
-		return r.syncTriggeredJob(ctx, pj)
+		res, err = r.syncTriggeredJob(ctx, pj)
 	case prowv1.AbortedState:
-		return nil, r.syncAbortedJob(ctx, pj)
+		err = r.syncAbortedJob(ctx, pj)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, nil
+	if !equality.Semantic.DeepEqual(prevPJ.Status, pj.Status) {
+		now := metav1.NewTime(r.clock.Now())
+		pj.Status.LastReconcileTime = &now
+		if r.config().Plank.RecordReconcileAttribution {
+			r.recordReconcileAttribution(pj)
+		}
+	}
+
+	if err := r.patchProwJob(ctx, prevPJ, pj); err != nil {
+		// Being over the quota is a problem of the namespace, not of the job.
+		// Keep retrying so the job recovers once the quota clears up.
+		if isQuotaExceededError(err) {
+			plankMetrics.prowJobQuotaExceeded.WithLabelValues(pj.Namespace).Inc()
+			r.log.WithFields(pjutil.ProwJobFields(pj)).WithError(err).Warn("ProwJob namespace exceeded its quota, requeueing.")
+			return &reconcile.Result{RequeueAfter: quotaExceededRequeueInterval}, nil
+		}
+		return nil, err
+	}
+	if r.recorder != nil && prevPJ.Status.State != pj.Status.State {
+		r.recordTransition(prevPJ.Status.State, pj)
+	}
+
+	if !prevPJ.Complete() && pj.Complete() && r.historySink != nil {
+		if err := r.historySink.Record(ctx, summarizeProwJob(pj)); err != nil {
+			// The history is best effort, it must not hold up the job.
+			r.log.WithFields(pjutil.ProwJobFields(pj)).WithError(err).Warn("Failed to record job in history sink.")
+		}
+	}
+	return res, nil
+}
+
+// recordTransition records an event on pj for its transition from the state
+// from to its current one, so that its lifecycle shows up when describing it.
+func (r *reconciler) recordTransition(from prowv1.ProwJobState, pj *prowv1.ProwJob) {
+	eventType := corev1.EventTypeNormal
+	if pj.Status.State == prowv1.FailureState || pj.Status.State == prowv1.ErrorState {
+		eventType = corev1.EventTypeWarning
+	}
+	r.recorder.Eventf(pj, eventType, transitionReason(pj), "Transitioned from %s to %s: %s", from, pj.Status.State, pj.Status.Description)
+}
+
+// transitionReason returns the reason why pj transitioned to its current state.
+func transitionReason(pj *prowv1.ProwJob) string {
+	switch pj.Status.State {
+	case prowv1.PendingState:
+		return "PodCreated"
+	case prowv1.SuccessState:
+		return "PodSucceeded"
+	case prowv1.FailureState:
+		return "PodFailed"
+	case prowv1.AbortedState:
+		return "JobAborted"
+	case prowv1.ErrorState:
+		if reason := pj.Annotations[kube.ErrorReasonAnnotation]; reason != "" {
+			return reason
+		}
+		if pj.Annotations[kube.OOMKilledAnnotation] == "true" {
+			return kube.ErrorReasonOOMKilled
+		}
+		return "JobErrored"
+	default:
+		return "StateChanged"
+	}
+}
+
+// recordReconcileAttribution records on pj which plank instance changed its
+// status, and in which of the reconciles of the instance.
+func (r *reconciler) recordReconcileAttribution(pj *prowv1.ProwJob) {
+	if pj.Annotations == nil {
+		pj.Annotations = map[string]string{}
+	}
+	pj.Annotations[kube.ReconcilerInstanceAnnotation] = r.instance
+	pj.Annotations[kube.ReconcileSequenceAnnotation] = strconv.FormatInt(r.reconcileSequence.Add(1), 10)
+}
+
+// instanceName returns the name of this plank instance. The POD_NAME
+// environment variable can be set from the downward API, the hostname
+// matches the pod name otherwise.
+func instanceName() string {
+	if name := os.Getenv("POD_NAME"); name != "" {
+		return name
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	return hostname
+}
+
+// patchProwJob applies all the changes made to pj since prevPJ with a single
+// patch. Nothing is written if pj didn't change.
+func (r *reconciler) patchProwJob(ctx context.Context, prevPJ, pj *prowv1.ProwJob) error {
+	if equality.Semantic.DeepEqual(prevPJ, pj) {
+		return nil
+	}
+
+	if prevPJ.Status.State != pj.Status.State {
+		r.log.WithFields(pjutil.ProwJobFields(pj)).
+			WithField("from", prevPJ.Status.State).
+			WithField("to", pj.Status.State).Info("Transitioning states.")
+	}
+
+	if err := r.pjClient.Patch(ctx, pj.DeepCopy(), ctrlruntimeclient.MergeFrom(prevPJ)); err != nil {
+		return fmt.Errorf("patching prowjob: %w", err)
+	}
+
+	if !shouldWaitForCachedProwJob(prevPJ, pj) {
+		return nil
+	}
+	state := pj.Status.State
+	nn := types.NamespacedName{Namespace: pj.Namespace, Name: pj.Name}
+	if err := wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, 2*time.Second, true, func(ctx context.Context) (bool, error) {
+		if err := r.pjClient.Get(ctx, nn, pj); err != nil {
+			return false, fmt.Errorf("failed to get prowjob: %w", err)
+		}
+		return pj.Status.State == state, nil
+	}); err != nil {
+		return fmt.Errorf("failed to wait for cached prowjob %s to get into state %s: %w", nn.String(), state, err)
+	}
+
+	return nil
+}
+
+// shouldWaitForCachedProwJob returns whether the reconciler has to block until
+// the patched state of pj reaches the cache.
+func shouldWaitForCachedProwJob(prevPJ, pj *prowv1.ProwJob) bool {
+	switch prevPJ.Status.State {
+	case prowv1.PendingState:
+		// If the ProwJob state has changed, we must ensure that the update reaches the cache before
+		// processing the key again. Without this we might accidentally replace intentionally deleted pods
+		// or otherwise incorrectly react to stale ProwJob state.
+		return prevPJ.Status.State != pj.Status.State
+	case prowv1.TriggeredState:
+		// If the job has either MaxConcurrency or JobQueueName configured, we must block here until we observe the state transition in our cache,
+		// otherwise subequent reconciliations for a different run of the same job might incorrectly conclude that they
+		// can run because that decision is made based on the data in the cache.
+		return pj.Spec.MaxConcurrency != 0 || pj.Spec.JobQueueName != ""
+	}
+	return false
 }
 
 func (r *reconciler) terminateDupes(ctx context.Context, pj *prowv1.ProwJob) error {
@@ -447,21 +900,51 @@ func (r *reconciler) terminateDupes(ctx context.Context, pj *prowv1.ProwJob) err
 		return fmt.Errorf("failed to list prowjobs: %w", err)
 	}
 
+	if r.config().Plank.DupePolicy == config.DupePolicyPreferOldest {
+		return pjutil.TerminateNewerJobs(r.pjClient, r.log, pjs.Items)
+	}
 	return pjutil.TerminateOlderJobs(r.pjClient, r.log, pjs.Items)
 }
 
 // syncPendingJob syncs jobs for which we already created the test workload
 func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*reconcile.Result, error) {
-	prevPJ := pj.DeepCopy()
-
 	pod, podExists, err := r.pod(ctx, pj)
 	if err != nil {
 		return nil, err
 	}
 
+	if podExists && pj.Status.PodQOSClass == "" {
+		r.recordPodQOSClass(pj, pod)
+	}
+	if podExists && pj.Status.NodeName == "" && pod.Status.Phase != corev1.PodPending {
+		r.recordPodNode(ctx, pj, pod)
+	}
+	if podExists && pj.Status.PodScheduledTime == nil {
+		r.recordPodScheduledTime(pj, pod)
+	}
+	if podExists && pod.DeletionTimestamp == nil && r.config().Plank.RepairPodLabels {
+		if err := r.repairPodLabels(ctx, pj, pod); err != nil {
+			return nil, fmt.Errorf("repairPodLabels: %w", err)
+		}
+	}
+
+	if podExists && pod.DeletionTimestamp != nil {
+		if err := r.handleStuckTerminatingPod(ctx, pj, pod); err != nil {
+			return nil, err
+		}
+	}
+
 	if !podExists {
+		// Right after the start the pod may only be missing from the cache.
+		if delay := r.cacheWarmupDelay(); delay > 0 {
+			r.log.WithFields(pjutil.ProwJobFields(pj)).WithField("delay", delay).Debug("Pod not found while the cache is warming up, checking again later.")
+			return &reconcile.Result{RequeueAfter: delay}, nil
+		}
 		// Pod is missing. This can happen in case the previous pod was deleted manually or by
 		// a rescheduler. Start a new pod.
+		if delay := r.revivalDelay(pj); delay > 0 {
+			return &reconcile.Result{RequeueAfter: delay}, nil
+		}
 		id, pn, err := r.startPod(ctx, pj)
 		if err != nil {
 			if !isRequestError(err) {
@@ -470,13 +953,15 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			pj.Status.State = prowv1.ErrorState
 			pj.SetComplete()
 			pj.Status.Description = fmt.Sprintf("Pod can not be created: %v", err)
+			setErrorReason(pj, kube.ErrorReasonPodCreationFailed)
 			r.log.WithFields(pjutil.ProwJobFields(pj)).WithError(err).Warning("Unprocessable pod.")
 		} else {
 			pj.Status.BuildID = id
 			pj.Status.PodName = pn
 			r.log.WithFields(pjutil.ProwJobFields(pj)).Info("Pod is missing, starting a new pod")
 		}
-	} else if podUnexpectedStopCause := getPodUnexpectedStopCause(pod); podUnexpectedStopCause != PodUnexpectedStopCauseNone {
+	} else if podUnexpectedStopCause := r.podUnexpectedStopCause(pj, pod); podUnexpectedStopCause != PodUnexpectedStopCauseNone {
+		maxRevivals := r.maxRevivals(pj)
 		switch {
 		case podUnexpectedStopCause == PodUnexpectedStopCauseOOMKilled:
 			// OOMKilled, complete the PJ and mark it as errored.
@@ -484,20 +969,28 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			pj.SetComplete()
 			pj.Status.State = prowv1.ErrorState
 			pj.Status.Description = "Job pod was OOM killed by the cluster."
+			setErrorReason(pj, kube.ErrorReasonOOMKilled)
 		case podUnexpectedStopCause == PodUnexpectedStopCauseEvicted && pj.Spec.ErrorOnEviction:
 			// ErrorOnEviction is enabled, complete the PJ and mark it as errored.
 			r.log.WithField("error-on-eviction", true).WithFields(pjutil.ProwJobFields(pj)).Info("Pods Node got evicted, fail job.")
 			pj.SetComplete()
 			pj.Status.State = prowv1.ErrorState
 			pj.Status.Description = "Job pod was evicted by the cluster."
-		case pj.Status.PodRevivalCount >= *r.config().Plank.MaxRevivals:
+			setErrorReason(pj, kube.ErrorReasonPodEvicted)
+		case pj.Status.PodRevivalCount >= maxRevivals:
 			// MaxRevivals is reached, complete the PJ and mark it as errored.
 			r.log.WithField("unexpected-stop-cause", podUnexpectedStopCause).WithFields(pjutil.ProwJobFields(pj)).Info("Pod Node reached max retries, fail job.")
 			pj.SetComplete()
 			pj.Status.State = prowv1.ErrorState
 			pj.Status.Description = fmt.Sprintf("Job pod reached max revivals (%d) after being stopped unexpectedly (%s)", pj.Status.PodRevivalCount, podUnexpectedStopCause)
+			setErrorReason(pj, kube.ErrorReasonPodStoppedUnexpectedly)
 		default:
 			// Update the revival count and delete the pod so it gets recreated in the next resync.
+			if r.config().Plank.RecordRevivalAttempts {
+				if err := recordRevivalAttempt(pj, pod, podUnexpectedStopCause); err != nil {
+					r.log.WithFields(pjutil.ProwJobFields(pj)).WithError(err).Warn("Failed to record revival attempt.")
+				}
+			}
 			pj.Status.PodRevivalCount++
 			r.log.
 				WithField("unexpected-stop-cause", podUnexpectedStopCause).
@@ -508,13 +1001,9 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			if !ok {
 				return nil, TerminalError(fmt.Errorf("pod %s which was stopped unexpectedly (%s): unknown cluster alias %q", pod.Name, podUnexpectedStopCause, pj.ClusterAlias()))
 			}
-			if finalizers := sets.New(pod.Finalizers...); finalizers.Has(kubernetesreporterapi.FinalizerName) {
-				// We want the end user to not see this, so we have to remove the finalizer, otherwise the pod hangs
-				oldPod := pod.DeepCopy()
-				pod.Finalizers = finalizers.Delete(kubernetesreporterapi.FinalizerName).UnsortedList()
-				if err := client.Patch(ctx, pod, ctrlruntimeclient.MergeFrom(oldPod)); err != nil {
-					return nil, fmt.Errorf("failed to patch pod trying to remove %s finalizer: %w", kubernetesreporterapi.FinalizerName, err)
-				}
+			// We want the end user to not see this, so we have to remove the finalizers, otherwise the pod hangs
+			if err := removePodFinalizers(ctx, client, pod, r.podFinalizers()); err != nil {
+				return nil, err
 			}
 
 			// Pod is already deleted, so we don't need to delete it again.
@@ -523,8 +1012,19 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			}
 
 			r.log.WithField("name", pj.ObjectMeta.Name).Debug("Delete Pod.")
-			return nil, ctrlruntimeclient.IgnoreNotFound(client.Delete(ctx, pod))
+			if err := client.Delete(ctx, pod); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				return nil, err
+			}
+			if backoff := r.revivalBackoff(pj.Status.PodRevivalCount); backoff > 0 {
+				return &reconcile.Result{RequeueAfter: backoff}, nil
+			}
+			return nil, nil
 		}
+	} else if r.config().Plank.WaitForPodInfoUpload && isPodInfoUploadPending(pod) {
+		// The pod finished, but crier didn't upload its pod info yet. Removing
+		// the finalizer updates the pod, so the job gets reconciled again then.
+		r.log.WithFields(pjutil.ProwJobFields(pj)).Debug("Waiting for the pod info to be uploaded.")
+		return nil, nil
 	} else {
 		switch pod.Status.Phase {
 		case corev1.PodSucceeded:
@@ -543,8 +1043,15 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 		case corev1.PodFailed:
 			// Pod failed. Update ProwJob, talk to GitHub.
 			pj.SetComplete()
-			pj.Status.State = prowv1.FailureState
-			pj.Status.Description = "Job failed."
+			if treatOOMAsError := r.config().Plank.TreatOOMAsError; (treatOOMAsError == nil || *treatOOMAsError) && wasOOMKilled(pod) {
+				pj.Status.State = prowv1.ErrorState
+				pj.Status.Description = "Job errored because a container ran out of memory."
+				setErrorReason(pj, kube.ErrorReasonOOMKilled)
+				pj.Annotations[kube.OOMKilledAnnotation] = "true"
+			} else {
+				pj.Status.State = prowv1.FailureState
+				pj.Status.Description = "Job failed."
+			}
 
 		case corev1.PodPending:
 			var requeueAfter time.Duration
@@ -563,6 +1070,8 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 					pj.SetComplete()
 					pj.Status.State = prowv1.ErrorState
 					pj.Status.Description = "Pod scheduling timeout."
+					plankMetrics.podTimeouts.WithLabelValues(podTimeoutUnscheduled, pj.Spec.Job).Inc()
+					setErrorReason(pj, kube.ErrorReasonPodUnscheduledTimeout)
 					r.log.WithFields(pjutil.ProwJobFields(pj)).Info("Marked job for stale unscheduled pod as errored.")
 					if err := r.deletePod(ctx, pj); err != nil {
 						return nil, fmt.Errorf("failed to delete pod %s/%s in cluster %s: %w", pod.Namespace, pod.Name, pj.ClusterAlias(), err)
@@ -574,21 +1083,43 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 					requeueAfter = maxPodUnscheduled - time.Since(pod.CreationTimestamp.Time)
 				}
 			} else {
+				if imagePullTimeout := r.config().Plank.ImagePullTimeout; imagePullTimeout != nil && imagePullTimeout.Duration > 0 {
+					if container, failing := failingImagePull(pod); failing {
+						if time.Since(pod.Status.StartTime.Time) >= imagePullTimeout.Duration {
+							// The image most likely doesn't exist, so there is no
+							// point in waiting for the pod pending timeout.
+							pj.SetComplete()
+							pj.Status.State = prowv1.ErrorState
+							pj.Status.Description = fmt.Sprintf("Pod could not pull image %q of container %s: %s.", container.Image, container.Name, container.State.Waiting.Reason)
+							setErrorReason(pj, kube.ErrorReasonImagePullTimeout)
+							r.log.WithFields(pjutil.ProwJobFields(pj)).WithField("image", container.Image).Info("Marked job for pod failing to pull its image as errored.")
+							if err := r.deletePod(ctx, pj); err != nil {
+								return nil, fmt.Errorf("failed to delete pod %s/%s in cluster %s: %w", pod.Namespace, pod.Name, pj.ClusterAlias(), err)
+							}
+							break
+						}
+						// The pull might still succeed, re-check on the pod once the
+						// image pull timeout is reached.
+						requeueAfter = imagePullTimeout.Duration - time.Since(pod.Status.StartTime.Time)
+					}
+				}
 				if time.Since(pod.Status.StartTime.Time) >= maxPodPending {
 					// Pod is stuck in pending state longer than maxPodPending
 					// abort the job, and talk to GitHub
 					pj.SetComplete()
 					pj.Status.State = prowv1.ErrorState
 					pj.Status.Description = "Pod pending timeout."
+					plankMetrics.podTimeouts.WithLabelValues(podTimeoutPending, pj.Spec.Job).Inc()
+					setErrorReason(pj, kube.ErrorReasonPodPendingTimeout)
 					r.log.WithFields(pjutil.ProwJobFields(pj)).Info("Marked job for stale pending pod as errored.")
 					if err := r.deletePod(ctx, pj); err != nil {
 						return nil, fmt.Errorf("failed to delete pod %s/%s in cluster %s: %w", pod.Namespace, pod.Name, pj.ClusterAlias(), err)
 					}
 					break
-				} else {
+				} else if untilPendingTimeout := maxPodPending - time.Since(pod.Status.StartTime.Time); requeueAfter == 0 || untilPendingTimeout < requeueAfter {
 					// We have to re-check on the pod once we reached maxPodPending to
 					// be able to fail the job if it didn't start running by then.
-					requeueAfter = maxPodPending - time.Since(pod.Status.StartTime.Time)
+					requeueAfter = untilPendingTimeout
 				}
 			}
 			// Pod didn't start but didn't reach the scheduling or pending timeout yet,
@@ -600,6 +1131,20 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			if pod.DeletionTimestamp != nil {
 				break
 			}
+			if r.config().Plank.CompleteOnTerminatedContainers && didTestContainersSucceed(pod) {
+				// The pod phase lags behind, most likely because of a sidecar that
+				// didn't exit. The test itself is done, so consider the job succeeded.
+				pj.SetComplete()
+				pj.Status.State = prowv1.SuccessState
+				pj.Status.Description = "Job succeeded."
+				r.log.WithFields(pjutil.ProwJobFields(pj)).Info("Marked job with terminated containers in running pod as succeeded.")
+				if r.config().Plank.DeleteLingeringPods {
+					if err := r.deletePod(ctx, pj); err != nil {
+						return nil, fmt.Errorf("failed to delete pod %s/%s in cluster %s: %w", pod.Namespace, pod.Name, pj.ClusterAlias(), err)
+					}
+				}
+				break
+			}
 			maxPodRunning := r.config().Plank.PodRunningTimeout.Duration
 			if pj.Spec.DecorationConfig != nil && pj.Spec.DecorationConfig.PodRunningTimeout != nil {
 				maxPodRunning = pj.Spec.DecorationConfig.PodRunningTimeout.Duration
@@ -614,6 +1159,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			pj.SetComplete()
 			pj.Status.State = prowv1.AbortedState
 			pj.Status.Description = "Pod running timeout."
+			plankMetrics.podTimeouts.WithLabelValues(podTimeoutRunning, pj.Spec.Job).Inc()
 			if err := r.deletePod(ctx, pj); err != nil {
 				return nil, fmt.Errorf("failed to delete pod %s/%s in cluster %s: %w", pod.Namespace, pod.Name, pj.ClusterAlias(), err)
 			}
@@ -631,6 +1177,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 		pj.SetComplete()
 		pj.Status.State = prowv1.ErrorState
 		pj.Status.Description = "Pod got deleted unexpectedly"
+		setErrorReason(pj, kube.ErrorReasonPodDeleted)
 	}
 
 	pj.Status.URL, err = pjutil.JobURL(r.config().Plank, *pj, r.log)
@@ -638,44 +1185,18 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 		r.log.WithFields(pjutil.ProwJobFields(pj)).WithError(err).Warn("failed to get jobURL")
 	}
 
-	if prevPJ.Status.State != pj.Status.State {
-		r.log.WithFields(pjutil.ProwJobFields(pj)).
-			WithField("from", prevPJ.Status.State).
-			WithField("to", pj.Status.State).Info("Transitioning states.")
-	}
-
-	if err := r.pjClient.Patch(ctx, pj.DeepCopy(), ctrlruntimeclient.MergeFrom(prevPJ)); err != nil {
-		return nil, fmt.Errorf("patching prowjob: %w", err)
-	}
-
-	// If the ProwJob state has changed, we must ensure that the update reaches the cache before
-	// processing the key again. Without this we might accidentally replace intentionally deleted pods
-	// or otherwise incorrectly react to stale ProwJob state.
-	state := pj.Status.State
-	if prevPJ.Status.State == state {
-		return nil, nil
-	}
-	nn := types.NamespacedName{Namespace: pj.Namespace, Name: pj.Name}
-	if err := wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, 2*time.Second, true, func(ctx context.Context) (bool, error) {
-		if err := r.pjClient.Get(ctx, nn, pj); err != nil {
-			return false, fmt.Errorf("failed to get prowjob: %w", err)
-		}
-		return pj.Status.State == state, nil
-	}); err != nil {
-		return nil, fmt.Errorf("failed to wait for cached prowjob %s to get into state %s: %w", nn.String(), state, err)
-	}
-
 	return nil, nil
 }
 
 type PodUnexpectedStopCause string
 
 const (
-	PodUnexpectedStopCauseNone        PodUnexpectedStopCause = ""
-	PodUnexpectedStopCauseUnknown     PodUnexpectedStopCause = "unknown"
-	PodUnexpectedStopCauseEvicted     PodUnexpectedStopCause = "evicted"
-	PodUnexpectedStopCauseOOMKilled   PodUnexpectedStopCause = "oomkilled"
-	PodUnexpectedStopCauseUnreachable PodUnexpectedStopCause = "unreachable"
+	PodUnexpectedStopCauseNone         PodUnexpectedStopCause = ""
+	PodUnexpectedStopCauseUnknown      PodUnexpectedStopCause = "unknown"
+	PodUnexpectedStopCauseEvicted      PodUnexpectedStopCause = "evicted"
+	PodUnexpectedStopCauseOOMKilled    PodUnexpectedStopCause = "oomkilled"
+	PodUnexpectedStopCauseUnreachable  PodUnexpectedStopCause = "unreachable"
+	PodUnexpectedStopCauseNodeShutdown PodUnexpectedStopCause = "node-shutdown"
 )
 
 func getPodUnexpectedStopCause(pod *corev1.Pod) PodUnexpectedStopCause {
@@ -687,27 +1208,50 @@ func getPodUnexpectedStopCause(pod *corev1.Pod) PodUnexpectedStopCause {
 		return PodUnexpectedStopCauseUnreachable
 	}
 
-	if pod.Status.Phase == corev1.PodRunning {
-		for _, container := range append(pod.Status.ContainerStatuses, pod.Status.InitContainerStatuses...) {
-			if container.State.Terminated != nil && container.State.Terminated.Reason == OOMKilled {
-				return PodUnexpectedStopCauseOOMKilled
-			}
-		}
+	if pod.Status.Phase == corev1.PodRunning && wasOOMKilled(pod) {
+		return PodUnexpectedStopCauseOOMKilled
 	}
 
 	if pod.Status.Phase == corev1.PodUnknown {
 		return PodUnexpectedStopCauseUnknown
 	}
 
+	if pod.Status.Phase == corev1.PodFailed && (pod.Status.Reason == Terminated || pod.Status.Reason == NodeShutdown) {
+		return PodUnexpectedStopCauseNodeShutdown
+	}
+
 	return PodUnexpectedStopCauseNone
 }
 
+// podUnexpectedStopCause returns why the pod of the job stopped unexpectedly.
+// A pod stopped by a node shutdown is only revived while the job has revivals
+// left, afterwards it is handled as-if it failed.
+func (r *reconciler) podUnexpectedStopCause(pj *prowv1.ProwJob, pod *corev1.Pod) PodUnexpectedStopCause {
+	cause := getPodUnexpectedStopCause(pod)
+	if cause == PodUnexpectedStopCauseNodeShutdown && pj.Status.PodRevivalCount >= r.maxRevivals(pj) {
+		return PodUnexpectedStopCauseNone
+	}
+	return cause
+}
+
+// maxRevivals returns how often the pod of the job may be revived.
+func (r *reconciler) maxRevivals(pj *prowv1.ProwJob) int {
+	if pj.Spec.DecorationConfig != nil && pj.Spec.DecorationConfig.MaxRevivals != nil {
+		return *pj.Spec.DecorationConfig.MaxRevivals
+	}
+	return *r.config().Plank.MaxRevivals
+}
+
 // syncTriggeredJob syncs jobs that do not yet have an associated test workload running
 func (r *reconciler) syncTriggeredJob(ctx context.Context, pj *prowv1.ProwJob) (*reconcile.Result, error) {
-	prevPJ := pj.DeepCopy()
-
 	var id, pn string
 
+	// Move the job away from its cluster before looking for its pod, as we
+	// may not have a client for it.
+	if err := r.failOverCluster(ctx, pj); err != nil {
+		return nil, fmt.Errorf("failOverCluster: %w", err)
+	}
+
 	pod, podExists, err := r.pod(ctx, pj)
 	if err != nil {
 		return nil, err
@@ -719,17 +1263,70 @@ func (r *reconciler) syncTriggeredJob(ctx context.Context, pj *prowv1.ProwJob) (
 	if podExists {
 		id = getPodBuildID(pod)
 		pn = pod.ObjectMeta.Name
+		r.recordPodQOSClass(pj, pod)
 	} else {
+		// Do not start the job before all of its dependencies succeeded.
+		dependenciesSucceeded, err := r.dependenciesSucceeded(ctx, pj)
+		if err != nil {
+			return nil, fmt.Errorf("dependenciesSucceeded: %w", err)
+		}
+		if pj.Complete() {
+			return nil, nil
+		}
+		if !dependenciesSucceeded {
+			r.reportStuckTriggered(pj)
+			return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+		// Do not start periodics again too soon after their previous run.
+		if err := r.enforcePeriodicMinInterval(ctx, pj); err != nil {
+			return nil, fmt.Errorf("enforcePeriodicMinInterval: %w", err)
+		}
+		if pj.Complete() {
+			return nil, nil
+		}
+		// Spread out the start of periodics that got triggered at once.
+		if delay := r.periodicStartDelay(pj); delay > 0 {
+			return &reconcile.Result{RequeueAfter: delay}, nil
+		}
+		// Only start pods while the build cluster accepts them.
+		if delay := r.podCreationWindowDelay(pj.ClusterAlias()); delay > 0 {
+			pj.Status.Description = fmt.Sprintf("Waiting for the next pod creation window of build cluster %s.", pj.ClusterAlias())
+			return &reconcile.Result{RequeueAfter: delay}, nil
+		}
 		// Do not start more jobs than specified and check again later.
 		canExecuteConcurrently, err := r.canExecuteConcurrently(ctx, pj)
 		if err != nil {
 			return nil, fmt.Errorf("canExecuteConcurrently: %w", err)
 		}
 		if !canExecuteConcurrently {
+			r.reportStuckTriggered(pj)
 			return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
 		}
+		// Do not try to start the pod on a cluster that is unreachable.
+		if !r.isClusterHealthy(ctx, pj.ClusterAlias()) {
+			pj.Status.Description = fmt.Sprintf("Waiting for build cluster %s to become reachable.", pj.ClusterAlias())
+			r.reportStuckTriggered(pj)
+			return &reconcile.Result{RequeueAfter: r.config().Plank.ClusterHealthCheckInterval.Duration}, nil
+		}
+		// Take the snapshot before starting the pod, so that it reflects
+		// what the job got admitted against.
+		var snapshot string
+		if r.config().Plank.RecordAdmissionSnapshot {
+			if snapshot, err = r.admissionSnapshot(ctx, pj); err != nil {
+				return nil, fmt.Errorf("admissionSnapshot: %w", err)
+			}
+		}
 		// We haven't started the pod yet. Do so.
 		id, pn, err = r.startPod(ctx, pj)
+		if err == nil && snapshot != "" {
+			if pj.Annotations == nil {
+				pj.Annotations = map[string]string{}
+			}
+			pj.Annotations[kube.AdmissionSnapshotAnnotation] = snapshot
+		}
+		if err == nil {
+			r.recordConcurrencyWait(pj)
+		}
 		if err != nil {
 			if !isRequestError(err) {
 				return nil, fmt.Errorf("error starting pod: %w", err)
@@ -737,6 +1334,7 @@ func (r *reconciler) syncTriggeredJob(ctx context.Context, pj *prowv1.ProwJob) (
 			pj.Status.State = prowv1.ErrorState
 			pj.SetComplete()
 			pj.Status.Description = fmt.Sprintf("Pod can not be created: %v", err)
+			setErrorReason(pj, kube.ErrorReasonPodCreationFailed)
 			logrus.WithField("job", pj.Spec.Job).WithError(err).Warning("Unprocessable pod.")
 		}
 	}
@@ -755,37 +1353,378 @@ func (r *reconciler) syncTriggeredJob(ctx context.Context, pj *prowv1.ProwJob) (
 		}
 	}
 
-	if prevPJ.Status.State != pj.Status.State {
-		r.log.WithFields(pjutil.ProwJobFields(pj)).
-			WithField("from", prevPJ.Status.State).
-			WithField("to", pj.Status.State).Info("Transitioning states.")
+	return nil, nil
+}
+
+// reportStuckTriggered reports pj if it has been waiting to be started for
+// longer than the configured threshold. Every job is only reported once.
+func (r *reconciler) reportStuckTriggered(pj *prowv1.ProwJob) {
+	threshold := r.config().Plank.StuckTriggeredThreshold
+	if threshold == nil || threshold.Duration <= 0 || r.clock.Since(pj.Status.StartTime.Time) < threshold.Duration {
+		return
 	}
-	if err := r.pjClient.Patch(ctx, pj.DeepCopy(), ctrlruntimeclient.MergeFrom(prevPJ)); err != nil {
-		return nil, fmt.Errorf("patch prowjob: %w", err)
+	if pj.Annotations[kube.StuckTriggeredAnnotation] == "true" {
+		return
 	}
+	plankMetrics.stuckTriggered.WithLabelValues(pj.Spec.Job).Inc()
+	r.log.WithFields(pjutil.ProwJobFields(pj)).WithField("threshold", threshold.Duration).Warn("Job is stuck triggered without a pod, check its dependencies, concurrency limits and build cluster.")
+	if pj.Annotations == nil {
+		pj.Annotations = map[string]string{}
+	}
+	pj.Annotations[kube.StuckTriggeredAnnotation] = "true"
+}
 
-	// If the job has either MaxConcurrency or JobQueueName configured, we must block here until we observe the state transition in our cache,
-	// otherwise subequent reconciliations for a different run of the same job might incorrectly conclude that they
-	// can run because that decision is made based on the data in the cache.
-	if pj.Spec.MaxConcurrency == 0 && pj.Spec.JobQueueName == "" {
-		return nil, nil
+// failOverCluster moves the given job to the first usable failover cluster of
+// its cluster if its cluster is draining or we have no client for it, unless
+// its pod already got created.
+func (r *reconciler) failOverCluster(ctx context.Context, pj *prowv1.ProwJob) error {
+	cluster := pj.ClusterAlias()
+	failovers := r.config().Plank.ClusterFailover[cluster]
+	if len(failovers) == 0 || r.isClusterUsable(cluster) {
+		return nil
+	}
+
+	// The pod may have been created before the cluster started draining.
+	if _, ok := r.buildClients[cluster]; ok {
+		_, podExists, err := r.pod(ctx, pj)
+		if err != nil || podExists {
+			return err
+		}
 	}
-	nn := types.NamespacedName{Namespace: pj.Namespace, Name: pj.Name}
-	state := pj.Status.State
-	if err := wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, 2*time.Second, true, func(ctx context.Context) (bool, error) {
-		if err := r.pjClient.Get(ctx, nn, pj); err != nil {
-			return false, fmt.Errorf("failed to get prowjob: %w", err)
+
+	for _, failover := range failovers {
+		if r.isClusterUsable(failover) {
+			r.log.WithFields(pjutil.ProwJobFields(pj)).WithField("failover-cluster", failover).Info("Moving job to failover cluster.")
+			pj.Spec.Cluster = failover
+			return nil
 		}
-		return pj.Status.State == state, nil
-	}); err != nil {
-		return nil, fmt.Errorf("failed to wait for cached prowjob %s to get into state %s: %w", nn.String(), state, err)
 	}
+	r.log.WithFields(pjutil.ProwJobFields(pj)).Warn("None of the failover clusters is usable, keeping the job on its cluster.")
+	return nil
+}
 
-	return nil, nil
+// ClusterHealth returns the result of the last health probe of every build
+// cluster that got probed.
+func (r *reconciler) ClusterHealth() map[string]ClusterHealth {
+	r.clusterHealth.lock.Lock()
+	defer r.clusterHealth.lock.Unlock()
+	return maps.Clone(r.clusterHealth.health)
 }
 
-// syncAbortedJob syncs jobs that got aborted because their result isn't needed anymore,
-// for example because of a new push or because a pull request got closed.
+// isClusterHealthy returns whether the given build cluster answered its last
+// health probe, probing it again if the last result is older than the health
+// check interval. Clusters are always considered healthy if the probes are
+// disabled.
+func (r *reconciler) isClusterHealthy(ctx context.Context, cluster string) bool {
+	interval := r.config().Plank.ClusterHealthCheckInterval
+	if interval == nil || interval.Duration <= 0 {
+		return true
+	}
+	client, ok := r.buildClients[cluster]
+	if !ok {
+		return true
+	}
+	if health, ok := r.clusterHealth.get(cluster); ok && r.clock.Since(health.LastProbeTime) < interval.Duration {
+		return health.Healthy
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, clusterHealthProbeTimeout)
+	defer cancel()
+	health := ClusterHealth{Healthy: true, LastProbeTime: r.clock.Now()}
+	if err := client.reader().List(probeCtx, &corev1.PodList{}, ctrlruntimeclient.InNamespace(r.config().PodNamespace), ctrlruntimeclient.Limit(1)); err != nil {
+		health.Healthy = false
+		health.Error = err.Error()
+		r.log.WithError(err).WithField("cluster", cluster).Warn("Build cluster failed its health probe.")
+	}
+	r.clusterHealth.set(cluster, health)
+	return health.Healthy
+}
+
+// isClusterUsable returns whether new pods can be started on the given cluster.
+func (r *reconciler) isClusterUsable(cluster string) bool {
+	if _, ok := r.buildClients[cluster]; !ok {
+		return false
+	}
+	return !slices.Contains(r.config().Plank.DrainingClusters, cluster)
+}
+
+// recordConcurrencyWait records the time the given job waited from its
+// creation until it got started.
+func (r *reconciler) recordConcurrencyWait(pj *prowv1.ProwJob) {
+	if pj.Status.StartTime.IsZero() {
+		return
+	}
+	wait := r.clock.Since(pj.Status.StartTime.Time)
+	plankMetrics.concurrencyWait.WithLabelValues(pj.Spec.Job, pj.Spec.JobQueueName).Observe(wait.Seconds())
+	if r.config().Plank.RecordConcurrencyWait {
+		if pj.Annotations == nil {
+			pj.Annotations = map[string]string{}
+		}
+		pj.Annotations[kube.ConcurrencyWaitAnnotation] = wait.Round(time.Second).String()
+	}
+}
+
+// dependenciesSucceeded returns true if all the jobs the given job depends on
+// succeeded. If any of them finished without succeeding, the job is errored.
+func (r *reconciler) dependenciesSucceeded(ctx context.Context, pj *prowv1.ProwJob) (bool, error) {
+	for _, name := range pj.Spec.DependsOn {
+		dependency := &prowv1.ProwJob{}
+		if err := r.pjClient.Get(ctx, types.NamespacedName{Namespace: r.config().ProwJobNamespace, Name: name}, dependency); err != nil {
+			if kerrors.IsNotFound(err) {
+				r.log.WithFields(pjutil.ProwJobFields(pj)).WithField("dependency", name).Debug("Dependency not found, waiting for it to show up.")
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to get dependency %s: %w", name, err)
+		}
+		if !dependency.Complete() {
+			return false, nil
+		}
+		if dependency.Status.State != prowv1.SuccessState {
+			pj.Status.State = prowv1.ErrorState
+			pj.SetComplete()
+			pj.Status.Description = fmt.Sprintf("Dependency %s did not succeed: %s.", name, dependency.Status.State)
+			r.log.WithFields(pjutil.ProwJobFields(pj)).WithField("dependency", name).Info("Dependency did not succeed, erroring job.")
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// enforcePeriodicMinInterval aborts the given periodic if a previous run of it
+// was started less than its configured minimum interval before it got created.
+func (r *reconciler) enforcePeriodicMinInterval(ctx context.Context, pj *prowv1.ProwJob) error {
+	if pj.Spec.Type != prowv1.PeriodicJob {
+		return nil
+	}
+	minInterval := r.config().Plank.PeriodicMinIntervals[pj.Spec.Job]
+	if minInterval == nil || minInterval.Duration <= 0 {
+		return nil
+	}
+
+	pjs := &prowv1.ProwJobList{}
+	if err := r.pjClient.List(ctx, pjs, optPeriodicsNamed(pj.Spec.Job)); err != nil {
+		return fmt.Errorf("failed listing prowjobs: %w", err)
+	}
+	for _, previous := range pjs.Items {
+		// Only runs that actually got started count, so that runs skipped
+		// here don't push back the next run.
+		if previous.Name == pj.Name || previous.Status.PendingTime == nil || previous.Status.StartTime.After(pj.Status.StartTime.Time) {
+			continue
+		}
+		if sinceLast := pj.Status.StartTime.Sub(previous.Status.StartTime.Time); sinceLast < minInterval.Duration {
+			pj.SetComplete()
+			pj.Status.State = prowv1.AbortedState
+			pj.Status.Description = fmt.Sprintf("Skipped, previous run %s started %s earlier, which is less than the minimum interval of %s.", previous.Name, sinceLast, minInterval.Duration)
+			r.log.WithFields(pjutil.ProwJobFields(pj)).WithField("previous", previous.Name).Info("Skipping periodic started too soon after its previous run.")
+			return nil
+		}
+	}
+	return nil
+}
+
+// revivalAttempt holds what is known about a pod that got stopped
+// unexpectedly and was deleted to revive the job.
+type revivalAttempt struct {
+	// Attempt is the revival count of the job when the pod was running.
+	Attempt int `json:"attempt"`
+	// PodName is the name of the pod.
+	PodName string `json:"pod_name"`
+	// Cause is the reason the pod was considered to be stopped unexpectedly.
+	Cause PodUnexpectedStopCause `json:"cause"`
+	// Reason and Message are taken from the pod status.
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Containers holds the state of the containers of the pod.
+	Containers []revivalAttemptContainer `json:"containers,omitempty"`
+}
+
+// revivalAttemptContainer holds the state of a container of a revived pod.
+type revivalAttemptContainer struct {
+	Name     string `json:"name"`
+	State    string `json:"state"`
+	Reason   string `json:"reason,omitempty"`
+	Message  string `json:"message,omitempty"`
+	ExitCode *int32 `json:"exit_code,omitempty"`
+}
+
+// revivalBackoff returns how long to wait before recreating the pod of a job
+// after its revivalCount-th revival. It doubles with every revival, starting
+// at the configured base, up to the configured maximum.
+func (r *reconciler) revivalBackoff(revivalCount int) time.Duration {
+	base, maxBackoff := r.config().Plank.RevivalBackoffBase, r.config().Plank.RevivalBackoffMax
+	if base == nil || base.Duration <= 0 || revivalCount <= 0 {
+		return 0
+	}
+	backoff := base.Duration
+	for i := 1; i < revivalCount; i++ {
+		if (maxBackoff != nil && backoff >= maxBackoff.Duration) || backoff > math.MaxInt64/2 {
+			break
+		}
+		backoff *= 2
+	}
+	if maxBackoff != nil && backoff > maxBackoff.Duration {
+		backoff = maxBackoff.Duration
+	}
+	return backoff
+}
+
+// revivalDelay returns how much longer to wait before recreating the pod of a
+// revived job. Reviving the job is the last change of its status until the new
+// pod is created, so the wait is measured from the last reconcile time.
+func (r *reconciler) revivalDelay(pj *prowv1.ProwJob) time.Duration {
+	if pj.Status.PodRevivalCount == 0 || pj.Status.LastReconcileTime == nil {
+		return 0
+	}
+	return r.revivalBackoff(pj.Status.PodRevivalCount) - r.clock.Since(pj.Status.LastReconcileTime.Time)
+}
+
+// jitterRequeue stretches the requeue of the given result by a random factor
+// of up to the configured requeue jitter factor.
+func (r *reconciler) jitterRequeue(res *reconcile.Result) *reconcile.Result {
+	factor := r.config().Plank.RequeueJitterFactor
+	if res == nil || res.RequeueAfter <= 0 || factor <= 0 {
+		return res
+	}
+	randFloat64 := r.randFloat64
+	if randFloat64 == nil {
+		randFloat64 = rand.Float64
+	}
+	res.RequeueAfter += time.Duration(float64(res.RequeueAfter) * factor * randFloat64())
+	return res
+}
+
+// podCreationWindowDelay returns how long it takes until pods may be created
+// on cluster, which is 0 if any of its pod creation windows is open or it has
+// none.
+func (r *reconciler) podCreationWindowDelay(cluster string) time.Duration {
+	windows := r.config().Plank.PodCreationWindows[cluster]
+	if len(windows) == 0 {
+		return 0
+	}
+	now := r.clock.Now()
+	var delay time.Duration
+	for _, window := range windows {
+		d, err := window.Delay(now)
+		if err != nil {
+			// The config is validated, so this is not expected to happen.
+			r.log.WithField("cluster", cluster).WithError(err).Warn("Ignoring invalid pod creation window.")
+			continue
+		}
+		if d == 0 {
+			return 0
+		}
+		if delay == 0 || d < delay {
+			delay = d
+		}
+	}
+	return delay
+}
+
+// cacheWarmupDelay returns how much longer the caches of the reconciler may be
+// warming up after its start.
+func (r *reconciler) cacheWarmupDelay() time.Duration {
+	period := r.config().Plank.CacheWarmupPeriod
+	if period == nil || r.startTime.IsZero() {
+		return 0
+	}
+	return period.Duration - r.clock.Since(r.startTime)
+}
+
+// recordRevivalAttempt appends the status of the pod to the revival attempts
+// annotation of the job.
+func recordRevivalAttempt(pj *prowv1.ProwJob, pod *corev1.Pod, cause PodUnexpectedStopCause) error {
+	var attempts []revivalAttempt
+	if raw, ok := pj.Annotations[kube.RevivalAttemptsAnnotation]; ok {
+		if err := json.Unmarshal([]byte(raw), &attempts); err != nil {
+			return fmt.Errorf("failed to unmarshal revival attempts %q: %w", raw, err)
+		}
+	}
+
+	attempt := revivalAttempt{
+		Attempt: pj.Status.PodRevivalCount,
+		PodName: pod.Name,
+		Cause:   cause,
+		Reason:  pod.Status.Reason,
+		Message: pod.Status.Message,
+	}
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		container := revivalAttemptContainer{Name: status.Name}
+		switch {
+		case status.State.Terminated != nil:
+			container.State = "terminated"
+			container.Reason = status.State.Terminated.Reason
+			container.Message = status.State.Terminated.Message
+			container.ExitCode = &status.State.Terminated.ExitCode
+		case status.State.Running != nil:
+			container.State = "running"
+		case status.State.Waiting != nil:
+			container.State = "waiting"
+			container.Reason = status.State.Waiting.Reason
+			container.Message = status.State.Waiting.Message
+		default:
+			container.State = "unknown"
+		}
+		attempt.Containers = append(attempt.Containers, container)
+	}
+	attempts = append(attempts, attempt)
+
+	b, err := json.Marshal(attempts)
+	if err != nil {
+		return fmt.Errorf("failed to marshal revival attempts: %w", err)
+	}
+	if pj.Annotations == nil {
+		pj.Annotations = map[string]string{}
+	}
+	pj.Annotations[kube.RevivalAttemptsAnnotation] = string(b)
+	return nil
+}
+
+// admissionSnapshot holds the number of pending jobs at the time a job got
+// admitted.
+type admissionSnapshot struct {
+	// Pending is the number of all pending jobs.
+	Pending int `json:"pending"`
+	// JobPending is the number of pending runs of the same job.
+	JobPending int `json:"job_pending"`
+	// QueuePending is the number of pending jobs in the job queue of the job.
+	QueuePending int `json:"queue_pending,omitempty"`
+	// ClusterPending is the number of pending jobs in the build cluster of the job.
+	ClusterPending int `json:"cluster_pending"`
+}
+
+// admissionSnapshot returns the JSON encoded admission snapshot of the job.
+func (r *reconciler) admissionSnapshot(ctx context.Context, pj *prowv1.ProwJob) (string, error) {
+	pjs := &prowv1.ProwJobList{}
+	if err := r.pjClient.List(ctx, pjs, optPendingProwJobs()); err != nil {
+		return "", fmt.Errorf("failed to list prowjobs: %w", err)
+	}
+
+	var snapshot admissionSnapshot
+	for _, pending := range pjs.Items {
+		if pending.Name == pj.Name {
+			continue
+		}
+		snapshot.Pending++
+		if pending.Spec.Job == pj.Spec.Job {
+			snapshot.JobPending++
+		}
+		if pj.Spec.JobQueueName != "" && pending.Spec.JobQueueName == pj.Spec.JobQueueName {
+			snapshot.QueuePending++
+		}
+		if pending.ClusterAlias() == pj.ClusterAlias() {
+			snapshot.ClusterPending++
+		}
+	}
+
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal admission snapshot: %w", err)
+	}
+	return string(b), nil
+}
+
+// syncAbortedJob syncs jobs that got aborted because their result isn't needed anymore,
+// for example because of a new push or because a pull request got closed.
 func (r *reconciler) syncAbortedJob(ctx context.Context, pj *prowv1.ProwJob) error {
 
 	buildClient, ok := r.buildClients[pj.ClusterAlias()]
@@ -798,13 +1737,19 @@ func (r *reconciler) syncAbortedJob(ctx context.Context, pj *prowv1.ProwJob) err
 		Name:      pj.Name,
 		Namespace: r.config().PodNamespace,
 	}}
-	if err := ctrlruntimeclient.IgnoreNotFound(buildClient.Delete(ctx, pod)); err != nil {
+	var opts []ctrlruntimeclient.DeleteOption
+	if gracePeriod := r.config().Plank.AbortGracePeriodSeconds; gracePeriod != nil {
+		opts = append(opts, ctrlruntimeclient.GracePeriodSeconds(*gracePeriod))
+	}
+	if err := r.removeExtraPodFinalizers(ctx, buildClient, pod); err != nil {
+		return err
+	}
+	if err := ctrlruntimeclient.IgnoreNotFound(buildClient.Delete(ctx, pod, opts...)); err != nil {
 		return fmt.Errorf("failed to delete pod %s/%s in cluster %s: %w", pod.Namespace, pod.Name, pj.ClusterAlias(), err)
 	}
 
-	originalPJ := pj.DeepCopy()
 	pj.SetComplete()
-	return r.pjClient.Patch(ctx, pj, ctrlruntimeclient.MergeFrom(originalPJ))
+	return nil
 }
 
 // pod Gets pod for a pj, returns pod, whether pod exist, and error.
@@ -830,6 +1775,32 @@ func (r *reconciler) pod(ctx context.Context, pj *prowv1.ProwJob) (*corev1.Pod,
 	return pod, true, nil
 }
 
+// handleStuckTerminatingPod reports the pod of pj if it has been terminating
+// for longer than the configured timeout and, if configured to, removes the
+// finalizers plank knows about from it so that it can go away.
+func (r *reconciler) handleStuckTerminatingPod(ctx context.Context, pj *prowv1.ProwJob, pod *corev1.Pod) error {
+	timeout := r.config().Plank.StuckTerminatingTimeout
+	if timeout == nil || r.clock.Since(pod.DeletionTimestamp.Time) < timeout.Duration {
+		return nil
+	}
+	plankMetrics.podsStuckTerminating.WithLabelValues(pj.ClusterAlias()).Inc()
+	r.log.WithFields(pjutil.ProwJobFields(pj)).WithField("finalizers", pod.Finalizers).Warn("Pod is stuck terminating.")
+
+	finalizers := r.podFinalizers()
+	if !r.config().Plank.RemoveStuckFinalizers || !sets.New(pod.Finalizers...).HasAny(finalizers.UnsortedList()...) {
+		return nil
+	}
+	client, ok := r.buildClients[pj.ClusterAlias()]
+	if !ok {
+		return TerminalError(fmt.Errorf("no build client found for cluster %q", pj.ClusterAlias()))
+	}
+	if err := removePodFinalizers(ctx, client, pod, finalizers); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return err
+	}
+	r.log.WithFields(pjutil.ProwJobFields(pj)).Info("Removed the finalizers from the pod stuck terminating.")
+	return nil
+}
+
 func (r *reconciler) deletePod(ctx context.Context, pj *prowv1.ProwJob) error {
 	buildClient, buildClientExists := r.buildClients[pj.ClusterAlias()]
 	if !buildClientExists {
@@ -843,6 +1814,9 @@ func (r *reconciler) deletePod(ctx context.Context, pj *prowv1.ProwJob) error {
 		},
 	}
 
+	if err := r.removeExtraPodFinalizers(ctx, buildClient, pod); err != nil {
+		return err
+	}
 	if err := ctrlruntimeclient.IgnoreNotFound(buildClient.Delete(ctx, pod)); err != nil {
 		return fmt.Errorf("failed to delete pod: %w", err)
 	}
@@ -851,6 +1825,112 @@ func (r *reconciler) deletePod(ctx context.Context, pj *prowv1.ProwJob) error {
 	return nil
 }
 
+// podFinalizers returns the finalizers plank removes from pods before deleting
+// them, which are the ones owned by Prow and the configured extra ones.
+func (r *reconciler) podFinalizers() sets.Set[string] {
+	return sets.New(prowPodFinalizers...).Insert(r.config().Plank.ExtraPodFinalizers...)
+}
+
+// removeExtraPodFinalizers removes the configured extra finalizers from the
+// current version of pod, so that deleting it doesn't hang on them. The
+// finalizers owned by Prow are left alone so that crier can still report
+// the pod.
+func (r *reconciler) removeExtraPodFinalizers(ctx context.Context, client ctrlruntimeclient.Client, pod *corev1.Pod) error {
+	extraFinalizers := r.config().Plank.ExtraPodFinalizers
+	if len(extraFinalizers) == 0 {
+		return nil
+	}
+	current := &corev1.Pod{}
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(pod), current); err != nil {
+		return ctrlruntimeclient.IgnoreNotFound(err)
+	}
+	return ctrlruntimeclient.IgnoreNotFound(removePodFinalizers(ctx, client, current, sets.New(extraFinalizers...)))
+}
+
+// removePodFinalizers patches the given finalizers out of pod, if it has any
+// of them.
+func removePodFinalizers(ctx context.Context, client ctrlruntimeclient.Client, pod *corev1.Pod, finalizers sets.Set[string]) error {
+	podFinalizers := sets.New(pod.Finalizers...)
+	if !podFinalizers.HasAny(finalizers.UnsortedList()...) {
+		return nil
+	}
+	oldPod := pod.DeepCopy()
+	pod.Finalizers = podFinalizers.Difference(finalizers).UnsortedList()
+	if err := client.Patch(ctx, pod, ctrlruntimeclient.MergeFrom(oldPod)); err != nil {
+		return fmt.Errorf("failed to patch pod trying to remove finalizers %v: %w", sets.List(podFinalizers.Intersection(finalizers)), err)
+	}
+	return nil
+}
+
+// periodicStartDelay returns how much longer the start of pj should be delayed
+// to spread out periodics that got triggered at the same time. The jitter is
+// derived from the name of the job, so that it stays the same across
+// reconciles.
+func (r *reconciler) periodicStartDelay(pj *prowv1.ProwJob) time.Duration {
+	jitter := r.config().Plank.PeriodicStartJitter
+	if pj.Spec.Type != prowv1.PeriodicJob || jitter == nil || jitter.Duration <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(pj.Name))
+	delay := time.Duration(h.Sum64() % uint64(jitter.Duration))
+	return delay - r.clock.Since(pj.Status.StartTime.Time)
+}
+
+// isMissingDecorationDefaults returns whether pj is decorated, but its resolved
+// decoration config has nowhere to upload the logs and artifacts to. This
+// usually means that no decoration defaults apply to the cluster of the job.
+func isMissingDecorationDefaults(pj *prowv1.ProwJob) bool {
+	dc := pj.Spec.DecorationConfig
+	if dc == nil {
+		return false
+	}
+	return dc.GCSConfiguration == nil || (dc.GCSConfiguration.Bucket == "" && dc.GCSConfiguration.LocalOutputDir == "")
+}
+
+// setMaxLogBytes limits the output the entrypoint of each container of pod
+// writes to maxLogBytes. Containers not wrapped by the entrypoint are left
+// alone.
+func setMaxLogBytes(pod *corev1.Pod, maxLogBytes int64) {
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		if !slices.ContainsFunc(container.Env, func(env corev1.EnvVar) bool { return env.Name == entrypoint.JSONConfigEnvVar }) {
+			continue
+		}
+		container.Env = append(container.Env, corev1.EnvVar{Name: entrypoint.MaxLogBytesEnvVar, Value: strconv.FormatInt(maxLogBytes, 10)})
+	}
+}
+
+// normalizeImagePullPolicies rewrites the image pull policy of each container
+// of pod according to rules.
+func normalizeImagePullPolicies(pod *corev1.Pod, rules *config.ImagePullPolicyRules) {
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i := range containers {
+			container := &containers[i]
+			switch {
+			case strings.Contains(container.Image, "@"):
+				if rules.Digest != "" {
+					container.ImagePullPolicy = rules.Digest
+				}
+			case hasLatestImageTag(container.Image):
+				if rules.Latest != "" {
+					container.ImagePullPolicy = rules.Latest
+				}
+			}
+		}
+	}
+}
+
+// hasLatestImageTag returns whether image has the latest tag, which is also
+// the case if it has no tag at all.
+func hasLatestImageTag(image string) bool {
+	// The registry host may have a port, so only the last path component
+	// can carry the tag.
+	name := image[strings.LastIndex(image, "/")+1:]
+	_, tag, found := strings.Cut(name, ":")
+	return !found || tag == "latest"
+}
+
 func (r *reconciler) startPod(ctx context.Context, pj *prowv1.ProwJob) (string, string, error) {
 	buildID, err := r.getBuildID(pj.Spec.Job)
 	if err != nil {
@@ -858,24 +1938,55 @@ func (r *reconciler) startPod(ctx context.Context, pj *prowv1.ProwJob) (string,
 	}
 
 	pj.Status.BuildID = buildID
-	pod, err := decorate.ProwJobToPod(*pj)
-	if err != nil {
-		return "", "", err
+	if isMissingDecorationDefaults(pj) {
+		plankMetrics.missingDecorationDefaults.WithLabelValues(pj.ClusterAlias()).Inc()
+		r.log.WithFields(pjutil.ProwJobFields(pj)).WithField("cluster", pj.ClusterAlias()).Warn("Decorated job has no GCS configuration, its artifacts will not be uploaded. Check the decoration defaults of the cluster.")
 	}
-	pod.Namespace = r.config().PodNamespace
-	// Add prow version as a label for better debugging prowjobs.
-	pod.ObjectMeta.Labels[kube.PlankVersionLabel] = version.Version
-	podName := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
-
 	client, ok := r.buildClients[pj.ClusterAlias()]
 	if !ok {
 		return "", "", TerminalError(fmt.Errorf("unknown cluster alias %q", pj.ClusterAlias()))
 	}
-	err = client.Create(ctx, pod)
-	r.log.WithFields(pjutil.ProwJobFields(pj)).Debug("Create Pod.")
+	pod, err := r.createPod(ctx, client, pj)
+	if err != nil && r.config().Plank.RetryPodCreateConflicts {
+		switch {
+		case kerrors.IsAlreadyExists(err):
+			// The pod is named after the ProwJob, so retrying would fail
+			// again. The pod is most likely the one of this job, created by
+			// an earlier reconciliation the cache has not caught up with yet.
+			existing, getErr := r.getExistingPod(ctx, client, pj)
+			if getErr != nil {
+				r.log.WithFields(pjutil.ProwJobFields(pj)).WithError(getErr).Info("Pod already exists and cannot be adopted.")
+				break
+			}
+			r.log.WithFields(pjutil.ProwJobFields(pj)).Info("Pod of the job already exists, adopting it.")
+			pod, err = existing, nil
+			buildID = existing.Labels[kube.ProwBuildIDLabel]
+			pj.Status.BuildID = buildID
+		case kerrors.IsConflict(err):
+			// Conflicts are often transient, so retry once with a new build
+			// ID. The pod keeps its name, as that is how the pod of a ProwJob
+			// is looked up.
+			r.log.WithFields(pjutil.ProwJobFields(pj)).WithError(err).Info("Creating pod conflicted, retrying with a new build ID.")
+			if buildID, err = r.getBuildID(pj.Spec.Job); err != nil {
+				return "", "", fmt.Errorf("error getting build ID: %w", err)
+			}
+			pj.Status.BuildID = buildID
+			pod, err = r.createPod(ctx, client, pj)
+		}
+	}
 	if err != nil {
-		return "", "", fmt.Errorf("create pod %s in cluster %s: %w", podName.String(), pj.ClusterAlias(), err)
+		return "", "", err
+	}
+	verifyNamespace := r.config().Plank.VerifyPodNamespace
+	if expected := r.config().PodNamespace; verifyNamespace && pod.Namespace != expected {
+		// Plank only ever looks for the pod in the pod namespace, so it would
+		// never find this one. Don't leave it running in the wrong place.
+		if err := client.Delete(ctx, pod); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			r.log.WithFields(pjutil.ProwJobFields(pj)).WithError(err).Warn("Failed to delete pod created in the wrong namespace.")
+		}
+		return "", "", kerrors.NewBadRequest(fmt.Sprintf("pod %s was created in namespace %q instead of the pod namespace %q in cluster %s, check the namespace routing", pod.Name, pod.Namespace, expected, pj.ClusterAlias()))
 	}
+	podName := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
 
 	// We must block until we see the pod, otherwise a new reconciliation may be triggered that tries to create
 	// the pod because its not in the cache yet, errors with IsAlreadyExists and sets the prowjob to failed
@@ -888,12 +1999,261 @@ func (r *reconciler) startPod(ctx context.Context, pj *prowv1.ProwJob) (string,
 		}
 		return true, nil
 	}); err != nil {
+		if verifyNamespace && wait.Interrupted(err) && ctx.Err() == nil {
+			return "", "", kerrors.NewBadRequest(fmt.Sprintf("pod %s did not show up in namespace %q in cluster %s after it got created, check the namespace routing", podName.Name, podName.Namespace, pj.ClusterAlias()))
+		}
 		return "", "", fmt.Errorf("failed waiting for new pod %s in cluster %s  appear in cache: %w", podName.String(), pj.ClusterAlias(), err)
 	}
+	r.recordPodQOSClass(pj, pod)
 
 	return buildID, pod.Name, nil
 }
 
+// getExistingPod returns the pod of pj from the API server of the build cluster
+// of client, if there is one and it was created for pj.
+func (r *reconciler) getExistingPod(ctx context.Context, client buildClient, pj *prowv1.ProwJob) (*corev1.Pod, error) {
+	pod := &corev1.Pod{}
+	if err := client.reader().Get(ctx, types.NamespacedName{Namespace: r.config().PodNamespace, Name: pj.Name}, pod); err != nil {
+		return nil, fmt.Errorf("failed to get pod %s: %w", pj.Name, err)
+	}
+	if owner := pod.Labels[kube.ProwJobIDLabel]; owner != pj.Name {
+		return nil, fmt.Errorf("pod %s belongs to prowjob %q", pod.Name, owner)
+	}
+	if pod.Labels[kube.ProwBuildIDLabel] == "" {
+		return nil, fmt.Errorf("pod %s has no build ID", pod.Name)
+	}
+	return pod, nil
+}
+
+// createPod creates the pod for pj in the build cluster of client.
+func (r *reconciler) createPod(ctx context.Context, client buildClient, pj *prowv1.ProwJob) (*corev1.Pod, error) {
+	pod, err := decorate.ProwJobToPod(*pj)
+	if err != nil {
+		return nil, err
+	}
+	pod.Namespace = r.config().PodNamespace
+	// Add prow version as a label for better debugging prowjobs.
+	pod.ObjectMeta.Labels[kube.PlankVersionLabel] = version.Version
+	if maxLogBytes := r.config().Plank.MaxJobLogBytes; maxLogBytes > 0 {
+		setMaxLogBytes(pod, maxLogBytes)
+	}
+	if rules := r.config().Plank.ImagePullPolicyRules; rules != nil {
+		normalizeImagePullPolicies(pod, rules)
+	}
+	podName := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+
+	if r.config().Plank.ValidatePodReferences {
+		if err := validatePodReferences(ctx, client.reader(), pod); err != nil {
+			return nil, err
+		}
+	}
+	err = client.Create(ctx, pod)
+	r.log.WithFields(pjutil.ProwJobFields(pj)).Debug("Create Pod.")
+	if err != nil {
+		return nil, fmt.Errorf("create pod %s in cluster %s: %w", podName.String(), pj.ClusterAlias(), err)
+	}
+	return pod, nil
+}
+
+// recordPodQOSClass records the quality of service class of the given pod on
+// the job, if configured to.
+func (r *reconciler) recordPodQOSClass(pj *prowv1.ProwJob, pod *corev1.Pod) {
+	if !r.config().Plank.RecordPodQOSClass {
+		return
+	}
+	pj.Status.PodQOSClass = podQOSClass(pod)
+}
+
+// recordPodNode records the node the given pod runs on and the zone of the
+// node on the job, if configured to. Failing to look up the zone is not fatal,
+// the job is only left without zone then. Nodes are cluster-scoped and thus not
+// in the cache of the build client, so they are read from the API directly,
+// once per job.
+func (r *reconciler) recordPodNode(ctx context.Context, pj *prowv1.ProwJob, pod *corev1.Pod) {
+	if !r.config().Plank.RecordPodNode || pod.Spec.NodeName == "" || pj.Status.NodeName == pod.Spec.NodeName {
+		return
+	}
+	pj.Status.NodeName = pod.Spec.NodeName
+
+	client, ok := r.buildClients[pj.ClusterAlias()]
+	if !ok {
+		return
+	}
+	node := &corev1.Node{}
+	if err := client.reader().Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+		r.log.WithFields(pjutil.ProwJobFields(pj)).WithError(err).WithField("node", pod.Spec.NodeName).Warn("Failed to get node of pod, not recording its zone.")
+		return
+	}
+	pj.Status.NodeZone = node.Labels[corev1.LabelTopologyZone]
+	if pj.Status.NodeZone == "" {
+		pj.Status.NodeZone = node.Labels[corev1.LabelFailureDomainBetaZone]
+	}
+}
+
+// recordPodScheduledTime records when the pod of pj got scheduled, once its
+// PodScheduled condition is true. The time of the transition of the condition
+// is used if it is known, the current time otherwise.
+func (r *reconciler) recordPodScheduledTime(pj *prowv1.ProwJob, pod *corev1.Pod) {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != corev1.PodScheduled || condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		scheduledTime := condition.LastTransitionTime
+		if scheduledTime.IsZero() {
+			scheduledTime = metav1.NewTime(r.clock.Now())
+		}
+		pj.Status.PodScheduledTime = &scheduledTime
+		return
+	}
+}
+
+// repairedPodLabels are the labels of pods that are restored from their
+// ProwJob if they drifted.
+var repairedPodLabels = []string{kube.ProwJobAnnotation, kube.ProwJobTypeLabel, kube.ProwJobIDLabel, kube.ProwBuildIDLabel}
+
+// repairPodLabels patches the labels of pod that identify its job back to the
+// values derived from pj, if they differ.
+func (r *reconciler) repairPodLabels(ctx context.Context, pj *prowv1.ProwJob, pod *corev1.Pod) error {
+	client, ok := r.buildClients[pj.ClusterAlias()]
+	if !ok {
+		return nil
+	}
+	expected, _ := decorate.LabelsAndAnnotationsForJob(*pj.DeepCopy())
+	oldPod := pod.DeepCopy()
+	for _, label := range repairedPodLabels {
+		value, ok := expected[label]
+		if !ok || value == "" || pod.Labels[label] == value {
+			continue
+		}
+		r.log.WithFields(pjutil.ProwJobFields(pj)).WithFields(logrus.Fields{
+			"label":    label,
+			"actual":   pod.Labels[label],
+			"expected": value,
+		}).Warn("Pod label drifted from its ProwJob, repairing it.")
+		if pod.Labels == nil {
+			pod.Labels = map[string]string{}
+		}
+		pod.Labels[label] = value
+	}
+	if equality.Semantic.DeepEqual(oldPod.Labels, pod.Labels) {
+		return nil
+	}
+	if err := client.Patch(ctx, pod, ctrlruntimeclient.MergeFrom(oldPod)); err != nil {
+		return fmt.Errorf("failed to patch labels of pod: %w", err)
+	}
+	return nil
+}
+
+// podQOSClass returns the quality of service class of the given pod. The API
+// server sets it in the pod status, it is computed from the resources of the
+// containers like Kubernetes does if it is missing.
+func podQOSClass(pod *corev1.Pod) corev1.PodQOSClass {
+	if pod.Status.QOSClass != "" {
+		return pod.Status.QOSClass
+	}
+
+	requests, limits := corev1.ResourceList{}, corev1.ResourceList{}
+	guaranteed := true
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		for name, quantity := range container.Resources.Requests {
+			if (name == corev1.ResourceCPU || name == corev1.ResourceMemory) && !quantity.IsZero() {
+				requests[name] = quantity
+			}
+		}
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			limit, ok := container.Resources.Limits[name]
+			if !ok || limit.IsZero() {
+				guaranteed = false
+				continue
+			}
+			limits[name] = limit
+			// Requests default to limits if they aren't set.
+			if request, ok := container.Resources.Requests[name]; ok && request.Cmp(limit) != 0 {
+				guaranteed = false
+			}
+		}
+	}
+
+	if len(requests) == 0 && len(limits) == 0 {
+		return corev1.PodQOSBestEffort
+	}
+	if guaranteed {
+		return corev1.PodQOSGuaranteed
+	}
+	return corev1.PodQOSBurstable
+}
+
+// validatePodReferences checks that all secrets and configmaps that the pod
+// requires exist in its namespace. Optional references are ignored. Missing
+// resources are reported as a BadRequest so the job gets errored instead of
+// being retried.
+func validatePodReferences(ctx context.Context, reader ctrlruntimeclient.Reader, pod *corev1.Pod) error {
+	secrets, configMaps := sets.New[string](), sets.New[string]()
+	isRequired := func(optional *bool) bool { return optional == nil || !*optional }
+
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Secret != nil && isRequired(volume.Secret.Optional) {
+			secrets.Insert(volume.Secret.SecretName)
+		}
+		if volume.ConfigMap != nil && isRequired(volume.ConfigMap.Optional) {
+			configMaps.Insert(volume.ConfigMap.Name)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil && isRequired(source.Secret.Optional) {
+					secrets.Insert(source.Secret.Name)
+				}
+				if source.ConfigMap != nil && isRequired(source.ConfigMap.Optional) {
+					configMaps.Insert(source.ConfigMap.Name)
+				}
+			}
+		}
+	}
+	for _, container := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil && isRequired(envFrom.SecretRef.Optional) {
+				secrets.Insert(envFrom.SecretRef.Name)
+			}
+			if envFrom.ConfigMapRef != nil && isRequired(envFrom.ConfigMapRef.Optional) {
+				configMaps.Insert(envFrom.ConfigMapRef.Name)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if ref := env.ValueFrom.SecretKeyRef; ref != nil && isRequired(ref.Optional) {
+				secrets.Insert(ref.Name)
+			}
+			if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil && isRequired(ref.Optional) {
+				configMaps.Insert(ref.Name)
+			}
+		}
+	}
+
+	var missing []string
+	for _, name := range sets.List(secrets) {
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: name}, &corev1.Secret{}); err != nil {
+			if !kerrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get secret %s/%s: %w", pod.Namespace, name, err)
+			}
+			missing = append(missing, fmt.Sprintf("secret %q", name))
+		}
+	}
+	for _, name := range sets.List(configMaps) {
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: name}, &corev1.ConfigMap{}); err != nil {
+			if !kerrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get configmap %s/%s: %w", pod.Namespace, name, err)
+			}
+			missing = append(missing, fmt.Sprintf("configmap %q", name))
+		}
+	}
+	if len(missing) > 0 {
+		return kerrors.NewBadRequest(fmt.Sprintf("pod %s/%s references nonexistent resources: %s", pod.Namespace, pod.Name, strings.Join(missing, ", ")))
+	}
+	return nil
+}
+
 func (r *reconciler) getBuildID(name string) (string, error) {
 	return pjutil.GetBuildID(name, r.totURL)
 }
@@ -911,6 +2271,24 @@ func (r *reconciler) canExecuteConcurrently(ctx context.Context, pj *prowv1.Prow
 
 		if running := len(pjs.Items); running >= max {
 			r.log.WithFields(pjutil.ProwJobFields(pj)).Infof("Not starting another job, already %d running.", running)
+			recordConcurrencyBlocked(pj, concurrencyBlockedMaxConcurrency)
+			return false, nil
+		}
+	}
+
+	if canExecute, err := r.canExecuteConcurrentlyPerCluster(ctx, pj); err != nil || !canExecute {
+		return canExecute, err
+	}
+
+	if max := r.config().Plank.GlobalMaxRunningPods; max > 0 {
+		running, err := r.countRunningPods(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		if running >= max {
+			r.log.WithFields(pjutil.ProwJobFields(pj)).Infof("Not starting another job, already %d pods running across all build clusters.", running)
+			recordConcurrencyBlocked(pj, concurrencyBlockedGlobalMaxRunningPods)
 			return false, nil
 		}
 	}
@@ -922,6 +2300,48 @@ func (r *reconciler) canExecuteConcurrently(ctx context.Context, pj *prowv1.Prow
 	return r.canExecuteConcurrentlyPerQueue(ctx, pj)
 }
 
+// countRunningPods counts the pods created by Prow that didn't finish yet
+// across all build clusters.
+func (r *reconciler) countRunningPods(ctx context.Context) (int, error) {
+	var running int
+	for cluster, client := range r.buildClients {
+		pods := &corev1.PodList{}
+		if err := client.List(ctx, pods, ctrlruntimeclient.InNamespace(r.config().PodNamespace), ctrlruntimeclient.MatchingLabels{kube.CreatedByProw: "true"}); err != nil {
+			return 0, fmt.Errorf("failed to list pods in cluster %s: %w", cluster, err)
+		}
+		for _, pod := range pods.Items {
+			if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+				running++
+			}
+		}
+	}
+	return running, nil
+}
+
+func (r *reconciler) canExecuteConcurrentlyPerCluster(ctx context.Context, pj *prowv1.ProwJob) (bool, error) {
+	cluster := pj.ClusterAlias()
+	max := r.config().Plank.ClusterMaxConcurrency[cluster]
+	if max <= 0 {
+		return true, nil
+	}
+
+	pjs := &prowv1.ProwJobList{}
+	if err := r.pjClient.List(ctx, pjs, optPendingTriggeredJobsInCluster(cluster)); err != nil {
+		return false, fmt.Errorf("failed listing prowjobs in cluster %s: %w", cluster, err)
+	}
+
+	pendingOrOlderMatchingPJs := countPendingOrOlderTriggeredMatchingPJs(*pj, pjs.Items)
+	if pendingOrOlderMatchingPJs >= max {
+		r.log.WithFields(pjutil.ProwJobFields(pj)).
+			Debugf("Not starting another instance of %s, have %d instances in cluster %s that are pending or older, %d is the limit",
+				pj.Spec.Job, pendingOrOlderMatchingPJs, cluster, max)
+		recordConcurrencyBlocked(pj, concurrencyBlockedClusterMaxConcurrency)
+		return false, nil
+	}
+
+	return true, nil
+}
+
 func (r *reconciler) canExecuteConcurrentlyPerJob(ctx context.Context, pj *prowv1.ProwJob) (bool, error) {
 	if pj.Spec.MaxConcurrency == 0 {
 		return true, nil
@@ -938,6 +2358,7 @@ func (r *reconciler) canExecuteConcurrentlyPerJob(ctx context.Context, pj *prowv
 		r.log.WithFields(pjutil.ProwJobFields(pj)).
 			Debugf("Not starting another instance of %s, have %d instances that are pending or older, %d is the limit",
 				pj.Spec.Job, pendingOrOlderMatchingPJs, pj.Spec.MaxConcurrency)
+		recordConcurrencyBlocked(pj, concurrencyBlockedJobMaxConcurrency)
 		return false, nil
 	}
 
@@ -955,6 +2376,7 @@ func (r *reconciler) canExecuteConcurrentlyPerQueue(ctx context.Context, pj *pro
 		return false, fmt.Errorf("failed to match queue name '%s' with Plank configuration", queueName)
 	}
 	if queueConcurrency == 0 {
+		recordConcurrencyBlocked(pj, concurrencyBlockedJobQueueCapacity)
 		return false, nil
 	}
 	if queueConcurrency < 0 {
@@ -972,12 +2394,19 @@ func (r *reconciler) canExecuteConcurrentlyPerQueue(ctx context.Context, pj *pro
 		r.log.WithFields(pjutil.ProwJobFields(pj)).
 			Debugf("Not starting another instance of %s, have %d instances in queue %s that are pending or older, %d is the limit",
 				pj.Spec.Job, pendingOrOlderMatchingPJs, queueName, queueConcurrency)
+		recordConcurrencyBlocked(pj, concurrencyBlockedJobQueueCapacity)
 		return false, nil
 	}
 
 	return true, nil
 }
 
+// recordConcurrencyBlocked counts that the job was held back by the
+// concurrency limit of the given reason.
+func recordConcurrencyBlocked(pj *prowv1.ProwJob, reason string) {
+	plankMetrics.concurrencyBlocked.WithLabelValues(pj.Spec.Job, pj.Spec.JobQueueName, reason).Inc()
+}
+
 func prowJobPredicate(callback func(bool)) predicate.Predicate {
 	return predicate.NewPredicateFuncs(func(o ctrlruntimeclient.Object) bool {
 		result := func() bool {
@@ -1001,7 +2430,9 @@ func prowJobPredicate(callback func(bool)) predicate.Predicate {
 	})
 }
 
-func podPredicate(additionalSelector string, callback func(bool)) (predicate.TypedPredicate[*corev1.Pod], error) {
+// podSelector returns the selector for the pods plank manages, which are the
+// ones created by prow that match additionalSelector.
+func podSelector(additionalSelector string) (labels.Selector, error) {
 	rawSelector := fmt.Sprintf("%s=true", kube.CreatedByProw)
 	if additionalSelector != "" {
 		rawSelector = fmt.Sprintf("%s,%s", rawSelector, additionalSelector)
@@ -1010,14 +2441,42 @@ func podPredicate(additionalSelector string, callback func(bool)) (predicate.Typ
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse label selector %s: %w", rawSelector, err)
 	}
+	return selector, nil
+}
 
+func podPredicate(selector labels.Selector, callback func(bool)) predicate.TypedPredicate[*corev1.Pod] {
 	return predicate.NewTypedPredicateFuncs(func(pod *corev1.Pod) bool {
 		result := selector.Matches(labels.Set(pod.GetLabels()))
 		if callback != nil {
 			callback(result)
 		}
 		return result
-	}), nil
+	})
+}
+
+// podEventHandler enqueues the ProwJobs of pods, debouncing pod updates per
+// the configuration.
+func podEventHandler(cfg config.Getter) handler.TypedEventHandler[*corev1.Pod, reconcile.Request] {
+	prowJobNamespace := cfg().ProwJobNamespace
+	mapper := podEventRequestMapper(prowJobNamespace)
+	return handler.TypedFuncs[*corev1.Pod, reconcile.Request]{
+		CreateFunc: mapper.Create,
+		UpdateFunc: func(ctx context.Context, e event.TypedUpdateEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			debounce := cfg().Plank.PodUpdateDebounce
+			if debounce == nil || debounce.Duration <= 0 {
+				mapper.Update(ctx, e, q)
+				return
+			}
+			// The queue keeps the earliest time an item is added for, so all
+			// updates within the window are coalesced into a single reconcile.
+			q.AddAfter(reconcile.Request{NamespacedName: ctrlruntimeclient.ObjectKey{
+				Namespace: prowJobNamespace,
+				Name:      e.ObjectNew.GetName(),
+			}}, debounce.Duration)
+		},
+		DeleteFunc:  mapper.Delete,
+		GenericFunc: mapper.Generic,
+	}
 }
 
 func podEventRequestMapper(prowJobNamespace string) handler.TypedEventHandler[*corev1.Pod, reconcile.Request] {
@@ -1046,10 +2505,18 @@ func pendingTriggeredIndexKeyByName(jobName string) string {
 	return fmt.Sprintf("pending-triggered-named-%s", jobName)
 }
 
+func periodicIndexKeyByName(jobName string) string {
+	return fmt.Sprintf("periodic-named-%s", jobName)
+}
+
 func pendingTriggeredIndexKeyByJobQueueName(jobQueueName string) string {
 	return fmt.Sprintf("pending-triggered-with-job-queue-name-%s", jobQueueName)
 }
 
+func pendingTriggeredIndexKeyByCluster(cluster string) string {
+	return fmt.Sprintf("pending-triggered-in-cluster-%s", cluster)
+}
+
 func prowJobIndexer(prowJobNamespace string) ctrlruntimeclient.IndexerFunc {
 	return func(o ctrlruntimeclient.Object) []string {
 		pj := o.(*prowv1.ProwJob)
@@ -1063,8 +2530,13 @@ func prowJobIndexer(prowJobNamespace string) ctrlruntimeclient.IndexerFunc {
 			indexes = append(indexes, prowJobIndexKeyPending)
 		}
 
+		if pj.Spec.Type == prowv1.PeriodicJob {
+			indexes = append(indexes, periodicIndexKeyByName(pj.Spec.Job))
+		}
+
 		if pj.Status.State == prowv1.PendingState || pj.Status.State == prowv1.TriggeredState {
 			indexes = append(indexes, pendingTriggeredIndexKeyByName(pj.Spec.Job))
+			indexes = append(indexes, pendingTriggeredIndexKeyByCluster(pj.ClusterAlias()))
 
 			if pj.Spec.JobQueueName != "" {
 				indexes = append(indexes, pendingTriggeredIndexKeyByJobQueueName(pj.Spec.JobQueueName))
@@ -1087,10 +2559,18 @@ func optPendingTriggeredJobsNamed(name string) ctrlruntimeclient.ListOption {
 	return ctrlruntimeclient.MatchingFields{prowJobIndexName: pendingTriggeredIndexKeyByName(name)}
 }
 
+func optPeriodicsNamed(name string) ctrlruntimeclient.ListOption {
+	return ctrlruntimeclient.MatchingFields{prowJobIndexName: periodicIndexKeyByName(name)}
+}
+
 func optPendingTriggeredJobsInQueue(queueName string) ctrlruntimeclient.ListOption {
 	return ctrlruntimeclient.MatchingFields{prowJobIndexName: pendingTriggeredIndexKeyByJobQueueName(queueName)}
 }
 
+func optPendingTriggeredJobsInCluster(cluster string) ctrlruntimeclient.ListOption {
+	return ctrlruntimeclient.MatchingFields{prowJobIndexName: pendingTriggeredIndexKeyByCluster(cluster)}
+}
+
 func didPodSucceed(p *corev1.Pod) bool {
 	if p.Status.Phase != corev1.PodSucceeded {
 		return false
@@ -1104,6 +2584,58 @@ func didPodSucceed(p *corev1.Pod) bool {
 	return true
 }
 
+// wasOOMKilled returns whether a container of the pod got terminated because
+// it ran out of memory.
+func wasOOMKilled(p *corev1.Pod) bool {
+	for _, container := range append(p.Status.ContainerStatuses, p.Status.InitContainerStatuses...) {
+		if container.State.Terminated != nil && container.State.Terminated.Reason == OOMKilled {
+			return true
+		}
+	}
+	return false
+}
+
+// failingImagePull returns a container of the pod that is waiting because
+// pulling its image failed, if any.
+func failingImagePull(p *corev1.Pod) (corev1.ContainerStatus, bool) {
+	for _, container := range append(p.Status.InitContainerStatuses, p.Status.ContainerStatuses...) {
+		if container.State.Waiting == nil {
+			continue
+		}
+		if reason := container.State.Waiting.Reason; reason == "ImagePullBackOff" || reason == "ErrImagePull" {
+			return container, true
+		}
+	}
+	return corev1.ContainerStatus{}, false
+}
+
+// isPodInfoUploadPending returns whether the pod finished but crier still has
+// to upload its pod info, which it signals by removing its finalizer.
+func isPodInfoUploadPending(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+		return false
+	}
+	return pod.DeletionTimestamp == nil && sets.New(pod.Finalizers...).Has(kubernetesreporterapi.FinalizerName)
+}
+
+// didTestContainersSucceed returns whether all containers of the pod except for
+// the pod utilities ones terminated successfully, regardless of the pod phase.
+func didTestContainersSucceed(p *corev1.Pod) bool {
+	podUtils := decorate.PodUtilsContainerNames()
+	var found bool
+	for _, container := range p.Status.ContainerStatuses {
+		if podUtils.Has(container.Name) {
+			continue
+		}
+		if container.State.Terminated == nil || container.State.Terminated.ExitCode != 0 || container.State.Terminated.FinishedAt.IsZero() {
+			return false
+		}
+		found = true
+	}
+
+	return found
+}
+
 func getPodBuildID(pod *corev1.Pod) string {
 	if buildID, ok := pod.ObjectMeta.Labels[kube.ProwBuildIDLabel]; ok && buildID != "" {
 		return buildID
@@ -1120,9 +2652,28 @@ func getPodBuildID(pod *corev1.Pod) string {
 	return ""
 }
 
+// setErrorReason records why plank errored the job.
+func setErrorReason(pj *prowv1.ProwJob, reason string) {
+	if pj.Annotations == nil {
+		pj.Annotations = map[string]string{}
+	}
+	pj.Annotations[kube.ErrorReasonAnnotation] = reason
+}
+
+// isQuotaExceededError returns true if the error was caused by a write that
+// got rejected because it would exceed a resource quota.
+func isQuotaExceededError(err error) bool {
+	return kerrors.IsForbidden(err) && strings.Contains(err.Error(), "exceeded quota")
+}
+
 // isRequestError extracts an HTTP status code from a kerrors.APIStatus and
-// returns true if it is a 4xx error.
+// returns true if it is a 4xx error. Transient errors are never request
+// errors, even if the API server reports them with a 4xx code, so that they
+// get retried instead of erroring the job.
 func isRequestError(err error) bool {
+	if isTransientError(err) {
+		return false
+	}
 	var code int32 = 500 // This is what kerrors.ReasonForError() defaults to.
 	if status := kerrors.APIStatus(nil); errors.As(err, &status) {
 		code = status.Status().Code
@@ -1130,6 +2681,22 @@ func isRequestError(err error) bool {
 	return 400 <= code && code < 500
 }
 
+// isTransientError returns true if the error is likely to go away when
+// retrying, like server side errors, e.g. etcd request timeouts, throttling
+// and connections that got reset or timed out.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, syscall.ECONNRESET) || utilnet.IsConnectionReset(err) {
+		return true
+	}
+	if kerrors.IsTooManyRequests(err) || kerrors.IsServerTimeout(err) || kerrors.IsTimeout(err) {
+		return true
+	}
+	if status := kerrors.APIStatus(nil); errors.As(err, &status) {
+		return status.Status().Code >= 500
+	}
+	return false
+}
+
 func countPendingOrOlderTriggeredMatchingPJs(pj prowv1.ProwJob, pjs []prowv1.ProwJob) int {
 	var pendingOrOlderTriggeredMatchingPJs int
 
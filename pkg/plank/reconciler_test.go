@@ -333,6 +333,7 @@ func TestProwJobIndexer(t *testing.T) {
 				prowJobIndexKeyAll,
 				prowJobIndexKeyPending,
 				pendingTriggeredIndexKeyByName(pjName),
+				pendingTriggeredIndexKeyByCluster(prowv1.DefaultClusterAlias),
 				pendingTriggeredIndexKeyByJobQueueName(pjJobQueue),
 			},
 		},
@@ -342,6 +343,18 @@ func TestProwJobIndexer(t *testing.T) {
 			expected: []string{
 				prowJobIndexKeyAll,
 				pendingTriggeredIndexKeyByName(pjName),
+				pendingTriggeredIndexKeyByCluster(prowv1.DefaultClusterAlias),
+				pendingTriggeredIndexKeyByJobQueueName(pjJobQueue),
+			},
+		},
+		{
+			name:   "Changing cluster changes pendingTriggeredIndexKeyByCluster index",
+			modify: func(pj *prowv1.ProwJob) { pj.Spec.Cluster = "trusted" },
+			expected: []string{
+				prowJobIndexKeyAll,
+				prowJobIndexKeyPending,
+				pendingTriggeredIndexKeyByName(pjName),
+				pendingTriggeredIndexKeyByCluster("trusted"),
 				pendingTriggeredIndexKeyByJobQueueName(pjJobQueue),
 			},
 		},
@@ -365,9 +378,21 @@ func TestProwJobIndexer(t *testing.T) {
 				prowJobIndexKeyAll,
 				prowJobIndexKeyPending,
 				pendingTriggeredIndexKeyByName("some-name"),
+				pendingTriggeredIndexKeyByCluster(prowv1.DefaultClusterAlias),
 				pendingTriggeredIndexKeyByJobQueueName(pjJobQueue),
 			},
 		},
+		{
+			name: "Completed periodic matches the periodicIndexKeyByName index",
+			modify: func(pj *prowv1.ProwJob) {
+				pj.Spec.Type = prowv1.PeriodicJob
+				pj.Status.State = prowv1.SuccessState
+			},
+			expected: []string{
+				prowJobIndexKeyAll,
+				periodicIndexKeyByName(pjName),
+			},
+		},
 		{
 			name:   "Changing job queue name changes pendingTriggeredIndexKeyByJobQueueName index",
 			modify: func(pj *prowv1.ProwJob) { pj.Spec.JobQueueName = "some-name" },
@@ -375,6 +400,7 @@ func TestProwJobIndexer(t *testing.T) {
 				prowJobIndexKeyAll,
 				prowJobIndexKeyPending,
 				pendingTriggeredIndexKeyByName(pjName),
+				pendingTriggeredIndexKeyByCluster(prowv1.DefaultClusterAlias),
 				pendingTriggeredIndexKeyByJobQueueName("some-name"),
 			},
 		},
@@ -430,7 +456,7 @@ func TestMaxConcurrencyConsidersCacheStaleness(t *testing.T) {
 			}
 			pjClient := &eventuallyConsistentClient{t: t, Client: fakeMgr.GetClient()}
 
-			r := newReconciler(context.Background(), pjClient, nil, cfg, nil, "")
+			r := newReconciler(context.Background(), pjClient, nil, cfg, nil, "", nil)
 			r.buildClients = map[string]buildClient{pja.Spec.Cluster: {Client: fakectrlruntimeclient.NewClientBuilder().Build()}}
 
 			wg := &sync.WaitGroup{}
@@ -608,6 +634,7 @@ func TestSyncClusterStatus(t *testing.T) {
 		expectedStatuses map[string]ClusterStatus // This is set to statuses ^^ if unspecified.
 		knownClusters    map[string]rest.Config
 		noWriteExpected  bool
+		recordPodNode    bool
 	}{
 		{
 			name:            "No location set, don't upload.",
@@ -651,6 +678,19 @@ func TestSyncClusterStatus(t *testing.T) {
 				"cluster-missing-permissions": {},
 			},
 		},
+		{
+			name:     "Missing node permissions when recording pod nodes",
+			location: "gs://my-bucket/build-cluster-statuses.json",
+			statuses: map[string]ClusterStatus{
+				"default":                          ClusterStatusReachable,
+				"cluster-missing-node-permissions": ClusterStatusMissingPermissions,
+			},
+			knownClusters: map[string]rest.Config{
+				"default":                          {},
+				"cluster-missing-node-permissions": {},
+			},
+			recordPodNode: true,
+		},
 	}
 	successfulFakeClient := &k8sFake.Clientset{}
 	successfulFakeClient.Fake.AddReactor("create", "selfsubjectaccessreviews", func(action k8sTesting.Action) (handled bool, ret runtime.Object, err error) {
@@ -680,6 +720,18 @@ func TestSyncClusterStatus(t *testing.T) {
 		return true, r, nil
 	})
 
+	missingNodePermissionsFakeClient := &k8sFake.Clientset{}
+	missingNodePermissionsFakeClient.Fake.AddReactor("create", "selfsubjectaccessreviews", func(action k8sTesting.Action) (handled bool, ret runtime.Object, err error) {
+		ssar := action.(k8sTesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		allowed := ssar.Spec.ResourceAttributes.Resource != "nodes"
+		r := &authorizationv1.SelfSubjectAccessReview{
+			Status: authorizationv1.SubjectAccessReviewStatus{
+				Allowed: allowed,
+			},
+		}
+		return true, r, nil
+	})
+
 	// Whether the authz client runs successfully or not depends on the use of
 	// the plain FakeAuthorizationV1 (always success) or erroringFakeAuthzClient
 	// (always fail).
@@ -688,7 +740,7 @@ func TestSyncClusterStatus(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			cfg := func() *config.Config {
-				return &config.Config{ProwConfig: config.ProwConfig{Plank: config.Plank{BuildClusterStatusFile: tc.location}}}
+				return &config.Config{ProwConfig: config.ProwConfig{Plank: config.Plank{BuildClusterStatusFile: tc.location, RecordPodNode: tc.recordPodNode}}}
 			}
 
 			clients := map[string]buildClient{}
@@ -705,9 +757,13 @@ func TestSyncClusterStatus(t *testing.T) {
 						ssar:   erroringFakeClient.AuthorizationV1().SelfSubjectAccessReviews(),
 					}
 				case ClusterStatusMissingPermissions:
+					ssar := missingPermissionsFakeClient.AuthorizationV1().SelfSubjectAccessReviews()
+					if tc.recordPodNode {
+						ssar = missingNodePermissionsFakeClient.AuthorizationV1().SelfSubjectAccessReviews()
+					}
 					clients[alias] = buildClient{
 						Client: fakectrlruntimeclient.NewClientBuilder().Build(),
-						ssar:   missingPermissionsFakeClient.AuthorizationV1().SelfSubjectAccessReviews(),
+						ssar:   ssar,
 					}
 				}
 			}
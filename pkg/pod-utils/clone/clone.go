@@ -157,22 +157,24 @@ type gitCtx struct {
 	repositoryURI string
 }
 
-// gitCtxForRefs creates a gitCtx based on the provide refs and baseDir.
-func gitCtxForRefs(refs prowapi.Refs, baseDir string, env []string, user, token string) gitCtx {
-	var repoURI string
+// RepositoryURIForRefs returns the URI that the repository described by the
+// refs is cloned from, without any credentials.
+func RepositoryURIForRefs(refs prowapi.Refs) string {
+	if refs.CloneURI != "" {
+		return refs.CloneURI
+	}
 	if refs.RepoLink != "" {
-		repoURI = fmt.Sprintf("%s.git", refs.RepoLink)
-	} else {
-		repoURI = fmt.Sprintf("https://%s/%s/%s.git", github.DefaultHost, refs.Org, refs.Repo)
+		return fmt.Sprintf("%s.git", refs.RepoLink)
 	}
+	return fmt.Sprintf("https://%s/%s/%s.git", github.DefaultHost, refs.Org, refs.Repo)
+}
 
+// gitCtxForRefs creates a gitCtx based on the provide refs and baseDir.
+func gitCtxForRefs(refs prowapi.Refs, baseDir string, env []string, user, token string) gitCtx {
 	g := gitCtx{
 		cloneDir:      PathForRefs(baseDir, refs),
 		env:           env,
-		repositoryURI: repoURI,
-	}
-	if refs.CloneURI != "" {
-		g.repositoryURI = refs.CloneURI
+		repositoryURI: RepositoryURIForRefs(refs),
 	}
 
 	if token != "" {
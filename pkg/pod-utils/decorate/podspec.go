@@ -130,6 +130,10 @@ func LabelsAndAnnotationsForSpec(spec prowapi.ProwJobSpec, extraLabels, extraAnn
 		annotations[key] = value
 	}
 
+	if spec.JobQueueName != "" {
+		labels[kube.JobQueueLabel] = spec.JobQueueName
+	}
+
 	var refs *prowapi.Refs
 	if spec.Refs != nil {
 		refs = spec.Refs
@@ -852,6 +856,17 @@ func decorate(spec *coreapi.PodSpec, pj *prowapi.ProwJob, rawEnv map[string]stri
 		}
 	}
 
+	if pj.Spec.DecorationConfig != nil && len(pj.Spec.DecorationConfig.DefaultNodeSelector) > 0 {
+		if spec.NodeSelector == nil {
+			spec.NodeSelector = map[string]string{}
+		}
+		for key, value := range pj.Spec.DecorationConfig.DefaultNodeSelector {
+			if _, ok := spec.NodeSelector[key]; !ok {
+				spec.NodeSelector[key] = value
+			}
+		}
+	}
+
 	if pj.Spec.DecorationConfig != nil && pj.Spec.DecorationConfig.SetLimitEqualsMemoryRequest != nil && *pj.Spec.DecorationConfig.SetLimitEqualsMemoryRequest {
 		for i, container := range spec.Containers {
 			if container.Resources.Requests == nil {
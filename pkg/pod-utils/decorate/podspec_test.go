@@ -1364,6 +1364,46 @@ func TestProwJobToPod_setsTerminationGracePeriodSeconds(t *testing.T) {
 	}
 }
 
+func TestDecorate_mergesDefaultNodeSelector(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		nodeSelector         map[string]string
+		expectedNodeSelector map[string]string
+	}{
+		{
+			name:                 "job without node selector gets the default one",
+			expectedNodeSelector: map[string]string{"node-pool": "spot", "arch": "amd64"},
+		},
+		{
+			name:                 "job keeps its own value for keys it selects on",
+			nodeSelector:         map[string]string{"node-pool": "on-demand", "disk": "ssd"},
+			expectedNodeSelector: map[string]string{"node-pool": "on-demand", "disk": "ssd", "arch": "amd64"},
+		},
+	}
+
+	for idx := range testCases {
+		tc := testCases[idx]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			pj := prowapi.ProwJob{
+				Spec: prowapi.ProwJobSpec{
+					PodSpec: &coreapi.PodSpec{NodeSelector: tc.nodeSelector, Containers: []coreapi.Container{{}}},
+					DecorationConfig: &prowapi.DecorationConfig{
+						UtilityImages:       &prowapi.UtilityImages{},
+						DefaultNodeSelector: map[string]string{"node-pool": "spot", "arch": "amd64"},
+					},
+				},
+			}
+			if err := decorate(pj.Spec.PodSpec, &pj, map[string]string{}, ""); err != nil {
+				t.Fatalf("decoration failed: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(tc.expectedNodeSelector, pj.Spec.PodSpec.NodeSelector) {
+				t.Errorf("unexpected node selector:\n%s", diff.ObjectReflectDiff(tc.expectedNodeSelector, pj.Spec.PodSpec.NodeSelector))
+			}
+		})
+	}
+}
+
 func TestSidecar(t *testing.T) {
 	var testCases = []struct {
 		name                                    string